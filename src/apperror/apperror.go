@@ -0,0 +1,127 @@
+package apperror
+
+import "github.com/gofiber/fiber/v2"
+
+// Kind classifies domain errors by the way clients should handle them
+type Kind int
+
+const (
+	// KindBadRequest is a malformed request, such as an unknown query parameter value
+	KindBadRequest Kind = iota + 1
+	// KindNotFound is a missing resource
+	KindNotFound
+	// KindValidation is a well-formed request whose data breaks a rule of the domain
+	KindValidation
+	// KindConflict is a request that clashes with the current state of a resource
+	KindConflict
+	// KindPreconditionFailed is a conditional request whose precondition does not hold,
+	// such as an If-Match header naming an outdated version
+	KindPreconditionFailed
+	// KindPreconditionRequired is a request that has to be made conditional
+	KindPreconditionRequired
+)
+
+// Status returns the HTTP status code of the kind
+func (k Kind) Status() int {
+	switch k {
+	case KindBadRequest:
+		return fiber.StatusBadRequest
+	case KindNotFound:
+		return fiber.StatusNotFound
+	case KindValidation:
+		return fiber.StatusUnprocessableEntity
+	case KindConflict:
+		return fiber.StatusConflict
+	case KindPreconditionFailed:
+		return fiber.StatusPreconditionFailed
+	case KindPreconditionRequired:
+		return fiber.StatusPreconditionRequired
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
+
+// FieldError describes why a single request field is invalid
+type FieldError struct {
+	Field   string   `json:"field"`
+	Message string   `json:"message"`
+	Allowed []string `json:"allowed,omitempty"`
+}
+
+// Reference identifies another record involved in an error, such as a book blocking a delete
+type Reference struct {
+	Type  string `json:"type"`
+	ID    uint   `json:"id"`
+	Title string `json:"title,omitempty"`
+}
+
+// Error is a domain error of a known Kind
+type Error struct {
+	Kind       Kind
+	Message    string
+	Fields     []FieldError
+	References []Reference
+	// Err is the underlying error, if any
+	Err error
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the underlying error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// NotFound creates a KindNotFound error
+func NotFound(message string) *Error {
+	return &Error{Kind: KindNotFound, Message: message}
+}
+
+// Conflict creates a KindConflict error
+func Conflict(message string) *Error {
+	return &Error{Kind: KindConflict, Message: message}
+}
+
+// InUse creates a KindConflict error listing the records that still refer to a resource
+func InUse(message string, references []Reference) *Error {
+	return &Error{Kind: KindConflict, Message: message, References: references}
+}
+
+// PreconditionFailed creates a KindPreconditionFailed error
+func PreconditionFailed(message string) *Error {
+	return &Error{Kind: KindPreconditionFailed, Message: message}
+}
+
+// PreconditionRequired creates a KindPreconditionRequired error
+func PreconditionRequired(message string) *Error {
+	return &Error{Kind: KindPreconditionRequired, Message: message}
+}
+
+// Validation creates a KindValidation error about field
+func Validation(field string, message string) *Error {
+	return &Error{Kind: KindValidation, Message: message, Fields: []FieldError{{Field: field, Message: message}}}
+}
+
+// InvalidField creates a KindValidation error about field wrapping err
+func InvalidField(field string, err error) *Error {
+	invalid := Validation(field, err.Error())
+	invalid.Err = err
+	return invalid
+}
+
+// allowedValues is implemented by errors that know the values a field accepts
+type allowedValues interface {
+	AllowedValues() []string
+}
+
+// BadRequest creates a KindBadRequest error about the request parameter field wrapping err
+func BadRequest(field string, err error) *Error {
+	fieldError := FieldError{Field: field, Message: err.Error()}
+	if allowed, ok := err.(allowedValues); ok {
+		fieldError.Allowed = allowed.AllowedValues()
+	}
+	return &Error{Kind: KindBadRequest, Message: err.Error(), Fields: []FieldError{fieldError}, Err: err}
+}
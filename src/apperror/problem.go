@@ -0,0 +1,53 @@
+package apperror
+
+import (
+	"errors"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+// ProblemContentType is the media type of Problem responses
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details response
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Errors   []FieldError `json:"errors,omitempty"`
+	// References lists the records that caused the problem, e.g. the books blocking a delete
+	References []Reference `json:"references,omitempty"`
+}
+
+// Handler is the fiber.Config ErrorHandler that renders every error returned by a handler
+// as a Problem: domain errors by their Kind, fiber errors by their code and anything else as 500
+func Handler(c *fiber.Ctx, err error) error {
+	problem := Problem{
+		Type:     "about:blank",
+		Status:   fiber.StatusInternalServerError,
+		Instance: c.OriginalURL(),
+	}
+
+	var appErr *Error
+	var fiberErr *fiber.Error
+	switch {
+	case errors.As(err, &appErr):
+		problem.Status = appErr.Kind.Status()
+		problem.Detail = appErr.Message
+		problem.Errors = appErr.Fields
+		problem.References = appErr.References
+	case errors.As(err, &fiberErr):
+		problem.Status = fiberErr.Code
+		problem.Detail = fiberErr.Message
+	default:
+		// Do not leak internal details to clients
+		log.Printf("%s %s: %v", c.Method(), c.OriginalURL(), err)
+	}
+	problem.Title = utils.StatusMessage(problem.Status)
+
+	return c.Status(problem.Status).JSON(problem, ProblemContentType)
+}
@@ -1,5 +1,11 @@
 package author
 
+import (
+	"time"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+)
+
 // AuthorRequest represents the request body for creating an author
 type AuthorRequest struct {
 	Name        string `json:"name" validate:"required"`
@@ -12,18 +18,24 @@ type AuthorCreateResponse struct {
 
 type AuthorDetailResponse struct {
 	ID          uint   `json:"id"`
+	Version     uint   `json:"version"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
 }
 
-// AuthorResponse represents the response for author endpoints
-type AuthorListResponse struct {
-	Result   []AuthorDTO `json:"result"`
-	Pages    uint        `json:"pages"`
-	Elements uint64      `json:"elements"`
-}
+// AuthorListResponse represents a page of authors
+type AuthorListResponse = pagination.Page[AuthorDTO]
 
 type AuthorDTO struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
 }
+
+// AuthorTrashResponse represents a deleted author in the trash
+type AuthorTrashResponse struct {
+	AuthorDTO
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// AuthorTrashListResponse represents a page of deleted authors
+type AuthorTrashListResponse = pagination.Page[AuthorTrashResponse]
@@ -0,0 +1,15 @@
+package author
+
+import "github.com/tedysaputro/book-catalog-with-go/src/apperror"
+
+var (
+	// ErrAuthorNotFound is returned when no live author has the requested ID
+	ErrAuthorNotFound = apperror.NotFound("author not found")
+	// ErrAuthorNotInTrash is returned when no deleted author has the requested ID
+	ErrAuthorNotInTrash = apperror.NotFound("author not found in trash")
+	// ErrReassignTargetInvalid is returned when the books of a deleted author are reassigned
+	// to the same author or to one that does not exist
+	ErrReassignTargetInvalid = apperror.Validation("reassign_to", "reassign_to must be the ID of another live author")
+	// ErrNameRequired is returned when an author has no name
+	ErrNameRequired = apperror.Validation("name", "name is required")
+)
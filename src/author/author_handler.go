@@ -2,6 +2,14 @@ package author
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/deletion"
+	"github.com/tedysaputro/book-catalog-with-go/src/fuzzy"
+	"github.com/tedysaputro/book-catalog-with-go/src/history"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/patch"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+	"github.com/tedysaputro/book-catalog-with-go/src/version"
 )
 
 // AuthorHandler handles HTTP requests for author operations
@@ -18,22 +26,12 @@ func NewAuthorHandler(service AuthorService) *AuthorHandler {
 func (h *AuthorHandler) CreateAuthor(c *fiber.Ctx) error {
 	var request AuthorRequest
 	if err := c.BodyParser(&request); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
 	}
 
-	if request.Name == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Name is required",
-		})
-	}
-
-	dto, err := h.service.createAuthor(request)
+	dto, err := h.service.CreateAuthor(c.UserContext(), request)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(dto)
@@ -43,79 +41,208 @@ func (h *AuthorHandler) CreateAuthor(c *fiber.Ctx) error {
 func (h *AuthorHandler) UpdateAuthor(c *fiber.Ctx) error {
 	id, err := c.ParamsInt("id")
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid author ID",
-		})
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid author ID")
 	}
 
 	var request AuthorRequest
 	if err := c.BodyParser(&request); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
 	}
 
-	if request.Name == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Name is required",
-		})
+	match, err := version.IfMatch(c)
+	if err != nil {
+		return err
 	}
 
-	dto, err := h.service.UpdateAuthor(uint(id), request)
+	dto, err := h.service.UpdateAuthor(c.UserContext(), uint(id), request, match)
 	if err != nil {
-		if err.Error() == "author not found" {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": err.Error(),
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
 	}
 
+	c.Set(fiber.HeaderETag, version.ETag(dto.Version))
 	return c.JSON(dto)
 }
 
-// GetAuthor handles GET /authors/:id request
+// PatchAuthor handles PATCH /authors/:id request
+func (h *AuthorHandler) PatchAuthor(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid author ID")
+	}
+
+	p, err := patch.FromRequest(c)
+	if err != nil {
+		return err
+	}
+
+	match, err := version.IfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	dto, err := h.service.PatchAuthor(c.UserContext(), uint(id), p, match)
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderETag, version.ETag(dto.Version))
+	return c.JSON(dto)
+}
+
+// RevertAuthor handles POST /authors/:id/revert request
+func (h *AuthorHandler) RevertAuthor(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid author ID")
+	}
+
+	var request history.RevertRequest
+	if err := c.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	match, err := version.IfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	dto, err := h.service.RevertAuthor(c.UserContext(), uint(id), request.Version, match)
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderETag, version.ETag(dto.Version))
+	return c.JSON(dto)
+}
+
+// GetAuthor handles GET /authors/:id request, returning the state at as_of when given
 func (h *AuthorHandler) GetAuthor(c *fiber.Ctx) error {
 	id, err := c.ParamsInt("id")
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid author ID",
-		})
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid author ID")
 	}
 
-	dto, err := h.service.GetAuthor(uint(id))
+	at, ok, err := history.AsOf(c)
 	if err != nil {
-		if err.Error() == "author not found" {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": err.Error(),
-			})
+		return err
+	}
+	if ok {
+		dto, err := h.service.GetAuthorAsOf(c.UserContext(), uint(id), at)
+		if err != nil {
+			return err
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.JSON(dto)
 	}
 
-	return c.JSON(dto)
+	dto, err := h.service.GetAuthor(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return version.Send(c, dto.Version, dto)
 }
 
 // GetAuthors handles GET /authors request
 func (h *AuthorHandler) GetAuthors(c *fiber.Ctx) error {
-	page := uint(c.QueryInt("p", 1))
-	limit := uint(c.QueryInt("limit", 10))
-	sortBy := c.Query("sortBy", "id")
-	direction := c.Query("direction", "asc")
+	sort, err := sorting.FromQuery(c.Query("sort"), c.Query("sortBy"), c.Query("direction"), SortFields)
+	if err != nil {
+		return apperror.BadRequest("sort", err)
+	}
+	sort = sort.Stable(SortFields)
 	authorName := c.Query("authorName", "")
+	match := fuzzy.Options{
+		Enabled:   c.QueryBool("fuzzy"),
+		Threshold: c.QueryFloat("threshold", fuzzy.DefaultThreshold),
+	}
+	if err := match.Validate(); err != nil {
+		return apperror.BadRequest("threshold", err)
+	}
+	page, err := pagination.FromQuery(c, sort)
+	if err != nil {
+		return err
+	}
+	if match.Enabled && page.Cursor != nil {
+		return apperror.BadRequest("cursor", pagination.ErrCursorUnsupported)
+	}
 
-	authors, err := h.service.GetAuthors(page, limit, sortBy, direction, authorName)
+	authors, err := h.service.GetAuthors(c.UserContext(), page, sort, authorName, match)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
 	}
 
-	return c.JSON(authors)
+	return c.JSON(authors.WithLinks(c))
+}
+
+// GetDeletedAuthors handles GET /authors/trash request
+func (h *AuthorHandler) GetDeletedAuthors(c *fiber.Ctx) error {
+	sort, err := sorting.FromQuery(c.Query("sort"), c.Query("sortBy"), c.Query("direction"), SortFields)
+	if err != nil {
+		return apperror.BadRequest("sort", err)
+	}
+	sort = sort.Stable(SortFields)
+	page, err := pagination.FromQuery(c, sort)
+	if err != nil {
+		return err
+	}
+
+	authors, err := h.service.GetDeletedAuthors(c.UserContext(), page, sort)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(authors.WithLinks(c))
+}
+
+// RestoreAuthor handles POST /authors/:id/restore request
+func (h *AuthorHandler) RestoreAuthor(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid author ID")
+	}
+
+	author, err := h.service.RestoreAuthor(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(author)
+}
+
+// DeleteAuthor handles DELETE /authors/:id request
+func (h *AuthorHandler) DeleteAuthor(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid author ID")
+	}
+	options, err := deletion.FromQuery(c)
+	if err != nil {
+		return err
+	}
+
+	match, err := version.IfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.DeleteAuthor(c.UserContext(), uint(id), match, options); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// PurgeAuthor handles DELETE /authors/:id/purge request
+func (h *AuthorHandler) PurgeAuthor(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid author ID")
+	}
+
+	if err := h.service.PurgeAuthor(c.UserContext(), uint(id)); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
 }
 
 // RegisterRoutes registers all routes for author module
@@ -127,6 +254,16 @@ func (h *AuthorHandler) RegisterRoutes(app *fiber.App) {
 	authors := v1.Group("/authors")
 	authors.Post("/", h.CreateAuthor)
 	authors.Get("/", h.GetAuthors)
+	authors.Get("/trash", h.GetDeletedAuthors)
 	authors.Get("/:id", h.GetAuthor)
 	authors.Put("/:id", h.UpdateAuthor)
+	authors.Patch("/:id", h.PatchAuthor)
+	authors.Delete("/:id", h.DeleteAuthor)
+	authors.Post("/:id/restore", h.RestoreAuthor)
+	authors.Post("/:id/revert", h.RevertAuthor)
+}
+
+// RegisterAdminRoutes registers the author routes that are only open to admins, guarded by admin
+func (h *AuthorHandler) RegisterAdminRoutes(app *fiber.App, admin fiber.Handler) {
+	app.Delete("/api/v1/authors/:id/purge", admin, h.PurgeAuthor)
 }
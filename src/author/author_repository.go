@@ -0,0 +1,197 @@
+package author
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/fuzzy"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+	"github.com/tedysaputro/book-catalog-with-go/src/transaction"
+	"github.com/tedysaputro/book-catalog-with-go/src/version"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AuthorRepository defines the persistence operations for authors
+type AuthorRepository interface {
+	Create(ctx context.Context, author *Author) error
+	Update(ctx context.Context, author *Author) error
+	FindByID(ctx context.Context, id uint) (*Author, error)
+	FindByName(ctx context.Context, name string) (*Author, error)
+	FindByIDs(ctx context.Context, ids []uint) ([]Author, error)
+	FindAll(ctx context.Context, page pagination.Request, sort sorting.Spec, authorName string, match fuzzy.Options) ([]Author, uint64, error)
+	SoftDelete(ctx context.Context, author *Author) error
+	FindAllDeleted(ctx context.Context, page pagination.Request, sort sorting.Spec) ([]Author, uint64, error)
+	FindDeletedByID(ctx context.Context, id uint) (*Author, error)
+	Restore(ctx context.Context, author *Author) error
+	Purge(ctx context.Context, author *Author) error
+	FindAllDeletedBefore(ctx context.Context, before time.Time) ([]Author, error)
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type gormAuthorRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAuthorRepository creates an AuthorRepository backed by GORM
+func NewGormAuthorRepository(db *gorm.DB) AuthorRepository {
+	return &gormAuthorRepository{db: db}
+}
+
+// Transaction runs fn in a database transaction that the writes of every repository made with
+// the context passed to fn join, keeping them only if fn returns nil
+func (r *gormAuthorRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction.Run(ctx, r.db, fn)
+}
+
+// Create saves a new Author record to the database
+func (r *gormAuthorRepository) Create(ctx context.Context, author *Author) error {
+	return transaction.DB(ctx, r.db).Create(author).Error
+}
+
+// Update updates an Author record base on id
+func (r *gormAuthorRepository) Update(ctx context.Context, author *Author) error {
+	if author.ID == 0 {
+		return errors.New("cannot update author without ID")
+	}
+	return version.Save(transaction.DB(ctx, r.db), author, &author.Version)
+}
+
+// FindByID retrieves an Author by ID
+func (r *gormAuthorRepository) FindByID(ctx context.Context, id uint) (*Author, error) {
+	var author Author
+	err := transaction.DB(ctx, r.db).First(&author, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAuthorNotFound
+		}
+		return nil, err
+	}
+	return &author, nil
+}
+
+// FindByIDs retrieves the Authors with the given IDs, skipping IDs that do not exist
+func (r *gormAuthorRepository) FindByIDs(ctx context.Context, ids []uint) ([]Author, error) {
+	var authors []Author
+	if len(ids) == 0 {
+		return authors, nil
+	}
+	if err := transaction.DB(ctx, r.db).Find(&authors, ids).Error; err != nil {
+		return nil, err
+	}
+	return authors, nil
+}
+
+// FindByName retrieves the oldest live Author named name, ignoring case
+func (r *gormAuthorRepository) FindByName(ctx context.Context, name string) (*Author, error) {
+	var author Author
+	err := transaction.DB(ctx, r.db).Where("UPPER(authors.name) = ?", strings.ToUpper(name)).Order("authors.id").First(&author).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAuthorNotFound
+		}
+		return nil, err
+	}
+	return &author, nil
+}
+
+// FindAll retrieves all Authors
+func (r *gormAuthorRepository) FindAll(ctx context.Context, page pagination.Request, sort sorting.Spec, authorName string, match fuzzy.Options) ([]Author, uint64, error) {
+	var authors []Author
+	var count int64
+
+	err := fuzzy.Run(ctx, r.db, match, func(ctx context.Context) error {
+		// Count total records
+		query, rank := filterByName(transaction.DB(ctx, r.db).Model(&Author{}), authorName, match)
+		if err := query.Session(&gorm.Session{}).Count(&count).Error; err != nil {
+			return err
+		}
+
+		// Get records with pagination
+		return page.Apply(query, sort, rank...).Find(&authors).Error
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return authors, uint64(count), nil
+}
+
+// SoftDelete removes an Author record (soft delete)
+func (r *gormAuthorRepository) SoftDelete(ctx context.Context, author *Author) error {
+	if author.ID == 0 {
+		return errors.New("cannot delete author without ID")
+	}
+	return version.SoftDelete(transaction.DB(ctx, r.db), author, author.Version)
+}
+
+// FindAllDeleted retrieves the soft deleted Author records
+func (r *gormAuthorRepository) FindAllDeleted(ctx context.Context, page pagination.Request, sort sorting.Spec) ([]Author, uint64, error) {
+	var authors []Author
+	var total int64
+
+	query := transaction.DB(ctx, r.db).Unscoped().Model(&Author{}).Where("authors.deleted_at IS NOT NULL")
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := page.Apply(query, sort).Find(&authors).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return authors, uint64(total), nil
+}
+
+// FindDeletedByID retrieves a soft deleted Author by ID
+func (r *gormAuthorRepository) FindDeletedByID(ctx context.Context, id uint) (*Author, error) {
+	var author Author
+	err := transaction.DB(ctx, r.db).Unscoped().Where("deleted_at IS NOT NULL").First(&author, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAuthorNotInTrash
+		}
+		return nil, err
+	}
+	return &author, nil
+}
+
+// Restore clears the deletion time of a soft deleted Author record and increments its version
+func (r *gormAuthorRepository) Restore(ctx context.Context, author *Author) error {
+	if err := version.Restore(transaction.DB(ctx, r.db), author, &author.Version); err != nil {
+		return err
+	}
+	author.DeletedAt = gorm.DeletedAt{}
+	return nil
+}
+
+// Purge permanently deletes a Author record and unlinks it from its books
+func (r *gormAuthorRepository) Purge(ctx context.Context, author *Author) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM book_authors WHERE author_id = ?", author.ID).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(author).Error
+	})
+}
+
+// FindAllDeletedBefore retrieves the Author records soft deleted before the given time, ordered by ID
+func (r *gormAuthorRepository) FindAllDeletedBefore(ctx context.Context, before time.Time) ([]Author, error) {
+	var authors []Author
+	err := transaction.DB(ctx, r.db).Unscoped().Where("deleted_at < ?", before).Order("id").Find(&authors).Error
+	return authors, err
+}
+
+// filterByName keeps the authors whose name contains name, or resembles it when match is enabled,
+// and returns the similarity ranking of fuzzy matches
+func filterByName(query *gorm.DB, name string, match fuzzy.Options) (*gorm.DB, []clause.Expr) {
+	if name == "" {
+		return query, nil
+	}
+	if match.Enabled {
+		return fuzzy.Where(query, "authors.name", name), []clause.Expr{fuzzy.Rank("authors.name", name)}
+	}
+	return query.Where("UPPER(authors.name) LIKE ?", "%"+strings.ToUpper(name)+"%"), nil
+}
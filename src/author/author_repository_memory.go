@@ -0,0 +1,236 @@
+package author
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/fuzzy"
+	"github.com/tedysaputro/book-catalog-with-go/src/memory"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+	"github.com/tedysaputro/book-catalog-with-go/src/version"
+	"gorm.io/gorm"
+)
+
+type memoryAuthorRepository struct {
+	mu      sync.RWMutex
+	authors map[uint]Author
+	nextID  uint
+}
+
+// NewMemoryAuthorRepository creates an AuthorRepository that keeps authors in memory
+func NewMemoryAuthorRepository() AuthorRepository {
+	return &memoryAuthorRepository{authors: make(map[uint]Author)}
+}
+
+// Transaction runs fn; the writes it made before a failure are not rolled back
+func (r *memoryAuthorRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// Create stores a new Author
+func (r *memoryAuthorRepository) Create(ctx context.Context, author *Author) error {
+	if err := author.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	now := time.Now()
+	author.ID = r.nextID
+	author.Version = 1
+	author.CreatedAt = now
+	author.UpdatedAt = now
+	r.authors[author.ID] = *author
+	return nil
+}
+
+// Update replaces a stored Author
+func (r *memoryAuthorRepository) Update(ctx context.Context, author *Author) error {
+	if author.ID == 0 {
+		return errors.New("cannot update author without ID")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if stored, ok := r.authors[author.ID]; !ok || stored.Version != author.Version {
+		return version.ErrStale
+	}
+	author.Version++
+	author.UpdatedAt = time.Now()
+	r.authors[author.ID] = *author
+	return nil
+}
+
+// FindByID retrieves a live Author by ID
+func (r *memoryAuthorRepository) FindByID(ctx context.Context, id uint) (*Author, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	author, ok := r.authors[id]
+	if !ok || author.DeletedAt.Valid {
+		return nil, ErrAuthorNotFound
+	}
+	return &author, nil
+}
+
+// FindByIDs retrieves the live Authors with the given IDs, skipping IDs that do not exist
+func (r *memoryAuthorRepository) FindByIDs(ctx context.Context, ids []uint) ([]Author, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	authors := []Author{}
+	for _, id := range ids {
+		if author, ok := r.authors[id]; ok && !author.DeletedAt.Valid {
+			authors = append(authors, author)
+		}
+	}
+	return authors, nil
+}
+
+// FindByName retrieves the oldest live Author named name, ignoring case
+func (r *memoryAuthorRepository) FindByName(ctx context.Context, name string) (*Author, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var found *Author
+	for _, author := range r.authors {
+		if !author.DeletedAt.Valid && strings.EqualFold(author.Name, name) && (found == nil || author.ID < found.ID) {
+			found = &author
+		}
+	}
+	if found == nil {
+		return nil, ErrAuthorNotFound
+	}
+	return found, nil
+}
+
+// FindAll retrieves all live Authors matching authorName
+func (r *memoryAuthorRepository) FindAll(ctx context.Context, page pagination.Request, sort sorting.Spec, authorName string, match fuzzy.Options) ([]Author, uint64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	authors := []Author{}
+	for _, author := range r.authors {
+		if author.DeletedAt.Valid {
+			continue
+		}
+		switch {
+		case authorName == "":
+		case match.Enabled && fuzzy.Similarity(authorName, author.Name) < match.Threshold:
+			continue
+		case !match.Enabled && !memory.ContainsFold(author.Name, authorName):
+			continue
+		}
+		authors = append(authors, author)
+	}
+
+	var score func(Author) float64
+	if authorName != "" && match.Enabled {
+		score = func(a Author) float64 { return fuzzy.Similarity(authorName, a.Name) }
+	}
+	value := func(a Author, field string) interface{} { return a.SortValue(field) }
+	memory.Sort(authors, sort, value, score)
+
+	return memory.Paginate(authors, page, sort, value), uint64(len(authors)), nil
+}
+
+// SoftDelete soft deletes a stored Author
+func (r *memoryAuthorRepository) SoftDelete(ctx context.Context, author *Author) error {
+	if author.ID == 0 {
+		return errors.New("cannot delete author without ID")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.authors[author.ID]
+	if !ok {
+		return nil
+	}
+	if stored.Version != author.Version {
+		return version.ErrStale
+	}
+	stored.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	r.authors[author.ID] = stored
+	return nil
+}
+
+// FindAllDeleted retrieves the soft deleted Author records
+func (r *memoryAuthorRepository) FindAllDeleted(ctx context.Context, page pagination.Request, sort sorting.Spec) ([]Author, uint64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	authors := []Author{}
+	for _, author := range r.authors {
+		if author.DeletedAt.Valid {
+			authors = append(authors, author)
+		}
+	}
+
+	value := func(a Author, field string) interface{} { return a.SortValue(field) }
+	memory.Sort(authors, sort, value, nil)
+
+	return memory.Paginate(authors, page, sort, value), uint64(len(authors)), nil
+}
+
+// FindDeletedByID retrieves a soft deleted Author by ID
+func (r *memoryAuthorRepository) FindDeletedByID(ctx context.Context, id uint) (*Author, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	author, ok := r.authors[id]
+	if !ok || !author.DeletedAt.Valid {
+		return nil, ErrAuthorNotInTrash
+	}
+	return &author, nil
+}
+
+// Restore clears the deletion time of a stored Author
+func (r *memoryAuthorRepository) Restore(ctx context.Context, author *Author) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.authors[author.ID]
+	if !ok {
+		return nil
+	}
+	stored.DeletedAt = gorm.DeletedAt{}
+	stored.Version++
+	stored.UpdatedAt = time.Now()
+	r.authors[author.ID] = stored
+	*author = stored
+	return nil
+}
+
+// Purge permanently removes a stored Author
+func (r *memoryAuthorRepository) Purge(ctx context.Context, author *Author) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.authors, author.ID)
+	return nil
+}
+
+// FindAllDeletedBefore retrieves the stored Authors soft deleted before the given time, ordered by ID
+func (r *memoryAuthorRepository) FindAllDeletedBefore(ctx context.Context, before time.Time) ([]Author, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	authors := []Author{}
+	for _, author := range r.authors {
+		if author.DeletedAt.Valid && author.DeletedAt.Time.Before(before) {
+			authors = append(authors, author)
+		}
+	}
+	slices.SortFunc(authors, func(a, b Author) int { return cmp.Compare(a.ID, b.ID) })
+	return authors, nil
+}
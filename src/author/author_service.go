@@ -1,24 +1,54 @@
 package author
 
-import "strconv"
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/deletion"
+	"github.com/tedysaputro/book-catalog-with-go/src/fuzzy"
+	"github.com/tedysaputro/book-catalog-with-go/src/history"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/patch"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+	"github.com/tedysaputro/book-catalog-with-go/src/version"
+)
 
 // AuthorService defines the interface for author operations
 type AuthorService interface {
-	createAuthor(request AuthorRequest) (*AuthorCreateResponse, error)
-	GetAuthor(id uint) (*AuthorDetailResponse, error)
-	GetAuthors(p uint, limit uint, sortBy string, direction string, authorName string) (*AuthorListResponse, error)
-	UpdateAuthor(id uint, request AuthorRequest) (*AuthorDetailResponse, error)
+	CreateAuthor(ctx context.Context, request AuthorRequest) (*AuthorCreateResponse, error)
+	GetAuthor(ctx context.Context, id uint) (*AuthorDetailResponse, error)
+	GetAuthorsByIDs(ctx context.Context, ids []uint) ([]AuthorDetailResponse, error)
+	GetAuthorAsOf(ctx context.Context, id uint, at time.Time) (*AuthorDetailResponse, error)
+	GetAuthors(ctx context.Context, page pagination.Request, sort sorting.Spec, authorName string, match fuzzy.Options) (*AuthorListResponse, error)
+	UpdateAuthor(ctx context.Context, id uint, request AuthorRequest, match version.Precondition) (*AuthorDetailResponse, error)
+	PatchAuthor(ctx context.Context, id uint, p patch.Patch, match version.Precondition) (*AuthorDetailResponse, error)
+	RevertAuthor(ctx context.Context, id uint, to uint, match version.Precondition) (*AuthorDetailResponse, error)
+	DeleteAuthor(ctx context.Context, id uint, match version.Precondition, options deletion.Options) error
+	GetDeletedAuthors(ctx context.Context, page pagination.Request, sort sorting.Spec) (*AuthorTrashListResponse, error)
+	RestoreAuthor(ctx context.Context, id uint) (*AuthorDetailResponse, error)
+	PurgeAuthor(ctx context.Context, id uint) error
+	PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error)
 }
 
-type authorServiceImpl struct{}
+// entityType names authors in their change history
+const entityType = "author"
+
+type authorServiceImpl struct {
+	repo    AuthorRepository
+	books   deletion.Dependents
+	history history.HistoryService
+}
 
-// NewAuthorService creates a new instance of AuthorService
-func NewAuthorService() AuthorService {
-	return &authorServiceImpl{}
+// NewAuthorService creates a new instance of AuthorService.
+// books handles the books of deleted authors and history records every change of an author.
+func NewAuthorService(repo AuthorRepository, books deletion.Dependents, history history.HistoryService) AuthorService {
+	return &authorServiceImpl{repo: repo, books: books, history: history}
 }
 
-// createAuthor creates a new author
-func (s *authorServiceImpl) createAuthor(request AuthorRequest) (*AuthorCreateResponse, error) {
+// CreateAuthor creates a new author
+func (s *authorServiceImpl) CreateAuthor(ctx context.Context, request AuthorRequest) (*AuthorCreateResponse, error) {
 	author := &Author{
 		Name:        request.Name,
 		Description: request.Description,
@@ -28,7 +58,13 @@ func (s *authorServiceImpl) createAuthor(request AuthorRequest) (*AuthorCreateRe
 		return nil, err
 	}
 
-	if err := author.Create(); err != nil {
+	err := s.repo.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, author); err != nil {
+			return err
+		}
+		return s.history.Record(ctx, entityType, author.ID, author.Version, history.ActionCreate, nil, toAuthorDetailResponse(author))
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -40,62 +76,268 @@ func (s *authorServiceImpl) createAuthor(request AuthorRequest) (*AuthorCreateRe
 }
 
 // Update Author by ID
-func (s *authorServiceImpl) UpdateAuthor(id uint, request AuthorRequest) (*AuthorDetailResponse, error) {
-	author, err := FindByID(id)
+func (s *authorServiceImpl) UpdateAuthor(ctx context.Context, id uint, request AuthorRequest, match version.Precondition) (*AuthorDetailResponse, error) {
+	author, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := match.Check(author.Version); err != nil {
+		return nil, err
+	}
+
+	return s.update(ctx, author, request, history.ActionUpdate)
+}
+
+// PatchAuthor applies a merge patch or JSON patch to the fields of an author by ID
+func (s *authorServiceImpl) PatchAuthor(ctx context.Context, id uint, p patch.Patch, match version.Precondition) (*AuthorDetailResponse, error) {
+	author, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if err := match.Check(author.Version); err != nil {
+		return nil, err
+	}
+
+	request := AuthorRequest{Name: author.Name, Description: author.Description}
+	if err := p.Apply(&request); err != nil {
+		return nil, err
+	}
+
+	return s.update(ctx, author, request, history.ActionUpdate)
+}
+
+// RevertAuthor restores the fields an author had at a previous version by ID
+func (s *authorServiceImpl) RevertAuthor(ctx context.Context, id uint, to uint, match version.Precondition) (*AuthorDetailResponse, error) {
+	author, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := match.Check(author.Version); err != nil {
+		return nil, err
+	}
+
+	var state AuthorDetailResponse
+	if err := s.history.StateAtVersion(ctx, entityType, id, to, &state); err != nil {
+		return nil, err
+	}
 
+	request := AuthorRequest{Name: state.Name, Description: state.Description}
+	return s.update(ctx, author, request, history.ActionRevert)
+}
+
+// update replaces the fields of author with request, stores it and records the change as action
+func (s *authorServiceImpl) update(ctx context.Context, author *Author, request AuthorRequest, action history.Action) (*AuthorDetailResponse, error) {
+	before := toAuthorDetailResponse(author)
 	author.Name = request.Name
 	author.Description = request.Description
 
-	if err := author.Update(); err != nil {
+	if err := author.Validate(); err != nil {
 		return nil, err
 	}
 
-	dto := &AuthorDetailResponse{
-		ID:          author.ID,
-		Name:        author.Name,
-		Description: author.Description,
+	var dto *AuthorDetailResponse
+	err := s.repo.Transaction(ctx, func(ctx context.Context) error {
+		store := func(ctx context.Context) error { return s.repo.Update(ctx, author) }
+		// The books show the name of the author, so a rename changes them as well
+		if author.Name != before.Name {
+			if err := s.books.Touch(ctx, author.ID, store); err != nil {
+				return err
+			}
+		} else if err := store(ctx); err != nil {
+			return err
+		}
+		dto = toAuthorDetailResponse(author)
+		return s.history.Record(ctx, entityType, author.ID, author.Version, action, before, dto)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return dto, nil
 }
 
 // GetAuthor retrieves an author by ID
-func (s *authorServiceImpl) GetAuthor(id uint) (*AuthorDetailResponse, error) {
-	author, err := FindByID(id)
+func (s *authorServiceImpl) GetAuthor(ctx context.Context, id uint) (*AuthorDetailResponse, error) {
+	author, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	dto := &AuthorDetailResponse{
-		ID:          author.ID,
-		Name:        author.Name,
-		Description: author.Description,
+	return toAuthorDetailResponse(author), nil
+}
+
+// GetAuthorsByIDs retrieves the authors with the given IDs, skipping IDs that do not exist
+func (s *authorServiceImpl) GetAuthorsByIDs(ctx context.Context, ids []uint) ([]AuthorDetailResponse, error) {
+	authors, err := s.repo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
 	}
 
-	return dto, nil
+	authorDTOs := make([]AuthorDetailResponse, len(authors))
+	for i := range authors {
+		authorDTOs[i] = *toAuthorDetailResponse(&authors[i])
+	}
+
+	return authorDTOs, nil
+}
+
+// GetAuthorAsOf retrieves the state an author had at the given time by ID
+func (s *authorServiceImpl) GetAuthorAsOf(ctx context.Context, id uint, at time.Time) (*AuthorDetailResponse, error) {
+	var state AuthorDetailResponse
+	if err := s.history.StateAsOf(ctx, entityType, id, at, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
 }
 
 // GetAuthors retrieves all authors
-func (s *authorServiceImpl) GetAuthors(p uint, limit uint, sortBy string, direction string, authorName string) (*AuthorListResponse, error) {
-	authors, p, el, err := FindAll(p, limit, sortBy, direction, authorName)
+func (s *authorServiceImpl) GetAuthors(ctx context.Context, page pagination.Request, sort sorting.Spec, authorName string, match fuzzy.Options) (*AuthorListResponse, error) {
+	authors, total, err := s.repo.FindAll(ctx, page, sort, authorName, match)
 	if err != nil {
 		return nil, err
 	}
 
-	dtos := make([]AuthorDTO, len(authors))
-	for i, author := range authors {
-		dtos[i] = AuthorDTO{
+	result := pagination.New(authors, page, total).
+		WithCursors(sort, func(a Author, field string) interface{} { return a.SortValue(field) })
+
+	return pagination.Map(result, func(author Author) AuthorDTO {
+		return AuthorDTO{
 			ID:   strconv.FormatUint(uint64(author.ID), 10),
 			Name: author.Name,
 		}
+	}), nil
+}
+
+// DeleteAuthor soft deletes an author by ID, handling its books according to options
+func (s *authorServiceImpl) DeleteAuthor(ctx context.Context, id uint, match version.Precondition, options deletion.Options) error {
+	// The books are handled in the transaction of the delete, so they are left as they were when the
+	// author turns out to have changed since the version check
+	return s.repo.Transaction(ctx, func(ctx context.Context) error {
+		author, err := s.repo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := match.Check(author.Version); err != nil {
+			return err
+		}
+
+		if options.Policy == deletion.PolicyReassign {
+			if options.ReassignTo == id {
+				return ErrReassignTargetInvalid
+			}
+			if _, err := s.repo.FindByID(ctx, options.ReassignTo); err != nil {
+				if errors.Is(err, ErrAuthorNotFound) {
+					return ErrReassignTargetInvalid
+				}
+				return err
+			}
+		}
+
+		if err := deletion.Apply(ctx, entityType, s.books, id, options); err != nil {
+			return err
+		}
+
+		// The books kept for their other authors no longer show the deleted one
+		return s.books.Touch(ctx, author.ID, func(ctx context.Context) error {
+			if err := s.repo.SoftDelete(ctx, author); err != nil {
+				return err
+			}
+			return s.history.Record(ctx, entityType, author.ID, author.Version, history.ActionDelete, toAuthorDetailResponse(author), nil)
+		})
+	})
+}
+
+// GetDeletedAuthors retrieves the authors in the trash with pagination
+func (s *authorServiceImpl) GetDeletedAuthors(ctx context.Context, page pagination.Request, sort sorting.Spec) (*AuthorTrashListResponse, error) {
+	authors, total, err := s.repo.FindAllDeleted(ctx, page, sort)
+	if err != nil {
+		return nil, err
+	}
+
+	result := pagination.New(authors, page, total).
+		WithCursors(sort, func(a Author, field string) interface{} { return a.SortValue(field) })
+
+	return pagination.Map(result, func(author Author) AuthorTrashResponse {
+		return AuthorTrashResponse{
+			AuthorDTO: AuthorDTO{
+				ID:   strconv.FormatUint(uint64(author.ID), 10),
+				Name: author.Name,
+			},
+			DeletedAt: author.DeletedAt.Time,
+		}
+	}), nil
+}
+
+// RestoreAuthor moves a author out of the trash
+func (s *authorServiceImpl) RestoreAuthor(ctx context.Context, id uint) (*AuthorDetailResponse, error) {
+	author, err := s.repo.FindDeletedByID(ctx, id)
+	if err != nil {
+		return nil, err
 	}
 
-	return &AuthorListResponse{
-		Result:   dtos,
-		Pages:    p,
-		Elements: el,
-	}, nil
+	var dto *AuthorDetailResponse
+	// The books kept for their other authors show a restored author again
+	err = s.repo.Transaction(ctx, func(ctx context.Context) error {
+		return s.books.Touch(ctx, author.ID, func(ctx context.Context) error {
+			if err := s.repo.Restore(ctx, author); err != nil {
+				return err
+			}
+			dto = toAuthorDetailResponse(author)
+			return s.history.Record(ctx, entityType, author.ID, author.Version, history.ActionRestore, nil, dto)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto, nil
+}
+
+// PurgeAuthor permanently deletes a author from the trash
+func (s *authorServiceImpl) PurgeAuthor(ctx context.Context, id uint) error {
+	author, err := s.repo.FindDeletedByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return s.purge(ctx, author)
+}
+
+// PurgeDeletedBefore permanently deletes the authors soft deleted before the given time and returns how many were purged
+func (s *authorServiceImpl) PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	authors, err := s.repo.FindAllDeletedBefore(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+
+	var purged int64
+	for i := range authors {
+		if err := s.purge(ctx, &authors[i]); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// purge permanently deletes author and its links to the live books, recording the change of both
+func (s *authorServiceImpl) purge(ctx context.Context, author *Author) error {
+	return s.repo.Transaction(ctx, func(ctx context.Context) error {
+		return s.books.Unlink(ctx, author.ID, func(ctx context.Context) error {
+			if err := s.repo.Purge(ctx, author); err != nil {
+				return err
+			}
+			return s.history.Record(ctx, entityType, author.ID, author.Version, history.ActionPurge, toAuthorDetailResponse(author), nil)
+		})
+	})
+}
+
+// toAuthorDetailResponse converts an Author into an AuthorDetailResponse
+func toAuthorDetailResponse(author *Author) *AuthorDetailResponse {
+	return &AuthorDetailResponse{
+		ID:          author.ID,
+		Version:     author.Version,
+		Name:        author.Name,
+		Description: author.Description,
+	}
 }
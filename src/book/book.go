@@ -1,170 +1,194 @@
 package book
 
 import (
-	"errors"
-	"strings"
+	"fmt"
 	"time"
 
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
 	"github.com/tedysaputro/book-catalog-with-go/src/author"
+	"github.com/tedysaputro/book-catalog-with-go/src/category"
+	"github.com/tedysaputro/book-catalog-with-go/src/fuzzy"
 	"github.com/tedysaputro/book-catalog-with-go/src/publisher"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
 	"gorm.io/gorm"
 )
 
-var db *gorm.DB
-
-// SetDB sets the database instance
-func SetDB(database *gorm.DB) {
-	db = database
+// SortFields lists the fields books can be sorted by
+var SortFields = sorting.Fields{
+	"id":             {Name: "books.id", Type: sorting.Integer},
+	"title":          {Name: "books.title"},
+	"isbn13":         {Name: "books.isbn13"},
+	"pages":          {Name: "books.pages", Type: sorting.Integer},
+	"year":           {Name: "books.year", Type: sorting.Integer},
+	"created_at":     {Name: "books.created_at", Type: sorting.Time},
+	"updated_at":     {Name: "books.updated_at", Type: sorting.Time},
+	"publisher.name": {Name: "publishers.name", Join: "LEFT JOIN publishers ON publishers.id = books.publisher_id"},
 }
 
 // Book represents a book in the catalog
 type Book struct {
-	ID          uint           `gorm:"primaryKey" json:"id"`
-	Title       string         `gorm:"type:varchar(200);not null" json:"title"`
-	Description string         `gorm:"type:varchar(1000)" json:"description"`
-	Pages       uint          `gorm:"not null" json:"pages"`
-	Year        uint          `gorm:"not null" json:"year"`
-	PublisherID uint          `gorm:"not null" json:"publisher_id"`
+	ID          uint                `gorm:"primaryKey" json:"id"`
+	Title       string              `gorm:"type:varchar(200);not null" json:"title"`
+	Description string              `gorm:"type:varchar(1000)" json:"description"`
+	ISBN10      string              `gorm:"column:isbn10;type:varchar(10)" json:"isbn10"`
+	ISBN13      string              `gorm:"column:isbn13;type:varchar(13);uniqueIndex:idx_books_isbn13,where:isbn13 <> '' AND deleted_at IS NULL" json:"isbn13"`
+	Pages       uint                `gorm:"not null" json:"pages"`
+	Year        uint                `gorm:"not null" json:"year"`
+	PublisherID uint                `gorm:"not null" json:"publisher_id"`
 	Publisher   publisher.Publisher `gorm:"foreignKey:PublisherID" json:"publisher"`
-	Authors     []author.Author    `gorm:"many2many:book_authors;" json:"authors"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+	Authors     []author.Author     `gorm:"many2many:book_authors;" json:"authors"`
+	Categories  []category.Category `gorm:"many2many:book_categories;" json:"categories"`
+	Version     uint                `gorm:"not null;default:1" json:"version"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt      `gorm:"index" json:"deleted_at,omitempty"`
 }
 
-// Create inserts a new Book record
-func (b *Book) Create() error {
-	if err := b.Validate(); err != nil {
-		return err
-	}
-	return db.Create(b).Error
+// BookFilter narrows down the Books returned by FindAll
+type BookFilter struct {
+	// Title matches books whose title contains it, or resembles it when Fuzzy is enabled
+	Title string
+	// CategoryCodes matches books linked to at least one of the categories
+	CategoryCodes []string
+	// AuthorIDs matches books written by at least one of the authors
+	AuthorIDs []uint
+	// PublisherIDs matches books published by one of the publishers
+	PublisherIDs []uint
+	Fuzzy        fuzzy.Options
 }
 
-// Update modifies an existing Book record
-func (b *Book) Update() error {
-	if err := b.Validate(); err != nil {
-		return err
-	}
-	
-	// Start a transaction
-	tx := db.Begin()
-	if tx.Error != nil {
-		return tx.Error
-	}
+// Grouping names the records whose books FindPagesByGroup pages through one record at a time
+type Grouping int
 
-	// Update book details
-	if err := tx.Save(b).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
+const (
+	// GroupByAuthor pages through the books of each author
+	GroupByAuthor Grouping = iota
+	// GroupByPublisher pages through the books of each publisher
+	GroupByPublisher
+	// GroupByCategory pages through the books of each category
+	GroupByCategory
+)
 
-	// Update authors relationship
-	if err := tx.Model(b).Association("Authors").Replace(b.Authors); err != nil {
-		tx.Rollback()
-		return err
+// keys returns the IDs of the records of the grouping that b belongs to
+func (g Grouping) keys(b *Book) []uint {
+	switch g {
+	case GroupByAuthor:
+		return b.AuthorIDs()
+	case GroupByCategory:
+		return b.CategoryIDs()
+	default:
+		return []uint{b.PublisherID}
 	}
-
-	return tx.Commit().Error
 }
 
-// FindByID retrieves a Book by ID while deleted_at is null
-func FindByID(id uint) (*Book, error) {
-	var book Book
-	err := db.Preload("Publisher").Preload("Authors").First(&book, id).Error
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("book not found")
-		}
-		return nil, err
-	}
-	return &book, nil
+// GroupPage is a page of the books of one record along with the total number of its books
+type GroupPage struct {
+	Books []Book
+	Total uint64
 }
 
-// FindAll retrieves all Books while deleted_at is null
-func FindAll(p uint, limit uint, sortBy string, direction string, title string) ([]Book, uint, uint64, error) {
-	var books []Book
-	var total int64
-
-	// Calculate offset
-	offset := (p - 1) * limit
-
-	// Count total records
-	query := db
-	if title != "" {
-		query = query.Where("UPPER(title) LIKE ?", "%"+strings.ToUpper(title)+"%")
-	}
-	err := query.Model(&Book{}).Count(&total).Error
-	if err != nil {
-		return nil, p, 0, err
-	}
-
-	// Get records with pagination
-	query = db.Preload("Publisher").Preload("Authors").Order(sortBy + " " + direction)
-	err = query.Where("UPPER(title) LIKE ?", "%"+strings.ToUpper(title)+"%").Offset(int(offset)).Limit(int(limit)).Find(&books).Error
-	if err != nil {
-		return nil, p, 0, err
-	}
-
-	//Get total pages of books
-	pages := uint64(total) / uint64(limit)
-	if uint64(total)%uint64(limit) > 0 {
-		pages++
-	}
-
-	return books, p, uint64(total), nil
+// ChangeFilter narrows down the live and soft deleted Books returned by FindChanged
+type ChangeFilter struct {
+	// From matches books changed at or after it when set
+	From time.Time
+	// Until matches books changed before it when set
+	Until time.Time
+	// CategoryCodes matches books linked to at least one of the categories
+	CategoryCodes []string
+	// PublisherID matches books published by the publisher when set
+	PublisherID uint
 }
 
-// SoftDelete performs a soft delete on the Book record
-func (b *Book) SoftDelete() error {
-	return db.Delete(b).Error
+// SortValue returns the value of a field of SortFields
+func (b *Book) SortValue(field string) interface{} {
+	switch field {
+	case "title":
+		return b.Title
+	case "isbn13":
+		return b.ISBN13
+	case "pages":
+		return b.Pages
+	case "year":
+		return b.Year
+	case "created_at":
+		return b.CreatedAt
+	case "updated_at":
+		return b.UpdatedAt
+	case "publisher.name":
+		return b.Publisher.Name
+	default:
+		return b.ID
+	}
 }
 
-// AddAuthors adds authors to the book
-func (b *Book) AddAuthors(authorIDs []uint) error {
-	var authors []author.Author
-	if err := db.Find(&authors, authorIDs).Error; err != nil {
-		return err
+// ChangedAt returns the time of the last change of the book: its deletion when it is soft deleted,
+// its last update otherwise
+func (b *Book) ChangedAt() time.Time {
+	if b.DeletedAt.Valid {
+		return b.DeletedAt.Time
 	}
+	return b.UpdatedAt
+}
 
-	if len(authors) != len(authorIDs) {
-		return errors.New("one or more authors not found")
+// AuthorIDs returns the IDs of the book's authors
+func (b *Book) AuthorIDs() []uint {
+	ids := make([]uint, len(b.Authors))
+	for i, a := range b.Authors {
+		ids[i] = a.ID
 	}
-
-	return db.Model(b).Association("Authors").Append(authors)
+	return ids
 }
 
-// RemoveAuthors removes authors from the book
-func (b *Book) RemoveAuthors(authorIDs []uint) error {
-	var authors []author.Author
-	if err := db.Find(&authors, authorIDs).Error; err != nil {
-		return err
+// CategoryIDs returns the IDs of the book's categories
+func (b *Book) CategoryIDs() []uint {
+	ids := make([]uint, len(b.Categories))
+	for i, c := range b.Categories {
+		ids[i] = c.ID
 	}
-
-	return db.Model(b).Association("Authors").Delete(authors)
+	return ids
 }
 
 // Validate checks if the Book data is valid
 func (b *Book) Validate() error {
 	if b.Title == "" {
-		return errors.New("title is required")
+		return ErrTitleRequired
 	}
 	if b.Pages == 0 {
-		return errors.New("pages must be greater than 0")
+		return ErrPagesRequired
 	}
 	if b.Year == 0 {
-		return errors.New("year is required")
+		return ErrYearRequired
 	}
 	if b.PublisherID == 0 {
-		return errors.New("publisher is required")
+		return ErrPublisherRequired
 	}
-
-	// Validate that publisher exists
-	var count int64
-	if err := db.Model(&publisher.Publisher{}).Where("id = ?", b.PublisherID).Count(&count).Error; err != nil {
+	if err := b.normalizeISBN(); err != nil {
 		return err
 	}
-	if count == 0 {
-		return errors.New("publisher not found")
+
+	return nil
+}
+
+// normalizeISBN strips hyphens from both ISBN forms, validates their checksums
+// and fills ISBN13 from ISBN10 (and ISBN10 from a 978-prefixed ISBN13)
+func (b *Book) normalizeISBN() error {
+	b.ISBN10 = cleanISBN(b.ISBN10)
+	b.ISBN13 = cleanISBN(b.ISBN13)
+
+	if b.ISBN10 != "" && !isValidISBN10(b.ISBN10) {
+		return apperror.InvalidField("isbn10", fmt.Errorf("isbn10: %w", ErrInvalidISBN))
+	}
+	if b.ISBN13 != "" && !isValidISBN13(b.ISBN13) {
+		return apperror.InvalidField("isbn13", fmt.Errorf("isbn13: %w", ErrInvalidISBN))
+	}
+
+	switch {
+	case b.ISBN10 != "" && b.ISBN13 == "":
+		b.ISBN13 = isbn10To13(b.ISBN10)
+	case b.ISBN10 == "" && b.ISBN13 != "":
+		b.ISBN10 = isbn13To10(b.ISBN13)
+	case b.ISBN10 != "" && isbn10To13(b.ISBN10) != b.ISBN13:
+		return ErrISBNMismatch
 	}
 
 	return nil
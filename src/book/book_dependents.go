@@ -0,0 +1,220 @@
+package book
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/deletion"
+	"github.com/tedysaputro/book-catalog-with-go/src/history"
+)
+
+// publisherDependents manages the books of publishers being deleted
+type publisherDependents struct {
+	books   BookRepository
+	history history.HistoryService
+}
+
+// NewPublisherDependents creates the deletion.Dependents of publishers, their live books.
+// history records the books the deletion changes.
+func NewPublisherDependents(books BookRepository, history history.HistoryService) deletion.Dependents {
+	return &publisherDependents{books: books, history: history}
+}
+
+// Find returns the live books of the publisher
+func (d *publisherDependents) Find(ctx context.Context, id uint) ([]apperror.Reference, error) {
+	books, err := d.books.FindAllByPublisher(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return references(books), nil
+}
+
+// Cascade soft deletes the live books of the publisher
+func (d *publisherDependents) Cascade(ctx context.Context, id uint) error {
+	books, err := d.books.FindAllByPublisher(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := d.books.SoftDeleteByPublisher(ctx, id); err != nil {
+		return err
+	}
+	return recordChanges(ctx, d.books, d.history, books)
+}
+
+// Reassign moves the live books of the publisher to the publisher to
+func (d *publisherDependents) Reassign(ctx context.Context, id uint, to uint) error {
+	books, err := d.books.FindAllByPublisher(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := d.books.ReassignPublisher(ctx, id, to); err != nil {
+		return err
+	}
+	return recordChanges(ctx, d.books, d.history, books)
+}
+
+// Touch runs change and gives the live books of the publisher, which show its name, a new version
+func (d *publisherDependents) Touch(ctx context.Context, id uint, change func(ctx context.Context) error) error {
+	books, err := d.books.FindAllByPublisher(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := change(ctx); err != nil {
+		return err
+	}
+	if err := d.books.TouchByPublisher(ctx, id); err != nil {
+		return err
+	}
+	return recordChanges(ctx, d.books, d.history, books)
+}
+
+// Unlink runs purge; a publisher cannot be purged while books, even deleted ones, refer to it
+func (d *publisherDependents) Unlink(ctx context.Context, id uint, purge func(ctx context.Context) error) error {
+	return purge(ctx)
+}
+
+// authorDependents manages the books of authors being deleted
+type authorDependents struct {
+	books   BookRepository
+	history history.HistoryService
+}
+
+// NewAuthorDependents creates the deletion.Dependents of authors, the live books linked to them.
+// history records the books the deletion changes.
+func NewAuthorDependents(books BookRepository, history history.HistoryService) deletion.Dependents {
+	return &authorDependents{books: books, history: history}
+}
+
+// Find returns the live books linked to the author
+func (d *authorDependents) Find(ctx context.Context, id uint) ([]apperror.Reference, error) {
+	books, err := d.books.FindAllByAuthor(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return references(books), nil
+}
+
+// Cascade soft deletes the live books left without another live author
+func (d *authorDependents) Cascade(ctx context.Context, id uint) error {
+	books, err := d.books.FindAllByAuthor(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := d.books.SoftDeleteByAuthor(ctx, id); err != nil {
+		return err
+	}
+	return recordChanges(ctx, d.books, d.history, books)
+}
+
+// Reassign links the live books of the author to the author to instead
+func (d *authorDependents) Reassign(ctx context.Context, id uint, to uint) error {
+	books, err := d.books.FindAllByAuthor(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := d.books.ReassignAuthor(ctx, id, to); err != nil {
+		return err
+	}
+	return recordChanges(ctx, d.books, d.history, books)
+}
+
+// Touch runs change and gives the live books linked to the author, which show its name, a new version
+func (d *authorDependents) Touch(ctx context.Context, id uint, change func(ctx context.Context) error) error {
+	books, err := d.books.FindAllByAuthor(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := change(ctx); err != nil {
+		return err
+	}
+	if err := d.books.TouchByAuthor(ctx, id); err != nil {
+		return err
+	}
+	return recordChanges(ctx, d.books, d.history, books)
+}
+
+// Unlink removes the links of the live books to the author before running purge
+func (d *authorDependents) Unlink(ctx context.Context, id uint, purge func(ctx context.Context) error) error {
+	books, err := d.books.FindAllByAuthor(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := d.books.UnlinkAuthor(ctx, id); err != nil {
+		return err
+	}
+	if err := purge(ctx); err != nil {
+		return err
+	}
+	return recordChanges(ctx, d.books, d.history, books)
+}
+
+// categoryLinks manages the books linked to categories that change
+type categoryLinks struct {
+	books   BookRepository
+	history history.HistoryService
+}
+
+// NewCategoryLinks creates the deletion.Links of categories, the live books linked to them.
+// history records the books a change of their categories changes.
+func NewCategoryLinks(books BookRepository, history history.HistoryService) deletion.Links {
+	return &categoryLinks{books: books, history: history}
+}
+
+// Touch runs change and gives the live books linked to the category, which show its code and name, a new version
+func (d *categoryLinks) Touch(ctx context.Context, id uint, change func(ctx context.Context) error) error {
+	books, err := d.books.FindAllLinkedToCategory(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := change(ctx); err != nil {
+		return err
+	}
+	if err := d.books.TouchByCategory(ctx, id); err != nil {
+		return err
+	}
+	return recordChanges(ctx, d.books, d.history, books)
+}
+
+// Unlink removes the links of the live books to the category before running purge
+func (d *categoryLinks) Unlink(ctx context.Context, id uint, purge func(ctx context.Context) error) error {
+	books, err := d.books.FindAllLinkedToCategory(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := d.books.UnlinkCategory(ctx, id); err != nil {
+		return err
+	}
+	if err := purge(ctx); err != nil {
+		return err
+	}
+	return recordChanges(ctx, d.books, d.history, books)
+}
+
+// recordChanges adds the books a bulk change deleted or updated to their history,
+// comparing their state before the change with the one stored now
+func recordChanges(ctx context.Context, books BookRepository, changes history.HistoryService, before []Book) error {
+	for i := range before {
+		old := &before[i]
+		book, err := books.FindByID(ctx, old.ID)
+		switch {
+		case errors.Is(err, ErrBookNotFound):
+			err = changes.Record(ctx, entityType, old.ID, old.Version, history.ActionDelete, toBookDetailResponse(old), nil)
+		case err == nil && book.Version != old.Version:
+			err = changes.Record(ctx, entityType, book.ID, book.Version, history.ActionUpdate, toBookDetailResponse(old), toBookDetailResponse(book))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// references identifies books in error responses
+func references(books []Book) []apperror.Reference {
+	refs := make([]apperror.Reference, len(books))
+	for i, b := range books {
+		refs[i] = apperror.Reference{Type: "book", ID: b.ID, Title: b.Title}
+	}
+	return refs
+}
@@ -1,9 +1,11 @@
 package book
 
 import (
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
 	"time"
 
 	"github.com/tedysaputro/book-catalog-with-go/src/author"
+	"github.com/tedysaputro/book-catalog-with-go/src/category"
 	"github.com/tedysaputro/book-catalog-with-go/src/publisher"
 )
 
@@ -11,10 +13,13 @@ import (
 type BookRequest struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
+	ISBN10      string `json:"isbn10"`
+	ISBN13      string `json:"isbn13"`
 	Pages       uint   `json:"pages"`
 	Year        uint   `json:"year"`
 	PublisherID uint   `json:"publisher_id"`
 	AuthorIDs   []uint `json:"author_ids"`
+	CategoryIDs []uint `json:"category_ids"`
 }
 
 type BookCreateResponse struct {
@@ -24,19 +29,28 @@ type BookCreateResponse struct {
 // BookDetailResponse represents the response payload for a single book
 type BookDetailResponse struct {
 	ID          uint                   `json:"id"`
+	Version     uint                   `json:"version"`
 	Title       string                 `json:"title"`
 	Description string                 `json:"description"`
+	ISBN10      string                 `json:"isbn10"`
+	ISBN13      string                 `json:"isbn13"`
 	Pages       uint                   `json:"pages"`
 	Year        uint                   `json:"year"`
 	Publisher   publisher.PublisherDTO `json:"publisher"`
 	Authors     []author.AuthorDTO     `json:"authors"`
+	Categories  []category.CategoryDTO `json:"categories"`
 	CreatedAt   time.Time              `json:"created_at"`
 	UpdatedAt   time.Time              `json:"updated_at"`
 }
 
 // BookListResponse represents the response payload for multiple books
-type BookListResponse struct {
-	Books []BookDetailResponse `json:"data"`
-	Page  uint                 `json:"page"`
-	Total uint64               `json:"total"`
+type BookListResponse = pagination.Page[BookDetailResponse]
+
+// BookTrashResponse represents a deleted book in the trash
+type BookTrashResponse struct {
+	BookDetailResponse
+	DeletedAt time.Time `json:"deleted_at"`
 }
+
+// BookTrashListResponse represents a page of deleted books
+type BookTrashListResponse = pagination.Page[BookTrashResponse]
@@ -0,0 +1,30 @@
+package book
+
+import "github.com/tedysaputro/book-catalog-with-go/src/apperror"
+
+var (
+	// ErrBookNotFound is returned when no live book has the requested ID or ISBN
+	ErrBookNotFound = apperror.NotFound("book not found")
+	// ErrBookNotInTrash is returned when no deleted book has the requested ID
+	ErrBookNotInTrash = apperror.NotFound("book not found in trash")
+	// ErrPublisherDeleted is returned when a book is restored while its publisher is deleted
+	ErrPublisherDeleted = apperror.Conflict("the publisher of the book is deleted, restore it first")
+	// ErrTitleRequired is returned when a book has no title
+	ErrTitleRequired = apperror.Validation("title", "title is required")
+	// ErrPagesRequired is returned when a book has no pages
+	ErrPagesRequired = apperror.Validation("pages", "pages must be greater than 0")
+	// ErrYearRequired is returned when a book has no year
+	ErrYearRequired = apperror.Validation("year", "year is required")
+	// ErrPublisherRequired is returned when a book has no publisher
+	ErrPublisherRequired = apperror.Validation("publisher_id", "publisher is required")
+	// ErrPublisherNotFound is returned when the publisher of a book does not exist
+	ErrPublisherNotFound = apperror.Validation("publisher_id", "publisher not found")
+	// ErrAuthorsNotFound is returned when some authors of a book do not exist
+	ErrAuthorsNotFound = apperror.Validation("author_ids", "one or more authors not found")
+	// ErrCategoriesNotFound is returned when some categories of a book do not exist
+	ErrCategoriesNotFound = apperror.Validation("category_ids", "one or more categories not found")
+	// ErrISBNMismatch is returned when the ISBN-10 and ISBN-13 of a book differ
+	ErrISBNMismatch = apperror.Validation("isbn13", "isbn10 and isbn13 do not identify the same book")
+	// ErrISBNTaken is returned when another book already uses the ISBN
+	ErrISBNTaken = apperror.Conflict("a book with this isbn already exists")
+)
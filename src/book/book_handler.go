@@ -2,8 +2,16 @@ package book
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/fuzzy"
+	"github.com/tedysaputro/book-catalog-with-go/src/history"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/patch"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+	"github.com/tedysaputro/book-catalog-with-go/src/version"
 )
 
 // BookHandler handles HTTP requests for books
@@ -20,91 +28,181 @@ func NewBookHandler(service BookService) *BookHandler {
 func (h *BookHandler) CreateBook(c *fiber.Ctx) error {
 	var request BookRequest
 	if err := c.BodyParser(&request); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	book, err := h.service.CreateBook(request)
+	book, err := h.service.CreateBook(c.UserContext(), request)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(book)
 }
 
-// GetBook handles GET /books/:id request
+// RevertBook handles POST /books/:id/revert request
+func (h *BookHandler) RevertBook(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid book ID")
+	}
+
+	var request history.RevertRequest
+	if err := c.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	match, err := version.IfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	dto, err := h.service.RevertBook(c.UserContext(), uint(id), request.Version, match)
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderETag, version.ETag(dto.Version))
+	return c.JSON(dto)
+}
+
+// GetBook handles GET /books/:id request, returning the state at as_of when given
 func (h *BookHandler) GetBook(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid book ID",
-		})
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid book ID")
 	}
 
-	book, err := h.service.GetBook(uint(id))
+	at, ok, err := history.AsOf(c)
 	if err != nil {
-		if err.Error() == "book not found" {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": err.Error(),
-			})
+		return err
+	}
+	if ok {
+		dto, err := h.service.GetBookAsOf(c.UserContext(), uint(id), at)
+		if err != nil {
+			return err
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.JSON(dto)
 	}
 
-	return c.JSON(book)
+	book, err := h.service.GetBook(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return version.Send(c, book.Version, book)
+}
+
+// GetBookByISBN handles GET /books/isbn/:isbn request
+func (h *BookHandler) GetBookByISBN(c *fiber.Ctx) error {
+	book, err := h.service.GetBookByISBN(c.UserContext(), c.Params("isbn"))
+	if err != nil {
+		return err
+	}
+
+	return version.Send(c, book.Version, book)
 }
 
 // GetBooks handles GET /books request
 func (h *BookHandler) GetBooks(c *fiber.Ctx) error {
-	page := uint(c.QueryInt("pages", 1))
-	limit := uint(c.QueryInt("limit", 10))
-	sortBy := c.Query("sortBy", "id")
-	direction := c.Query("direction", "asc")
-	title := c.Query("title", "")
+	sort, err := sorting.FromQuery(c.Query("sort"), c.Query("sortBy"), c.Query("direction"), SortFields)
+	if err != nil {
+		return apperror.BadRequest("sort", err)
+	}
+	sort = sort.Stable(SortFields)
+	filter, err := FilterFromQuery(c)
+	if err != nil {
+		return err
+	}
+	page, err := pagination.FromQuery(c, sort)
+	if err != nil {
+		return err
+	}
+	if filter.Fuzzy.Enabled && page.Cursor != nil {
+		return apperror.BadRequest("cursor", pagination.ErrCursorUnsupported)
+	}
 
-	books, err := h.service.GetBooks(page, limit, sortBy, direction, title)
+	books, err := h.service.GetBooks(c.UserContext(), page, sort, filter)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
 	}
 
-	return c.JSON(books)
+	return c.JSON(books.WithLinks(c))
+}
+
+// GetBooksByCategory handles GET /categories/:id/books request
+func (h *BookHandler) GetBooksByCategory(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid category ID")
+	}
+
+	sort, err := sorting.FromQuery(c.Query("sort"), c.Query("sortBy"), c.Query("direction"), SortFields)
+	if err != nil {
+		return apperror.BadRequest("sort", err)
+	}
+	sort = sort.Stable(SortFields)
+	page, err := pagination.FromQuery(c, sort)
+	if err != nil {
+		return err
+	}
+
+	books, err := h.service.GetBooksByCategory(c.UserContext(), uint(id), page, sort)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(books.WithLinks(c))
 }
 
 // UpdateBook handles PUT /books/:id request
 func (h *BookHandler) UpdateBook(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid book ID",
-		})
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid book ID")
 	}
 
 	var request BookRequest
 	if err := c.BodyParser(&request); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	book, err := h.service.UpdateBook(uint(id), request)
+	match, err := version.IfMatch(c)
 	if err != nil {
-		if err.Error() == "book not found" {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": err.Error(),
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
+	}
+
+	book, err := h.service.UpdateBook(c.UserContext(), uint(id), request, match)
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderETag, version.ETag(book.Version))
+	return c.JSON(book)
+}
+
+// PatchBook handles PATCH /books/:id request
+func (h *BookHandler) PatchBook(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid book ID")
+	}
+
+	p, err := patch.FromRequest(c)
+	if err != nil {
+		return err
+	}
+
+	match, err := version.IfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	book, err := h.service.PatchBook(c.UserContext(), uint(id), p, match)
+	if err != nil {
+		return err
 	}
 
+	c.Set(fiber.HeaderETag, version.ETag(book.Version))
 	return c.JSON(book)
 }
 
@@ -112,20 +210,65 @@ func (h *BookHandler) UpdateBook(c *fiber.Ctx) error {
 func (h *BookHandler) DeleteBook(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid book ID",
-		})
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid book ID")
 	}
 
-	if err := h.service.DeleteBook(uint(id)); err != nil {
-		if err.Error() == "book not found" {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": err.Error(),
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+	match, err := version.IfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.DeleteBook(c.UserContext(), uint(id), match); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetDeletedBooks handles GET /books/trash request
+func (h *BookHandler) GetDeletedBooks(c *fiber.Ctx) error {
+	sort, err := sorting.FromQuery(c.Query("sort"), c.Query("sortBy"), c.Query("direction"), SortFields)
+	if err != nil {
+		return apperror.BadRequest("sort", err)
+	}
+	sort = sort.Stable(SortFields)
+	page, err := pagination.FromQuery(c, sort)
+	if err != nil {
+		return err
+	}
+
+	books, err := h.service.GetDeletedBooks(c.UserContext(), page, sort)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(books.WithLinks(c))
+}
+
+// RestoreBook handles POST /books/:id/restore request
+func (h *BookHandler) RestoreBook(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid book ID")
+	}
+
+	book, err := h.service.RestoreBook(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(book)
+}
+
+// PurgeBook handles DELETE /books/:id/purge request
+func (h *BookHandler) PurgeBook(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid book ID")
+	}
+
+	if err := h.service.PurgeBook(c.UserContext(), uint(id)); err != nil {
+		return err
 	}
 
 	return c.SendStatus(fiber.StatusNoContent)
@@ -136,7 +279,47 @@ func (h *BookHandler) RegisterRoutes(app *fiber.App) {
 	books := app.Group("/api/v1/books")
 	books.Post("/", h.CreateBook)
 	books.Get("/", h.GetBooks)
+	books.Get("/isbn/:isbn", h.GetBookByISBN)
+	books.Get("/trash", h.GetDeletedBooks)
 	books.Get("/:id", h.GetBook)
 	books.Put("/:id", h.UpdateBook)
+	books.Patch("/:id", h.PatchBook)
 	books.Delete("/:id", h.DeleteBook)
+	books.Post("/:id/restore", h.RestoreBook)
+	books.Post("/:id/revert", h.RevertBook)
+
+	categories := app.Group("/api/v1/categories")
+	categories.Get("/:id/books", h.GetBooksByCategory)
+}
+
+// RegisterAdminRoutes registers the book routes that are only open to admins, guarded by admin
+func (h *BookHandler) RegisterAdminRoutes(app *fiber.App, admin fiber.Handler) {
+	app.Delete("/api/v1/books/:id/purge", admin, h.PurgeBook)
+}
+
+// FilterFromQuery reads the title, category, fuzzy and threshold query parameters of c
+func FilterFromQuery(c *fiber.Ctx) (BookFilter, error) {
+	match := fuzzy.Options{
+		Enabled:   c.QueryBool("fuzzy"),
+		Threshold: c.QueryFloat("threshold", fuzzy.DefaultThreshold),
+	}
+	if err := match.Validate(); err != nil {
+		return BookFilter{}, apperror.BadRequest("threshold", err)
+	}
+	return BookFilter{
+		Title:         c.Query("title", ""),
+		CategoryCodes: parseList(c.Query("category", "")),
+		Fuzzy:         match,
+	}, nil
+}
+
+// parseList splits a comma separated query value into its non-empty items
+func parseList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
@@ -0,0 +1,587 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/fuzzy"
+	"github.com/tedysaputro/book-catalog-with-go/src/history"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+	"github.com/tedysaputro/book-catalog-with-go/src/transaction"
+	"github.com/tedysaputro/book-catalog-with-go/src/version"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BookRepository defines the persistence operations for books.
+// Books are returned with their Publisher, Authors and Categories loaded.
+type BookRepository interface {
+	Create(ctx context.Context, book *Book) error
+	Update(ctx context.Context, book *Book) error
+	FindByID(ctx context.Context, id uint) (*Book, error)
+	FindByISBN(ctx context.Context, isbn13 string) (*Book, error)
+	FindAllByIDs(ctx context.Context, ids []uint) ([]Book, error)
+	FindAll(ctx context.Context, page pagination.Request, sort sorting.Spec, filter BookFilter) ([]Book, uint64, error)
+	FindAllByCategory(ctx context.Context, categoryID uint, page pagination.Request, sort sorting.Spec) ([]Book, uint64, error)
+	FindAllByPublisher(ctx context.Context, publisherID uint) ([]Book, error)
+	FindAllByAuthor(ctx context.Context, authorID uint) ([]Book, error)
+	FindAllLinkedToCategory(ctx context.Context, categoryID uint) ([]Book, error)
+	FindPagesByGroup(ctx context.Context, grouping Grouping, ids []uint, page pagination.Request, sort sorting.Spec) (map[uint]GroupPage, error)
+	FindChangedByPublisher(ctx context.Context, publisherID uint, since time.Time) ([]Book, error)
+	FindChanged(ctx context.Context, page pagination.Request, sort sorting.Spec, filter ChangeFilter) ([]Book, uint64, error)
+	ExistsByISBN(ctx context.Context, isbn13 string, excludeID uint) (bool, error)
+	SoftDelete(ctx context.Context, book *Book) error
+	SoftDeleteByPublisher(ctx context.Context, publisherID uint) error
+	SoftDeleteByAuthor(ctx context.Context, authorID uint) error
+	ReassignPublisher(ctx context.Context, from uint, to uint) error
+	ReassignAuthor(ctx context.Context, from uint, to uint) error
+	UnlinkAuthor(ctx context.Context, authorID uint) error
+	UnlinkCategory(ctx context.Context, categoryID uint) error
+	TouchByPublisher(ctx context.Context, publisherID uint) error
+	TouchByAuthor(ctx context.Context, authorID uint) error
+	TouchByCategory(ctx context.Context, categoryID uint) error
+	FindAllDeleted(ctx context.Context, page pagination.Request, sort sorting.Spec) ([]Book, uint64, error)
+	FindDeletedByID(ctx context.Context, id uint) (*Book, error)
+	Restore(ctx context.Context, book *Book) error
+	Purge(ctx context.Context, book *Book) error
+	FindAllDeletedBefore(ctx context.Context, before time.Time) ([]Book, error)
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// FindAllMatching retrieves every live Book matching filter from books, ordered by ID,
+// reading them a page of pagination.MaxPageSize at a time
+func FindAllMatching(ctx context.Context, books BookRepository, filter BookFilter) ([]Book, error) {
+	sort, err := sorting.Parse("id", SortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	all := []Book{}
+	page := pagination.Request{Page: 1, PageSize: pagination.MaxPageSize}
+	for {
+		found, total, err := books.FindAll(ctx, page, sort, filter)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, found...)
+		if len(found) == 0 || uint64(page.Page*page.PageSize) >= total {
+			return all, nil
+		}
+		page.Page++
+	}
+}
+
+// FindMovedFromPublisher retrieves the live and soft deleted Books that changes shows moving away from
+// the publisher since the given time and that no longer belong to it, ordered by the time of the move
+func FindMovedFromPublisher(ctx context.Context, books BookRepository, changes history.HistoryRepository, publisherID uint, since time.Time) ([]Book, error) {
+	entries, err := changes.FindSince(ctx, entityType, since)
+	if err != nil {
+		return nil, err
+	}
+
+	id := strconv.FormatUint(uint64(publisherID), 10)
+	moved := []Book{}
+	seen := map[uint]bool{}
+	for _, entry := range entries {
+		if len(entry.Before) == 0 || len(entry.After) == 0 || seen[entry.EntityID] {
+			continue
+		}
+		var before, after BookDetailResponse
+		if err := entry.Before.Decode(&before); err != nil {
+			return nil, err
+		}
+		if err := entry.After.Decode(&after); err != nil {
+			return nil, err
+		}
+		if before.Publisher.ID != id || after.Publisher.ID == id {
+			continue
+		}
+		seen[entry.EntityID] = true
+
+		book, err := books.FindByID(ctx, entry.EntityID)
+		if errors.Is(err, ErrBookNotFound) {
+			book, err = books.FindDeletedByID(ctx, entry.EntityID)
+		}
+		switch {
+		case errors.Is(err, ErrBookNotInTrash):
+			// Purged books are gone from every feed
+		case err != nil:
+			return nil, err
+		case book.PublisherID != publisherID:
+			moved = append(moved, *book)
+		}
+	}
+	return moved, nil
+}
+
+type gormBookRepository struct {
+	db *gorm.DB
+}
+
+// NewGormBookRepository creates a BookRepository backed by GORM
+func NewGormBookRepository(db *gorm.DB) BookRepository {
+	return &gormBookRepository{db: db}
+}
+
+// Transaction runs fn in a database transaction that the writes of every repository made with
+// the context passed to fn join, keeping them only if fn returns nil
+func (r *gormBookRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction.Run(ctx, r.db, fn)
+}
+
+// Create inserts a new Book record
+func (r *gormBookRepository) Create(ctx context.Context, book *Book) error {
+	return translateError(transaction.DB(ctx, r.db).Create(book).Error)
+}
+
+// Update modifies an existing Book record and replaces its authors and categories
+func (r *gormBookRepository) Update(ctx context.Context, book *Book) error {
+	err := transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Update book details, the relations are replaced below
+		if err := version.Save(tx.Omit(clause.Associations), book, &book.Version); err != nil {
+			return err
+		}
+
+		// Update authors relationship
+		if err := tx.Model(book).Association("Authors").Replace(book.Authors); err != nil {
+			return err
+		}
+
+		// Update categories relationship
+		return tx.Model(book).Association("Categories").Replace(book.Categories)
+	})
+	return translateError(err)
+}
+
+// FindByID retrieves a Book by ID while deleted_at is null
+func (r *gormBookRepository) FindByID(ctx context.Context, id uint) (*Book, error) {
+	var book Book
+	err := r.preload(transaction.DB(ctx, r.db)).First(&book, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBookNotFound
+		}
+		return nil, err
+	}
+	return &book, nil
+}
+
+// FindByISBN retrieves a Book by its normalized ISBN-13 while deleted_at is null
+func (r *gormBookRepository) FindByISBN(ctx context.Context, isbn13 string) (*Book, error) {
+	var book Book
+	err := r.preload(transaction.DB(ctx, r.db)).Where("isbn13 = ?", isbn13).First(&book).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBookNotFound
+		}
+		return nil, err
+	}
+	return &book, nil
+}
+
+// FindAllByIDs retrieves the Books with the given IDs while deleted_at is null
+func (r *gormBookRepository) FindAllByIDs(ctx context.Context, ids []uint) ([]Book, error) {
+	var books []Book
+	if len(ids) == 0 {
+		return books, nil
+	}
+	err := r.preload(transaction.DB(ctx, r.db)).Find(&books, ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return books, nil
+}
+
+// FindAll retrieves all Books matching filter while deleted_at is null.
+// Fuzzy title matches are ordered by similarity before sort.
+func (r *gormBookRepository) FindAll(ctx context.Context, page pagination.Request, sort sorting.Spec, filter BookFilter) ([]Book, uint64, error) {
+	var books []Book
+	var total uint64
+	match := fuzzy.Options{Enabled: filter.Fuzzy.Enabled && filter.Title != "", Threshold: filter.Fuzzy.Threshold}
+	err := fuzzy.Run(ctx, r.db, match, func(ctx context.Context) error {
+		query := transaction.DB(ctx, r.db).Model(&Book{})
+		var rank []clause.Expr
+		if filter.Title != "" {
+			if filter.Fuzzy.Enabled {
+				query = fuzzy.Where(query, "books.title", filter.Title)
+				rank = append(rank, fuzzy.Rank("books.title", filter.Title))
+			} else {
+				query = query.Where("UPPER(books.title) LIKE ?", "%"+strings.ToUpper(filter.Title)+"%")
+			}
+		}
+		if len(filter.CategoryCodes) > 0 {
+			query = query.Where("books.id IN (?)", r.inCategories(ctx, filter.CategoryCodes))
+		}
+		if len(filter.AuthorIDs) > 0 {
+			subQuery := transaction.DB(ctx, r.db).Table("book_authors").Select("book_id").Where("author_id IN ?", filter.AuthorIDs)
+			query = query.Where("books.id IN (?)", subQuery)
+		}
+		if len(filter.PublisherIDs) > 0 {
+			query = query.Where("books.publisher_id IN ?", filter.PublisherIDs)
+		}
+
+		var err error
+		books, total, err = r.paginate(query, page, sort, rank...)
+		return err
+	})
+	return books, total, err
+}
+
+// FindAllByCategory retrieves all Books linked to the given category while deleted_at is null
+func (r *gormBookRepository) FindAllByCategory(ctx context.Context, categoryID uint, page pagination.Request, sort sorting.Spec) ([]Book, uint64, error) {
+	subQuery := transaction.DB(ctx, r.db).Table("book_categories").
+		Select("book_id").
+		Where("category_id = ?", categoryID)
+	query := transaction.DB(ctx, r.db).Model(&Book{}).Where("books.id IN (?)", subQuery)
+
+	return r.paginate(query, page, sort)
+}
+
+// FindAllByPublisher retrieves all live Books published by the given publisher, ordered by ID
+func (r *gormBookRepository) FindAllByPublisher(ctx context.Context, publisherID uint) ([]Book, error) {
+	var books []Book
+	err := r.preload(transaction.DB(ctx, r.db)).Where("publisher_id = ?", publisherID).Order("books.id").Find(&books).Error
+	return books, err
+}
+
+// FindChangedByPublisher retrieves the live and soft deleted Books of the given publisher
+// updated or deleted at or after since, ordered by ID
+func (r *gormBookRepository) FindChangedByPublisher(ctx context.Context, publisherID uint, since time.Time) ([]Book, error) {
+	var books []Book
+	err := r.preloadLive(transaction.DB(ctx, r.db).Unscoped()).
+		Where("books.publisher_id = ?", publisherID).
+		Where("books.updated_at >= ? OR books.deleted_at >= ?", since, since).
+		Order("books.id").
+		Find(&books).Error
+	return books, err
+}
+
+// FindChanged retrieves the live and soft deleted Books matching filter by the time of their
+// last change, see Book.ChangedAt
+func (r *gormBookRepository) FindChanged(ctx context.Context, page pagination.Request, sort sorting.Spec, filter ChangeFilter) ([]Book, uint64, error) {
+	var books []Book
+	var total int64
+
+	query := transaction.DB(ctx, r.db).Unscoped().Model(&Book{})
+	if !filter.From.IsZero() {
+		query = query.Where("COALESCE(books.deleted_at, books.updated_at) >= ?", filter.From)
+	}
+	if !filter.Until.IsZero() {
+		query = query.Where("COALESCE(books.deleted_at, books.updated_at) < ?", filter.Until)
+	}
+	if len(filter.CategoryCodes) > 0 {
+		query = query.Where("books.id IN (?)", r.inCategories(ctx, filter.CategoryCodes))
+	}
+	if filter.PublisherID != 0 {
+		query = query.Where("books.publisher_id = ?", filter.PublisherID)
+	}
+
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := r.preloadLive(page.Apply(query, sort)).Find(&books).Error; err != nil {
+		return nil, 0, err
+	}
+	return books, uint64(total), nil
+}
+
+// FindAllByAuthor retrieves all live Books linked to the given author, ordered by ID
+func (r *gormBookRepository) FindAllByAuthor(ctx context.Context, authorID uint) ([]Book, error) {
+	var books []Book
+	err := r.preload(transaction.DB(ctx, r.db)).
+		Where("books.id IN (?)", r.db.Table("book_authors").Select("book_id").Where("author_id = ?", authorID)).
+		Order("books.id").
+		Find(&books).Error
+	return books, err
+}
+
+// FindAllLinkedToCategory retrieves all live Books linked to the given category, ordered by ID
+func (r *gormBookRepository) FindAllLinkedToCategory(ctx context.Context, categoryID uint) ([]Book, error) {
+	var books []Book
+	err := r.preload(transaction.DB(ctx, r.db)).
+		Where("books.id IN (?)", r.db.Table("book_categories").Select("book_id").Where("category_id = ?", categoryID)).
+		Order("books.id").
+		Find(&books).Error
+	return books, err
+}
+
+// FindPagesByGroup retrieves the same page of the live Books of each of the records ids of grouping,
+// ranking the books of every record by sort in a single query. Records without books are left out.
+func (r *gormBookRepository) FindPagesByGroup(ctx context.Context, grouping Grouping, ids []uint, page pagination.Request, sort sorting.Spec) (map[uint]GroupPage, error) {
+	pages := make(map[uint]GroupPage, len(ids))
+	if len(ids) == 0 {
+		return pages, nil
+	}
+
+	key, query := "books.publisher_id", transaction.DB(ctx, r.db).Model(&Book{})
+	switch grouping {
+	case GroupByAuthor:
+		key, query = "book_authors.author_id", query.Joins("JOIN book_authors ON book_authors.book_id = books.id")
+	case GroupByCategory:
+		key, query = "book_categories.category_id", query.Joins("JOIN book_categories ON book_categories.book_id = books.id")
+	}
+	ranked := sort.Joins(query).Where(key+" IN ?", ids).Select(fmt.Sprintf(
+		"books.id AS book_id, %[1]s AS group_id, ROW_NUMBER() OVER (PARTITION BY %[1]s ORDER BY %[2]s) AS position, COUNT(*) OVER (PARTITION BY %[1]s) AS total",
+		key, sort.OrderBy()))
+
+	// The first book of every record is read even past the page, for the total number of its books
+	var rows []struct {
+		BookID   uint
+		GroupID  uint
+		Position int
+		Total    uint64
+	}
+	err := transaction.DB(ctx, r.db).Table("(?) AS ranked", ranked).
+		Where("(position > ? AND position <= ?) OR position = 1", page.Offset(), page.Offset()+int(page.PageSize)).
+		Order("group_id, position").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	bookIDs := make([]uint, 0, len(rows))
+	for _, row := range rows {
+		bookIDs = append(bookIDs, row.BookID)
+	}
+	books, err := r.FindAllByIDs(ctx, bookIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]Book, len(books))
+	for _, b := range books {
+		byID[b.ID] = b
+	}
+
+	for _, row := range rows {
+		group := pages[row.GroupID]
+		group.Total = row.Total
+		if b, ok := byID[row.BookID]; ok && row.Position > page.Offset() {
+			group.Books = append(group.Books, b)
+		}
+		pages[row.GroupID] = group
+	}
+	return pages, nil
+}
+
+// ExistsByISBN reports whether a live book other than excludeID uses isbn13
+func (r *gormBookRepository) ExistsByISBN(ctx context.Context, isbn13 string, excludeID uint) (bool, error) {
+	var count int64
+	if err := transaction.DB(ctx, r.db).Model(&Book{}).Where("isbn13 = ? AND id <> ?", isbn13, excludeID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// SoftDelete performs a soft delete on the Book record
+func (r *gormBookRepository) SoftDelete(ctx context.Context, book *Book) error {
+	return version.SoftDelete(transaction.DB(ctx, r.db), book, book.Version)
+}
+
+// SoftDeleteByPublisher soft deletes the live Books published by the given publisher
+func (r *gormBookRepository) SoftDeleteByPublisher(ctx context.Context, publisherID uint) error {
+	return transaction.DB(ctx, r.db).Where("publisher_id = ?", publisherID).Delete(&Book{}).Error
+}
+
+// SoftDeleteByAuthor soft deletes the live Books linked to the given author that have no other live author.
+// Books written with other authors are kept; their link to the author stays for a later restore.
+func (r *gormBookRepository) SoftDeleteByAuthor(ctx context.Context, authorID uint) error {
+	return transaction.DB(ctx, r.db).
+		Where("books.id IN (?)", r.db.Table("book_authors").Select("book_id").Where("author_id = ?", authorID)).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM book_authors ba JOIN authors a ON a.id = ba.author_id
+			WHERE ba.book_id = books.id AND ba.author_id <> ? AND a.deleted_at IS NULL
+		)`, authorID).
+		Delete(&Book{}).Error
+}
+
+// ReassignPublisher moves the live Books of the publisher from to the publisher to
+func (r *gormBookRepository) ReassignPublisher(ctx context.Context, from uint, to uint) error {
+	return transaction.DB(ctx, r.db).Model(&Book{}).Where("publisher_id = ?", from).
+		Updates(map[string]interface{}{"publisher_id": to, "version": gorm.Expr("version + 1")}).Error
+}
+
+// ReassignAuthor moves the links of the live Books of the author from to the author to.
+// Books already linked to both keep a single link.
+func (r *gormBookRepository) ReassignAuthor(ctx context.Context, from uint, to uint) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&Book{}).
+			Where("books.id IN (?)", tx.Table("book_authors").Select("book_id").Where("author_id = ?", from)).
+			Update("version", gorm.Expr("version + 1")).Error
+		if err != nil {
+			return err
+		}
+		// Insert and delete rather than update the links so the book_authors search triggers fire
+		err = tx.Exec(`INSERT INTO book_authors (book_id, author_id)
+			SELECT book_id, ? FROM book_authors
+			WHERE author_id = ? AND book_id IN (SELECT id FROM books WHERE deleted_at IS NULL)
+			ON CONFLICT DO NOTHING`, to, from).Error
+		if err != nil {
+			return err
+		}
+		return tx.Exec(`DELETE FROM book_authors
+			WHERE author_id = ? AND book_id IN (SELECT id FROM books WHERE deleted_at IS NULL)`, from).Error
+	})
+}
+
+// UnlinkAuthor removes the links of the live Books to the author
+func (r *gormBookRepository) UnlinkAuthor(ctx context.Context, authorID uint) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&Book{}).
+			Where("books.id IN (?)", tx.Table("book_authors").Select("book_id").Where("author_id = ?", authorID)).
+			Update("version", gorm.Expr("version + 1")).Error
+		if err != nil {
+			return err
+		}
+		return tx.Exec(`DELETE FROM book_authors
+			WHERE author_id = ? AND book_id IN (SELECT id FROM books WHERE deleted_at IS NULL)`, authorID).Error
+	})
+}
+
+// UnlinkCategory removes the links of the live Books to the category
+func (r *gormBookRepository) UnlinkCategory(ctx context.Context, categoryID uint) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&Book{}).
+			Where("books.id IN (?)", tx.Table("book_categories").Select("book_id").Where("category_id = ?", categoryID)).
+			Update("version", gorm.Expr("version + 1")).Error
+		if err != nil {
+			return err
+		}
+		return tx.Exec(`DELETE FROM book_categories
+			WHERE category_id = ? AND book_id IN (SELECT id FROM books WHERE deleted_at IS NULL)`, categoryID).Error
+	})
+}
+
+// TouchByPublisher increments the version of the live Books of the publisher, whose content shows it
+func (r *gormBookRepository) TouchByPublisher(ctx context.Context, publisherID uint) error {
+	return r.touch(transaction.DB(ctx, r.db).Model(&Book{}).Where("publisher_id = ?", publisherID))
+}
+
+// TouchByAuthor increments the version of the live Books linked to the author, whose content shows it
+func (r *gormBookRepository) TouchByAuthor(ctx context.Context, authorID uint) error {
+	return r.touch(transaction.DB(ctx, r.db).Model(&Book{}).
+		Where("books.id IN (?)", r.db.Table("book_authors").Select("book_id").Where("author_id = ?", authorID)))
+}
+
+// TouchByCategory increments the version of the live Books linked to the category, whose content shows it
+func (r *gormBookRepository) TouchByCategory(ctx context.Context, categoryID uint) error {
+	return r.touch(transaction.DB(ctx, r.db).Model(&Book{}).
+		Where("books.id IN (?)", r.db.Table("book_categories").Select("book_id").Where("category_id = ?", categoryID)))
+}
+
+// touch increments the version and sets the update time of the Books matched by query
+func (r *gormBookRepository) touch(query *gorm.DB) error {
+	return query.Updates(map[string]interface{}{"version": gorm.Expr("version + 1"), "updated_at": time.Now()}).Error
+}
+
+// FindAllDeleted retrieves the soft deleted Books
+func (r *gormBookRepository) FindAllDeleted(ctx context.Context, page pagination.Request, sort sorting.Spec) ([]Book, uint64, error) {
+	var books []Book
+	var total int64
+
+	query := transaction.DB(ctx, r.db).Unscoped().Model(&Book{}).Where("books.deleted_at IS NOT NULL")
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.preloadLive(page.Apply(query, sort)).Find(&books).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return books, uint64(total), nil
+}
+
+// FindDeletedByID retrieves a soft deleted Book by ID
+func (r *gormBookRepository) FindDeletedByID(ctx context.Context, id uint) (*Book, error) {
+	var book Book
+	err := r.preloadLive(transaction.DB(ctx, r.db).Unscoped()).Where("books.deleted_at IS NOT NULL").First(&book, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBookNotInTrash
+		}
+		return nil, err
+	}
+	return &book, nil
+}
+
+// Restore clears the deletion time of a soft deleted Book record and increments its version
+func (r *gormBookRepository) Restore(ctx context.Context, book *Book) error {
+	if err := translateError(version.Restore(transaction.DB(ctx, r.db).Omit(clause.Associations), book, &book.Version)); err != nil {
+		return err
+	}
+	book.DeletedAt = gorm.DeletedAt{}
+	return nil
+}
+
+// Purge permanently deletes a Book record with its author and category links
+func (r *gormBookRepository) Purge(ctx context.Context, book *Book) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM book_authors WHERE book_id = ?", book.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM book_categories WHERE book_id = ?", book.ID).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(book).Error
+	})
+}
+
+// FindAllDeletedBefore retrieves the Book records soft deleted before the given time, ordered by ID
+func (r *gormBookRepository) FindAllDeletedBefore(ctx context.Context, before time.Time) ([]Book, error) {
+	var books []Book
+	err := r.preloadLive(transaction.DB(ctx, r.db).Unscoped()).Where("books.deleted_at < ?", before).Order("books.id").Find(&books).Error
+	return books, err
+}
+
+// paginate counts the books matched by query and loads the requested page with its relations,
+// ordered by the rank expressions first and then by sort
+func (r *gormBookRepository) paginate(query *gorm.DB, page pagination.Request, sort sorting.Spec, rank ...clause.Expr) ([]Book, uint64, error) {
+	var books []Book
+	var total int64
+
+	// Count total records
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get records with pagination
+	err := r.preload(page.Apply(query.Session(&gorm.Session{}), sort, rank...)).Find(&books).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return books, uint64(total), nil
+}
+
+// translateError reports a unique violation of books.isbn13 as ErrISBNTaken
+func translateError(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrISBNTaken
+	}
+	return err
+}
+
+// inCategories selects the IDs of the books linked to a live category with one of the codes
+func (r *gormBookRepository) inCategories(ctx context.Context, codes []string) *gorm.DB {
+	return transaction.DB(ctx, r.db).Table("book_categories").
+		Select("book_categories.book_id").
+		Joins("JOIN categories ON categories.id = book_categories.category_id").
+		Where("categories.code IN ? AND categories.deleted_at IS NULL", codes)
+}
+
+// preload loads the relations returned with every book
+func (r *gormBookRepository) preload(query *gorm.DB) *gorm.DB {
+	return query.Preload("Publisher").Preload("Authors").Preload("Categories")
+}
+
+// preloadLive loads the live relations of books read with Unscoped, which preloads would inherit
+func (r *gormBookRepository) preloadLive(query *gorm.DB) *gorm.DB {
+	return query.
+		Preload("Publisher", "publishers.deleted_at IS NULL").
+		Preload("Authors", "authors.deleted_at IS NULL").
+		Preload("Categories", "categories.deleted_at IS NULL")
+}
@@ -0,0 +1,539 @@
+package book
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/author"
+	"github.com/tedysaputro/book-catalog-with-go/src/category"
+	"github.com/tedysaputro/book-catalog-with-go/src/fuzzy"
+	"github.com/tedysaputro/book-catalog-with-go/src/memory"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/publisher"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+	"github.com/tedysaputro/book-catalog-with-go/src/version"
+	"gorm.io/gorm"
+)
+
+type memoryBookRepository struct {
+	mu         sync.RWMutex
+	books      map[uint]Book
+	nextID     uint
+	authors    author.AuthorRepository
+	publishers publisher.PublisherRepository
+	categories category.CategoryRepository
+}
+
+// NewMemoryBookRepository creates a BookRepository that keeps books in memory
+// and loads their relations from the given repositories
+func NewMemoryBookRepository(authors author.AuthorRepository, publishers publisher.PublisherRepository, categories category.CategoryRepository) BookRepository {
+	return &memoryBookRepository{
+		books:      make(map[uint]Book),
+		authors:    authors,
+		publishers: publishers,
+		categories: categories,
+	}
+}
+
+// Transaction runs fn; the writes it made before a failure are not rolled back
+func (r *memoryBookRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// Create stores a new Book
+func (r *memoryBookRepository) Create(ctx context.Context, book *Book) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	now := time.Now()
+	book.ID = r.nextID
+	book.Version = 1
+	book.CreatedAt = now
+	book.UpdatedAt = now
+	r.books[book.ID] = detach(*book)
+	return nil
+}
+
+// Update replaces a stored Book with its authors and categories
+func (r *memoryBookRepository) Update(ctx context.Context, book *Book) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if stored, ok := r.books[book.ID]; !ok || stored.Version != book.Version {
+		return version.ErrStale
+	}
+	book.Version++
+	book.UpdatedAt = time.Now()
+	r.books[book.ID] = detach(*book)
+	return nil
+}
+
+// FindByID retrieves a live Book by ID
+func (r *memoryBookRepository) FindByID(ctx context.Context, id uint) (*Book, error) {
+	r.mu.RLock()
+	book, ok := r.books[id]
+	r.mu.RUnlock()
+
+	if !ok || book.DeletedAt.Valid {
+		return nil, ErrBookNotFound
+	}
+	if err := r.hydrate(ctx, &book); err != nil {
+		return nil, err
+	}
+	return &book, nil
+}
+
+// FindByISBN retrieves a live Book by its normalized ISBN-13
+func (r *memoryBookRepository) FindByISBN(ctx context.Context, isbn13 string) (*Book, error) {
+	books, err := r.live(ctx, func(b Book) bool { return b.ISBN13 == isbn13 })
+	if err != nil {
+		return nil, err
+	}
+	if len(books) == 0 {
+		return nil, ErrBookNotFound
+	}
+	return &books[0], nil
+}
+
+// FindAllByIDs retrieves the live Books with the given IDs
+func (r *memoryBookRepository) FindAllByIDs(ctx context.Context, ids []uint) ([]Book, error) {
+	return r.live(ctx, func(b Book) bool { return slices.Contains(ids, b.ID) })
+}
+
+// FindAll retrieves all live Books matching filter.
+// Fuzzy title matches are ordered by similarity before sort.
+func (r *memoryBookRepository) FindAll(ctx context.Context, page pagination.Request, sort sorting.Spec, filter BookFilter) ([]Book, uint64, error) {
+	books, err := r.live(ctx, func(b Book) bool {
+		switch {
+		case len(filter.AuthorIDs) > 0 && !slices.ContainsFunc(b.AuthorIDs(), func(id uint) bool { return slices.Contains(filter.AuthorIDs, id) }):
+			return false
+		case len(filter.PublisherIDs) > 0 && !slices.Contains(filter.PublisherIDs, b.PublisherID):
+			return false
+		case filter.Title == "":
+		case filter.Fuzzy.Enabled && fuzzy.Similarity(filter.Title, b.Title) < filter.Fuzzy.Threshold:
+			return false
+		case !filter.Fuzzy.Enabled && !memory.ContainsFold(b.Title, filter.Title):
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Category codes are only known once the categories are loaded
+	books = inCategories(books, filter.CategoryCodes)
+
+	var score func(Book) float64
+	if filter.Title != "" && filter.Fuzzy.Enabled {
+		score = func(b Book) float64 { return fuzzy.Similarity(filter.Title, b.Title) }
+	}
+	value := func(b Book, field string) interface{} { return b.SortValue(field) }
+	memory.Sort(books, sort, value, score)
+
+	return memory.Paginate(books, page, sort, value), uint64(len(books)), nil
+}
+
+// FindAllByCategory retrieves all live Books linked to the given category
+func (r *memoryBookRepository) FindAllByCategory(ctx context.Context, categoryID uint, page pagination.Request, sort sorting.Spec) ([]Book, uint64, error) {
+	books, err := r.live(ctx, func(b Book) bool { return slices.Contains(b.CategoryIDs(), categoryID) })
+	if err != nil {
+		return nil, 0, err
+	}
+
+	value := func(b Book, field string) interface{} { return b.SortValue(field) }
+	memory.Sort(books, sort, value, nil)
+
+	return memory.Paginate(books, page, sort, value), uint64(len(books)), nil
+}
+
+// FindAllByPublisher retrieves all live Books published by the given publisher, ordered by ID
+func (r *memoryBookRepository) FindAllByPublisher(ctx context.Context, publisherID uint) ([]Book, error) {
+	return r.live(ctx, func(b Book) bool { return b.PublisherID == publisherID })
+}
+
+// FindChangedByPublisher retrieves the live and soft deleted Books of the given publisher
+// updated or deleted at or after since, ordered by ID
+func (r *memoryBookRepository) FindChangedByPublisher(ctx context.Context, publisherID uint, since time.Time) ([]Book, error) {
+	return r.find(ctx, func(b Book) bool {
+		changed := !b.UpdatedAt.Before(since) || b.DeletedAt.Valid && !b.DeletedAt.Time.Before(since)
+		return b.PublisherID == publisherID && changed
+	})
+}
+
+// FindChanged retrieves the live and soft deleted Books matching filter by the time of their
+// last change, see Book.ChangedAt
+func (r *memoryBookRepository) FindChanged(ctx context.Context, page pagination.Request, sort sorting.Spec, filter ChangeFilter) ([]Book, uint64, error) {
+	books, err := r.find(ctx, func(b Book) bool {
+		changed := b.ChangedAt()
+		switch {
+		case !filter.From.IsZero() && changed.Before(filter.From):
+			return false
+		case !filter.Until.IsZero() && !changed.Before(filter.Until):
+			return false
+		case filter.PublisherID != 0 && b.PublisherID != filter.PublisherID:
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	books = inCategories(books, filter.CategoryCodes)
+
+	value := func(b Book, field string) interface{} { return b.SortValue(field) }
+	memory.Sort(books, sort, value, nil)
+
+	return memory.Paginate(books, page, sort, value), uint64(len(books)), nil
+}
+
+// FindAllByAuthor retrieves all live Books linked to the given author, ordered by ID
+func (r *memoryBookRepository) FindAllByAuthor(ctx context.Context, authorID uint) ([]Book, error) {
+	return r.live(ctx, func(b Book) bool { return slices.Contains(b.AuthorIDs(), authorID) })
+}
+
+// FindAllLinkedToCategory retrieves all live Books linked to the given category, ordered by ID
+func (r *memoryBookRepository) FindAllLinkedToCategory(ctx context.Context, categoryID uint) ([]Book, error) {
+	return r.live(ctx, func(b Book) bool { return slices.Contains(b.CategoryIDs(), categoryID) })
+}
+
+// FindPagesByGroup retrieves the same page of the live Books of each of the records ids of grouping,
+// ordered by sort. Records without books are left out.
+func (r *memoryBookRepository) FindPagesByGroup(ctx context.Context, grouping Grouping, ids []uint, page pagination.Request, sort sorting.Spec) (map[uint]GroupPage, error) {
+	books, err := r.live(ctx, func(b Book) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+	value := func(b Book, field string) interface{} { return b.SortValue(field) }
+	memory.Sort(books, sort, value, nil)
+
+	pages := make(map[uint]GroupPage, len(ids))
+	for _, id := range ids {
+		var group []Book
+		for i := range books {
+			if slices.Contains(grouping.keys(&books[i]), id) {
+				group = append(group, books[i])
+			}
+		}
+		if len(group) > 0 {
+			pages[id] = GroupPage{Books: memory.Paginate(group, page, sort, value), Total: uint64(len(group))}
+		}
+	}
+	return pages, nil
+}
+
+// ExistsByISBN reports whether a live book other than excludeID uses isbn13
+func (r *memoryBookRepository) ExistsByISBN(ctx context.Context, isbn13 string, excludeID uint) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, b := range r.books {
+		if !b.DeletedAt.Valid && b.ID != excludeID && b.ISBN13 == isbn13 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// SoftDelete soft deletes a stored Book
+func (r *memoryBookRepository) SoftDelete(ctx context.Context, book *Book) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.books[book.ID]
+	if !ok {
+		return nil
+	}
+	if stored.Version != book.Version {
+		return version.ErrStale
+	}
+	stored.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	r.books[book.ID] = stored
+	return nil
+}
+
+// SoftDeleteByPublisher soft deletes the live Books published by the given publisher
+func (r *memoryBookRepository) SoftDeleteByPublisher(ctx context.Context, publisherID uint) error {
+	books, err := r.FindAllByPublisher(ctx, publisherID)
+	if err != nil {
+		return err
+	}
+	for i := range books {
+		if err := r.SoftDelete(ctx, &books[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SoftDeleteByAuthor soft deletes the live Books linked to the given author that have no other live author.
+// Books written with other authors are kept; their link to the author stays for a later restore.
+func (r *memoryBookRepository) SoftDeleteByAuthor(ctx context.Context, authorID uint) error {
+	books, err := r.FindAllByAuthor(ctx, authorID)
+	if err != nil {
+		return err
+	}
+	for i := range books {
+		// Authors are hydrated live, so any other author left means a co-author
+		if slices.ContainsFunc(books[i].Authors, func(a author.Author) bool { return a.ID != authorID }) {
+			continue
+		}
+		if err := r.SoftDelete(ctx, &books[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReassignPublisher moves the live Books of the publisher from to the publisher to
+func (r *memoryBookRepository) ReassignPublisher(ctx context.Context, from uint, to uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, b := range r.books {
+		if !b.DeletedAt.Valid && b.PublisherID == from {
+			b.PublisherID = to
+			b.Version++
+			b.UpdatedAt = time.Now()
+			r.books[id] = b
+		}
+	}
+	return nil
+}
+
+// ReassignAuthor moves the links of the live Books of the author from to the author to.
+// Books already linked to both keep a single link.
+func (r *memoryBookRepository) ReassignAuthor(ctx context.Context, from uint, to uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, b := range r.books {
+		ids := b.AuthorIDs()
+		if b.DeletedAt.Valid || !slices.Contains(ids, from) {
+			continue
+		}
+		authors := []author.Author{}
+		for _, authorID := range ids {
+			if authorID == from {
+				authorID = to
+			}
+			if !slices.ContainsFunc(authors, func(a author.Author) bool { return a.ID == authorID }) {
+				authors = append(authors, author.Author{ID: authorID})
+			}
+		}
+		b.Authors = authors
+		b.Version++
+		b.UpdatedAt = time.Now()
+		r.books[id] = b
+	}
+	return nil
+}
+
+// UnlinkAuthor removes the links of the live Books to the author
+func (r *memoryBookRepository) UnlinkAuthor(ctx context.Context, authorID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, b := range r.books {
+		if b.DeletedAt.Valid || !slices.Contains(b.AuthorIDs(), authorID) {
+			continue
+		}
+		b.Authors = slices.DeleteFunc(slices.Clone(b.Authors), func(a author.Author) bool { return a.ID == authorID })
+		b.Version++
+		b.UpdatedAt = time.Now()
+		r.books[id] = b
+	}
+	return nil
+}
+
+// UnlinkCategory removes the links of the live Books to the category
+func (r *memoryBookRepository) UnlinkCategory(ctx context.Context, categoryID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, b := range r.books {
+		if b.DeletedAt.Valid || !slices.Contains(b.CategoryIDs(), categoryID) {
+			continue
+		}
+		b.Categories = slices.DeleteFunc(slices.Clone(b.Categories), func(c category.Category) bool { return c.ID == categoryID })
+		b.Version++
+		b.UpdatedAt = time.Now()
+		r.books[id] = b
+	}
+	return nil
+}
+
+// TouchByPublisher increments the version of the live Books of the publisher, whose content shows it
+func (r *memoryBookRepository) TouchByPublisher(ctx context.Context, publisherID uint) error {
+	return r.touch(func(b Book) bool { return b.PublisherID == publisherID })
+}
+
+// TouchByAuthor increments the version of the live Books linked to the author, whose content shows it
+func (r *memoryBookRepository) TouchByAuthor(ctx context.Context, authorID uint) error {
+	return r.touch(func(b Book) bool { return slices.Contains(b.AuthorIDs(), authorID) })
+}
+
+// TouchByCategory increments the version of the live Books linked to the category, whose content shows it
+func (r *memoryBookRepository) TouchByCategory(ctx context.Context, categoryID uint) error {
+	return r.touch(func(b Book) bool { return slices.Contains(b.CategoryIDs(), categoryID) })
+}
+
+// touch increments the version and sets the update time of the live Books matching keep
+func (r *memoryBookRepository) touch(keep func(b Book) bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for id, b := range r.books {
+		if !b.DeletedAt.Valid && keep(b) {
+			b.Version++
+			b.UpdatedAt = now
+			r.books[id] = b
+		}
+	}
+	return nil
+}
+
+// FindAllDeleted retrieves the soft deleted Books
+func (r *memoryBookRepository) FindAllDeleted(ctx context.Context, page pagination.Request, sort sorting.Spec) ([]Book, uint64, error) {
+	books, err := r.find(ctx, func(b Book) bool { return b.DeletedAt.Valid })
+	if err != nil {
+		return nil, 0, err
+	}
+
+	value := func(b Book, field string) interface{} { return b.SortValue(field) }
+	memory.Sort(books, sort, value, nil)
+
+	return memory.Paginate(books, page, sort, value), uint64(len(books)), nil
+}
+
+// FindDeletedByID retrieves a soft deleted Book by ID
+func (r *memoryBookRepository) FindDeletedByID(ctx context.Context, id uint) (*Book, error) {
+	r.mu.RLock()
+	book, ok := r.books[id]
+	r.mu.RUnlock()
+
+	if !ok || !book.DeletedAt.Valid {
+		return nil, ErrBookNotInTrash
+	}
+	if err := r.hydrate(ctx, &book); err != nil {
+		return nil, err
+	}
+	return &book, nil
+}
+
+// Restore clears the deletion time of a stored Book unless a live book took its ISBN,
+// mirroring the unique index on books.isbn13
+func (r *memoryBookRepository) Restore(ctx context.Context, book *Book) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.books[book.ID]
+	if !ok {
+		return nil
+	}
+	for _, b := range r.books {
+		if !b.DeletedAt.Valid && stored.ISBN13 != "" && b.ISBN13 == stored.ISBN13 {
+			return ErrISBNTaken
+		}
+	}
+	stored.DeletedAt = gorm.DeletedAt{}
+	stored.Version++
+	stored.UpdatedAt = time.Now()
+	r.books[book.ID] = stored
+	book.DeletedAt = stored.DeletedAt
+	book.Version = stored.Version
+	book.UpdatedAt = stored.UpdatedAt
+	return nil
+}
+
+// Purge permanently removes a stored Book
+func (r *memoryBookRepository) Purge(ctx context.Context, book *Book) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.books, book.ID)
+	return nil
+}
+
+// FindAllDeletedBefore retrieves the Books soft deleted before the given time, ordered by ID
+func (r *memoryBookRepository) FindAllDeletedBefore(ctx context.Context, before time.Time) ([]Book, error) {
+	return r.find(ctx, func(b Book) bool { return b.DeletedAt.Valid && b.DeletedAt.Time.Before(before) })
+}
+
+// live returns the hydrated live books accepted by keep, ordered by ID
+func (r *memoryBookRepository) live(ctx context.Context, keep func(b Book) bool) ([]Book, error) {
+	return r.find(ctx, func(b Book) bool { return !b.DeletedAt.Valid && keep(b) })
+}
+
+// find returns the hydrated stored books accepted by keep, ordered by ID
+func (r *memoryBookRepository) find(ctx context.Context, keep func(b Book) bool) ([]Book, error) {
+	r.mu.RLock()
+	books := []Book{}
+	for _, b := range r.books {
+		if keep(b) {
+			books = append(books, b)
+		}
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(books, func(a, b Book) int { return memory.Compare(a.ID, b.ID) })
+	for i := range books {
+		if err := r.hydrate(ctx, &books[i]); err != nil {
+			return nil, err
+		}
+	}
+	return books, nil
+}
+
+// hydrate loads the publisher, authors and categories of book from their repositories,
+// leaving out deleted ones like a GORM preload does
+func (r *memoryBookRepository) hydrate(ctx context.Context, book *Book) error {
+	book.Publisher = publisher.Publisher{}
+	if p, err := r.publishers.FindByID(ctx, book.PublisherID); err == nil {
+		book.Publisher = *p
+	}
+
+	authors, err := r.authors.FindByIDs(ctx, book.AuthorIDs())
+	if err != nil {
+		return err
+	}
+	book.Authors = authors
+
+	categories, err := r.categories.FindByIDs(ctx, book.CategoryIDs())
+	if err != nil {
+		return err
+	}
+	book.Categories = categories
+	return nil
+}
+
+// inCategories keeps the hydrated books linked to a category with one of the codes, all of them without codes
+func inCategories(books []Book, codes []string) []Book {
+	if len(codes) == 0 {
+		return books
+	}
+	return slices.DeleteFunc(books, func(b Book) bool {
+		return !slices.ContainsFunc(b.Categories, func(c category.Category) bool {
+			return slices.Contains(codes, c.Code)
+		})
+	})
+}
+
+// detach keeps only the IDs of the relations of book so later reads see their current state
+func detach(book Book) Book {
+	authorIDs, categoryIDs := book.AuthorIDs(), book.CategoryIDs()
+	book.Publisher = publisher.Publisher{}
+	book.Authors = make([]author.Author, len(authorIDs))
+	for i, id := range authorIDs {
+		book.Authors[i] = author.Author{ID: id}
+	}
+	book.Categories = make([]category.Category, len(categoryIDs))
+	for i, id := range categoryIDs {
+		book.Categories[i] = category.Category{ID: id}
+	}
+	return book
+}
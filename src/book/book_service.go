@@ -1,219 +1,607 @@
 package book
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strconv"
+	"time"
 
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
 	"github.com/tedysaputro/book-catalog-with-go/src/author"
+	"github.com/tedysaputro/book-catalog-with-go/src/category"
+	"github.com/tedysaputro/book-catalog-with-go/src/history"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/patch"
 	"github.com/tedysaputro/book-catalog-with-go/src/publisher"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+	"github.com/tedysaputro/book-catalog-with-go/src/version"
 )
 
 // BookService defines the interface for book operations
 type BookService interface {
-	CreateBook(request BookRequest) (*BookCreateResponse, error)
-	GetBook(id uint) (*BookDetailResponse, error)
-	GetBooks(p uint, limit uint, sortBy string, direction string, title string) (*BookListResponse, error)
-	UpdateBook(id uint, request BookRequest) (*BookDetailResponse, error)
-	DeleteBook(id uint) error
+	CreateBook(ctx context.Context, request BookRequest) (*BookCreateResponse, error)
+	GetBook(ctx context.Context, id uint) (*BookDetailResponse, error)
+	GetBookAsOf(ctx context.Context, id uint, at time.Time) (*BookDetailResponse, error)
+	GetBookByISBN(ctx context.Context, isbn string) (*BookDetailResponse, error)
+	GetBooksByIDs(ctx context.Context, ids []uint) ([]BookDetailResponse, error)
+	GetBooksByGroup(ctx context.Context, grouping Grouping, ids []uint, page pagination.Request, sort sorting.Spec) (map[uint]*BookListResponse, error)
+	GetBooks(ctx context.Context, page pagination.Request, sort sorting.Spec, filter BookFilter) (*BookListResponse, error)
+	GetBooksByCategory(ctx context.Context, categoryID uint, page pagination.Request, sort sorting.Spec) (*BookListResponse, error)
+	UpdateBook(ctx context.Context, id uint, request BookRequest, match version.Precondition) (*BookDetailResponse, error)
+	PatchBook(ctx context.Context, id uint, p patch.Patch, match version.Precondition) (*BookDetailResponse, error)
+	RevertBook(ctx context.Context, id uint, to uint, match version.Precondition) (*BookDetailResponse, error)
+	DeleteBook(ctx context.Context, id uint, match version.Precondition) error
+	GetDeletedBooks(ctx context.Context, page pagination.Request, sort sorting.Spec) (*BookTrashListResponse, error)
+	RestoreBook(ctx context.Context, id uint) (*BookDetailResponse, error)
+	PurgeBook(ctx context.Context, id uint) error
+	PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error)
 }
 
-type bookServiceImpl struct{}
+// entityType names books in their change history
+const entityType = "book"
 
-// NewBookService creates a new instance of BookService
-func NewBookService() BookService {
-	return &bookServiceImpl{}
+type bookServiceImpl struct {
+	books      BookRepository
+	authors    author.AuthorRepository
+	publishers publisher.PublisherRepository
+	categories category.CategoryRepository
+	history    history.HistoryService
+}
+
+// NewBookService creates a new instance of BookService.
+// history records every change of a book, including its author and category links.
+func NewBookService(books BookRepository, authors author.AuthorRepository, publishers publisher.PublisherRepository, categories category.CategoryRepository, history history.HistoryService) BookService {
+	return &bookServiceImpl{
+		books:      books,
+		authors:    authors,
+		publishers: publishers,
+		categories: categories,
+		history:    history,
+	}
 }
 
 // CreateBook creates a new book
-func (s *bookServiceImpl) CreateBook(request BookRequest) (*BookCreateResponse, error) {
+func (s *bookServiceImpl) CreateBook(ctx context.Context, request BookRequest) (*BookCreateResponse, error) {
 	// Get authors if author IDs are provided
-	var authors []author.Author
-	if len(request.AuthorIDs) > 0 {
-		if err := db.Find(&authors, request.AuthorIDs).Error; err != nil {
-			return nil, err
-		}
-		if len(authors) != len(request.AuthorIDs) {
-			return nil, errors.New("one or more authors not found")
-		}
+	authors, err := s.findAuthors(ctx, request.AuthorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get categories if category IDs are provided
+	categories, err := s.findCategories(ctx, request.CategoryIDs)
+	if err != nil {
+		return nil, err
 	}
 
 	book := Book{
 		Title:       request.Title,
 		Description: request.Description,
+		ISBN10:      request.ISBN10,
+		ISBN13:      request.ISBN13,
 		Pages:       request.Pages,
 		Year:        request.Year,
 		PublisherID: request.PublisherID,
 		Authors:     authors,
+		Categories:  categories,
 	}
 
-	if err := book.Create(); err != nil {
+	if err := s.validate(ctx, &book); err != nil {
 		return nil, err
 	}
 
-	// Fetch the book again to get the publisher and author details
-	createdBook, err := FindByID(book.ID)
+	err = s.books.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.books.Create(ctx, &book); err != nil {
+			return err
+		}
+
+		// Fetch the book again to get the publisher and author details
+		createdBook, err := s.books.FindByID(ctx, book.ID)
+		if err != nil {
+			return err
+		}
+
+		return s.history.Record(ctx, entityType, createdBook.ID, createdBook.Version, history.ActionCreate, nil, toBookDetailResponse(createdBook))
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	return &BookCreateResponse{
-		ID: createdBook.ID,
+		ID: book.ID,
 	}, nil
 }
 
 // GetBook retrieves a book by ID
-func (s *bookServiceImpl) GetBook(id uint) (*BookDetailResponse, error) {
-	book, err := FindByID(id)
+func (s *bookServiceImpl) GetBook(ctx context.Context, id uint) (*BookDetailResponse, error) {
+	book, err := s.books.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert Publisher to PublisherDTO
-	publisherDTO := publisher.PublisherDTO{
-		ID:   fmt.Sprintf("%d", book.Publisher.ID),
-		Name: book.Publisher.Name,
+	return toBookDetailResponse(book), nil
+}
+
+// GetBookAsOf retrieves the state a book had at the given time by ID
+func (s *bookServiceImpl) GetBookAsOf(ctx context.Context, id uint, at time.Time) (*BookDetailResponse, error) {
+	var state BookDetailResponse
+	if err := s.history.StateAsOf(ctx, entityType, id, at, &state); err != nil {
+		return nil, err
 	}
+	return &state, nil
+}
 
-	// Convert Authors to AuthorDTOs
-	authorDTOs := make([]author.AuthorDTO, len(book.Authors))
-	for i, a := range book.Authors {
-		authorDTOs[i] = author.AuthorDTO{
-			ID:   fmt.Sprintf("%d", a.ID),
-			Name: a.Name,
-		}
+// GetBookByISBN retrieves a book by its ISBN-10 or ISBN-13
+func (s *bookServiceImpl) GetBookByISBN(ctx context.Context, isbn string) (*BookDetailResponse, error) {
+	isbn13, err := NormalizeISBN(isbn)
+	if err != nil {
+		return nil, apperror.BadRequest("isbn", err)
 	}
 
-	return &BookDetailResponse{
-		ID:          book.ID,
-		Title:       book.Title,
-		Description: book.Description,
-		Pages:       book.Pages,
-		Year:        book.Year,
-		Publisher:   publisherDTO,
-		Authors:     authorDTOs,
-		CreatedAt:   book.CreatedAt,
-		UpdatedAt:   book.UpdatedAt,
-	}, nil
+	book, err := s.books.FindByISBN(ctx, isbn13)
+	if err != nil {
+		return nil, err
+	}
+
+	return toBookDetailResponse(book), nil
+}
+
+// GetBooksByIDs retrieves the books with the given IDs, skipping IDs that do not exist
+func (s *bookServiceImpl) GetBooksByIDs(ctx context.Context, ids []uint) ([]BookDetailResponse, error) {
+	books, err := s.books.FindAllByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	bookDTOs := make([]BookDetailResponse, len(books))
+	for i := range books {
+		bookDTOs[i] = *toBookDetailResponse(&books[i])
+	}
+
+	return bookDTOs, nil
+}
+
+// GetBooksByGroup retrieves the same page of the books of each of the records ids of grouping,
+// by record ID. Records without books get an empty page.
+func (s *bookServiceImpl) GetBooksByGroup(ctx context.Context, grouping Grouping, ids []uint, page pagination.Request, sort sorting.Spec) (map[uint]*BookListResponse, error) {
+	pages, err := s.books.FindPagesByGroup(ctx, grouping, ids, page, sort)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uint]*BookListResponse, len(ids))
+	for _, id := range ids {
+		group := pages[id]
+		result[id] = pagination.Map(pagination.New(group.Books, page, group.Total), func(b Book) BookDetailResponse {
+			return *toBookDetailResponse(&b)
+		})
+	}
+	return result, nil
 }
 
 // GetBooks retrieves a list of books with pagination
-func (s *bookServiceImpl) GetBooks(p uint, limit uint, sortBy string, direction string, title string) (*BookListResponse, error) {
-	books, page, total, err := FindAll(p, limit, sortBy, direction, title)
+func (s *bookServiceImpl) GetBooks(ctx context.Context, page pagination.Request, sort sorting.Spec, filter BookFilter) (*BookListResponse, error) {
+	books, total, err := s.books.FindAll(ctx, page, sort, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	var bookDTOs []BookDetailResponse
-	for _, book := range books {
-		// Convert Publisher to PublisherDTO
-		publisherDTO := publisher.PublisherDTO{
-			ID:   fmt.Sprintf("%d", book.Publisher.ID),
-			Name: book.Publisher.Name,
-		}
+	return toBookListResponse(books, page, sort, total), nil
+}
 
-		// Convert Authors to AuthorDTOs
-		authorDTOs := make([]author.AuthorDTO, len(book.Authors))
-		for i, a := range book.Authors {
-			authorDTOs[i] = author.AuthorDTO{
-				ID:   fmt.Sprintf("%d", a.ID),
-				Name: a.Name,
-			}
-		}
+// GetBooksByCategory retrieves the books of a category with pagination
+func (s *bookServiceImpl) GetBooksByCategory(ctx context.Context, categoryID uint, page pagination.Request, sort sorting.Spec) (*BookListResponse, error) {
+	if _, err := s.categories.FindByID(ctx, categoryID); err != nil {
+		return nil, err
+	}
 
-		dto := BookDetailResponse{
-			ID:          book.ID,
-			Title:       book.Title,
-			Description: book.Description,
-			Pages:       book.Pages,
-			Year:        book.Year,
-			Publisher:   publisherDTO,
-			Authors:     authorDTOs,
-			CreatedAt:   book.CreatedAt,
-			UpdatedAt:   book.UpdatedAt,
-		}
-		bookDTOs = append(bookDTOs, dto)
+	books, total, err := s.books.FindAllByCategory(ctx, categoryID, page, sort)
+	if err != nil {
+		return nil, err
 	}
 
-	return &BookListResponse{
-		Books: bookDTOs,
-		Page:  page,
-		Total: total,
-	}, nil
+	return toBookListResponse(books, page, sort, total), nil
 }
 
 // UpdateBook updates a book by ID
-func (s *bookServiceImpl) UpdateBook(id uint, request BookRequest) (*BookDetailResponse, error) {
-	book, err := FindByID(id)
+func (s *bookServiceImpl) UpdateBook(ctx context.Context, id uint, request BookRequest, match version.Precondition) (*BookDetailResponse, error) {
+	book, err := s.books.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if err := match.Check(book.Version); err != nil {
+		return nil, err
+	}
+
+	return s.update(ctx, book, request, history.ActionUpdate)
+}
+
+// PatchBook applies a merge patch or JSON patch to the fields of a book by ID
+func (s *bookServiceImpl) PatchBook(ctx context.Context, id uint, p patch.Patch, match version.Precondition) (*BookDetailResponse, error) {
+	book, err := s.books.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := match.Check(book.Version); err != nil {
+		return nil, err
+	}
+
+	request := toBookRequest(book)
+	if err := p.Apply(&request); err != nil {
+		return nil, err
+	}
+
+	// A patch of one ISBN form replaces the other one too: it is derived again from the
+	// patched form, or cleared along with it
+	isbn10Patched := cleanISBN(request.ISBN10) != book.ISBN10
+	isbn13Patched := cleanISBN(request.ISBN13) != book.ISBN13
+	switch {
+	case isbn13Patched && !isbn10Patched:
+		request.ISBN10 = ""
+	case isbn10Patched && !isbn13Patched:
+		request.ISBN13 = ""
+	}
+
+	return s.update(ctx, book, request, history.ActionUpdate)
+}
+
+// RevertBook restores the fields and links a book had at a previous version by ID.
+// Authors, categories and a publisher deleted since then make the revert fail.
+func (s *bookServiceImpl) RevertBook(ctx context.Context, id uint, to uint, match version.Precondition) (*BookDetailResponse, error) {
+	book, err := s.books.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := match.Check(book.Version); err != nil {
+		return nil, err
+	}
+
+	var state BookDetailResponse
+	if err := s.history.StateAtVersion(ctx, entityType, id, to, &state); err != nil {
+		return nil, err
+	}
+	request, err := fromBookDetailResponse(&state)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.update(ctx, book, request, history.ActionRevert)
+}
+
+// update replaces the fields of book with request, stores it and records the change as action
+func (s *bookServiceImpl) update(ctx context.Context, book *Book, request BookRequest, action history.Action) (*BookDetailResponse, error) {
+	before := toBookDetailResponse(book)
 
 	// Get authors if author IDs are provided
-	var authors []author.Author
-	if len(request.AuthorIDs) > 0 {
-		if err := db.Find(&authors, request.AuthorIDs).Error; err != nil {
-			return nil, err
-		}
-		if len(authors) != len(request.AuthorIDs) {
-			return nil, errors.New("one or more authors not found")
-		}
+	authors, err := s.findAuthors(ctx, request.AuthorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get categories if category IDs are provided
+	categories, err := s.findCategories(ctx, request.CategoryIDs)
+	if err != nil {
+		return nil, err
 	}
 
 	book.Title = request.Title
 	book.Description = request.Description
+	book.ISBN10 = request.ISBN10
+	book.ISBN13 = request.ISBN13
 	book.Pages = request.Pages
 	book.Year = request.Year
 	book.PublisherID = request.PublisherID
 	book.Authors = authors
+	book.Categories = categories
 
-	if err := book.Update(); err != nil {
+	if err := s.validate(ctx, book); err != nil {
 		return nil, err
 	}
 
-	// Fetch the book again to get the updated publisher and author details
-	updatedBook, err := FindByID(book.ID)
+	var dto *BookDetailResponse
+	err = s.books.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.books.Update(ctx, book); err != nil {
+			return err
+		}
+
+		// Fetch the book again to get the updated publisher and author details
+		updatedBook, err := s.books.FindByID(ctx, book.ID)
+		if err != nil {
+			return err
+		}
+
+		dto = toBookDetailResponse(updatedBook)
+		return s.history.Record(ctx, entityType, updatedBook.ID, updatedBook.Version, action, before, dto)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert Publisher to PublisherDTO
-	publisherDTO := publisher.PublisherDTO{
-		ID:   fmt.Sprintf("%d", updatedBook.Publisher.ID),
-		Name: updatedBook.Publisher.Name,
+	return dto, nil
+}
+
+// DeleteBook soft delete a book by ID
+func (s *bookServiceImpl) DeleteBook(ctx context.Context, id uint, match version.Precondition) error {
+	book, err := s.books.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := match.Check(book.Version); err != nil {
+		return err
 	}
 
-	// Convert Authors to AuthorDTOs
-	authorDTOs := make([]author.AuthorDTO, len(updatedBook.Authors))
-	for i, a := range updatedBook.Authors {
-		authorDTOs[i] = author.AuthorDTO{
-			ID:   fmt.Sprintf("%d", a.ID),
-			Name: a.Name,
+	return s.books.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.books.SoftDelete(ctx, book); err != nil {
+			return err
 		}
+		return s.history.Record(ctx, entityType, book.ID, book.Version, history.ActionDelete, toBookDetailResponse(book), nil)
+	})
+}
+
+// GetDeletedBooks retrieves the books in the trash with pagination
+func (s *bookServiceImpl) GetDeletedBooks(ctx context.Context, page pagination.Request, sort sorting.Spec) (*BookTrashListResponse, error) {
+	books, total, err := s.books.FindAllDeleted(ctx, page, sort)
+	if err != nil {
+		return nil, err
 	}
 
-	return &BookDetailResponse{
-		ID:          updatedBook.ID,
-		Title:       updatedBook.Title,
-		Description: updatedBook.Description,
-		Pages:       updatedBook.Pages,
-		Year:        updatedBook.Year,
-		Publisher:   publisherDTO,
-		Authors:     authorDTOs,
-		CreatedAt:   updatedBook.CreatedAt,
-		UpdatedAt:   updatedBook.UpdatedAt,
-	}, nil
+	result := pagination.New(books, page, total).
+		WithCursors(sort, func(b Book, field string) interface{} { return b.SortValue(field) })
+
+	return pagination.Map(result, func(book Book) BookTrashResponse {
+		return BookTrashResponse{
+			BookDetailResponse: *toBookDetailResponse(&book),
+			DeletedAt:          book.DeletedAt.Time,
+		}
+	}), nil
 }
 
-// DeleteBook soft delete a book by ID
-func (s *bookServiceImpl) DeleteBook(id uint) error {
-	book, err := FindByID(id)
+// RestoreBook moves a book out of the trash, provided its publisher is live and its ISBN is still free
+func (s *bookServiceImpl) RestoreBook(ctx context.Context, id uint) (*BookDetailResponse, error) {
+	book, err := s.books.FindDeletedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.publishers.FindByID(ctx, book.PublisherID); err != nil {
+		if errors.Is(err, publisher.ErrPublisherNotFound) {
+			return nil, ErrPublisherDeleted
+		}
+		return nil, err
+	}
+
+	var dto *BookDetailResponse
+	err = s.books.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.books.Restore(ctx, book); err != nil {
+			return err
+		}
+
+		restoredBook, err := s.books.FindByID(ctx, book.ID)
+		if err != nil {
+			return err
+		}
+
+		dto = toBookDetailResponse(restoredBook)
+		return s.history.Record(ctx, entityType, restoredBook.ID, restoredBook.Version, history.ActionRestore, nil, dto)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto, nil
+}
+
+// PurgeBook permanently deletes a book from the trash
+func (s *bookServiceImpl) PurgeBook(ctx context.Context, id uint) error {
+	book, err := s.books.FindDeletedByID(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	if err := book.SoftDelete(); err != nil {
+	return s.purge(ctx, book)
+}
+
+// PurgeDeletedBefore permanently deletes the books soft deleted before the given time and returns how many were purged
+func (s *bookServiceImpl) PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	books, err := s.books.FindAllDeletedBefore(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+
+	var purged int64
+	for i := range books {
+		if err := s.purge(ctx, &books[i]); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// purge permanently deletes book and records the change
+func (s *bookServiceImpl) purge(ctx context.Context, book *Book) error {
+	return s.books.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.books.Purge(ctx, book); err != nil {
+			return err
+		}
+		return s.history.Record(ctx, entityType, book.ID, book.Version, history.ActionPurge, toBookDetailResponse(book), nil)
+	})
+}
+
+// validate checks the book data and that its publisher exists and its ISBN is not taken
+func (s *bookServiceImpl) validate(ctx context.Context, book *Book) error {
+	if err := book.Validate(); err != nil {
 		return err
 	}
 
+	// Validate that publisher exists
+	if _, err := s.publishers.FindByID(ctx, book.PublisherID); err != nil {
+		if errors.Is(err, publisher.ErrPublisherNotFound) {
+			return ErrPublisherNotFound
+		}
+		return err
+	}
+
+	// Validate that no other book uses the same ISBN
+	if book.ISBN13 != "" {
+		exists, err := s.books.ExistsByISBN(ctx, book.ISBN13, book.ID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return ErrISBNTaken
+		}
+	}
+
 	return nil
 }
+
+// findAuthors loads the authors with the given IDs and fails if any of them does not exist
+func (s *bookServiceImpl) findAuthors(ctx context.Context, authorIDs []uint) ([]author.Author, error) {
+	var authors []author.Author
+	if len(authorIDs) == 0 {
+		return authors, nil
+	}
+	// An ID listed twice, e.g. added again by a patch, links the author once
+	authorIDs = slices.Compact(slices.Sorted(slices.Values(authorIDs)))
+	authors, err := s.authors.FindByIDs(ctx, authorIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(authors) != len(authorIDs) {
+		return nil, ErrAuthorsNotFound
+	}
+	return authors, nil
+}
+
+// findCategories loads the categories with the given IDs and fails if any of them does not exist
+func (s *bookServiceImpl) findCategories(ctx context.Context, categoryIDs []uint) ([]category.Category, error) {
+	var categories []category.Category
+	if len(categoryIDs) == 0 {
+		return categories, nil
+	}
+	// An ID listed twice, e.g. added again by a patch, links the category once
+	categoryIDs = slices.Compact(slices.Sorted(slices.Values(categoryIDs)))
+	categories, err := s.categories.FindByIDs(ctx, categoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(categories) != len(categoryIDs) {
+		return nil, ErrCategoriesNotFound
+	}
+	return categories, nil
+}
+
+// toBookListResponse converts a page of books into a BookListResponse with the cursors of its neighbours
+func toBookListResponse(books []Book, page pagination.Request, sort sorting.Spec, total uint64) *BookListResponse {
+	result := pagination.New(books, page, total).
+		WithCursors(sort, func(b Book, field string) interface{} { return b.SortValue(field) })
+
+	return pagination.Map(result, func(book Book) BookDetailResponse {
+		return *toBookDetailResponse(&book)
+	})
+}
+
+// toBookRequest converts a Book into the BookRequest that would leave it unchanged.
+// Author and category IDs are sorted so JSON patches can address them by index.
+func toBookRequest(book *Book) BookRequest {
+	authorIDs, categoryIDs := book.AuthorIDs(), book.CategoryIDs()
+	slices.Sort(authorIDs)
+	slices.Sort(categoryIDs)
+	return BookRequest{
+		Title:       book.Title,
+		Description: book.Description,
+		ISBN10:      book.ISBN10,
+		ISBN13:      book.ISBN13,
+		Pages:       book.Pages,
+		Year:        book.Year,
+		PublisherID: book.PublisherID,
+		AuthorIDs:   authorIDs,
+		CategoryIDs: categoryIDs,
+	}
+}
+
+// fromBookDetailResponse converts a BookDetailResponse, e.g. a state from the history of a book,
+// into the BookRequest that would restore it
+func fromBookDetailResponse(state *BookDetailResponse) (BookRequest, error) {
+	publisherID, err := strconv.ParseUint(state.Publisher.ID, 10, 32)
+	if err != nil {
+		return BookRequest{}, err
+	}
+
+	authorIDs := make([]uint, len(state.Authors))
+	for i, a := range state.Authors {
+		id, err := strconv.ParseUint(a.ID, 10, 32)
+		if err != nil {
+			return BookRequest{}, err
+		}
+		authorIDs[i] = uint(id)
+	}
+
+	categoryIDs := make([]uint, len(state.Categories))
+	for i, c := range state.Categories {
+		id, err := strconv.ParseUint(c.ID, 10, 32)
+		if err != nil {
+			return BookRequest{}, err
+		}
+		categoryIDs[i] = uint(id)
+	}
+
+	return BookRequest{
+		Title:       state.Title,
+		Description: state.Description,
+		ISBN10:      state.ISBN10,
+		ISBN13:      state.ISBN13,
+		Pages:       state.Pages,
+		Year:        state.Year,
+		PublisherID: uint(publisherID),
+		AuthorIDs:   authorIDs,
+		CategoryIDs: categoryIDs,
+	}, nil
+}
+
+// toBookDetailResponse converts a Book with its relations into a BookDetailResponse
+func toBookDetailResponse(book *Book) *BookDetailResponse {
+	// Convert Publisher to PublisherDTO
+	publisherDTO := publisher.PublisherDTO{
+		ID:   fmt.Sprintf("%d", book.Publisher.ID),
+		Name: book.Publisher.Name,
+	}
+
+	// Convert Authors to AuthorDTOs
+	authorDTOs := make([]author.AuthorDTO, len(book.Authors))
+	for i, a := range book.Authors {
+		authorDTOs[i] = author.AuthorDTO{
+			ID:   fmt.Sprintf("%d", a.ID),
+			Name: a.Name,
+		}
+	}
+
+	// Convert Categories to CategoryDTOs
+	categoryDTOs := make([]category.CategoryDTO, len(book.Categories))
+	for i, c := range book.Categories {
+		categoryDTOs[i] = category.CategoryDTO{
+			ID:   fmt.Sprintf("%d", c.ID),
+			Code: c.Code,
+			Name: c.Name,
+		}
+	}
+
+	return &BookDetailResponse{
+		ID:          book.ID,
+		Version:     book.Version,
+		Title:       book.Title,
+		Description: book.Description,
+		ISBN10:      book.ISBN10,
+		ISBN13:      book.ISBN13,
+		Pages:       book.Pages,
+		Year:        book.Year,
+		Publisher:   publisherDTO,
+		Authors:     authorDTOs,
+		Categories:  categoryDTOs,
+		CreatedAt:   book.CreatedAt,
+		UpdatedAt:   book.UpdatedAt,
+	}
+}
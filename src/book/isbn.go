@@ -0,0 +1,104 @@
+package book
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidISBN is returned when an ISBN has the wrong length, characters or checksum
+var ErrInvalidISBN = errors.New("invalid isbn")
+
+// NormalizeISBN validates an ISBN-10 or ISBN-13, with or without hyphens,
+// and returns its canonical ISBN-13 form
+func NormalizeISBN(isbn string) (string, error) {
+	isbn = cleanISBN(isbn)
+	switch {
+	case isValidISBN10(isbn):
+		return isbn10To13(isbn), nil
+	case isValidISBN13(isbn):
+		return isbn, nil
+	default:
+		return "", ErrInvalidISBN
+	}
+}
+
+// cleanISBN strips hyphens and spaces and upper-cases the ISBN-10 check character
+func cleanISBN(isbn string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(isbn))
+}
+
+// isValidISBN10 reports whether isbn is ten characters long with a valid mod 11 checksum
+func isValidISBN10(isbn string) bool {
+	if len(isbn) != 10 {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 10; i++ {
+		var digit int
+		switch c := isbn[i]; {
+		case c >= '0' && c <= '9':
+			digit = int(c - '0')
+		case c == 'X' && i == 9:
+			digit = 10
+		default:
+			return false
+		}
+		sum += digit * (10 - i)
+	}
+	return sum%11 == 0
+}
+
+// isValidISBN13 reports whether isbn is thirteen digits long with a valid mod 10 checksum
+func isValidISBN13(isbn string) bool {
+	if len(isbn) != 13 {
+		return false
+	}
+	for i := 0; i < 13; i++ {
+		if isbn[i] < '0' || isbn[i] > '9' {
+			return false
+		}
+	}
+	return isbn13CheckDigit(isbn[:12]) == isbn[12]
+}
+
+// isbn13CheckDigit computes the ISBN-13 check digit for the first twelve digits
+func isbn13CheckDigit(digits string) byte {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += int(digits[i]-'0') * weight
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// isbn10CheckDigit computes the ISBN-10 check character for the first nine digits
+func isbn10CheckDigit(digits string) byte {
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += int(digits[i]-'0') * (10 - i)
+	}
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return 'X'
+	}
+	return byte('0' + check)
+}
+
+// isbn10To13 converts a valid ISBN-10 into its 978-prefixed ISBN-13
+func isbn10To13(isbn string) string {
+	digits := "978" + isbn[:9]
+	return digits + string(isbn13CheckDigit(digits))
+}
+
+// isbn13To10 converts a valid 978-prefixed ISBN-13 into its ISBN-10, or returns
+// an empty string when the ISBN-13 has no ISBN-10 equivalent
+func isbn13To10(isbn string) string {
+	if !strings.HasPrefix(isbn, "978") {
+		return ""
+	}
+	digits := isbn[3:12]
+	return digits + string(isbn10CheckDigit(digits))
+}
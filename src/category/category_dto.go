@@ -1,5 +1,7 @@
 package category
 
+import "github.com/tedysaputro/book-catalog-with-go/src/pagination"
+
 import "time"
 
 // CategoryRequest represents the request payload for creating/updating a category
@@ -12,6 +14,7 @@ type CategoryRequest struct {
 // CategoryDetailResponse represents the response payload for a single category
 type CategoryDetailResponse struct {
 	ID          uint      `json:"id"`
+	Version     uint      `json:"version"`
 	Code        string    `json:"code"`
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
@@ -20,8 +23,20 @@ type CategoryDetailResponse struct {
 }
 
 // CategoryListResponse represents the response payload for multiple categories
-type CategoryListResponse struct {
-	Categories []CategoryDetailResponse `json:"data"`
-	Page      uint                     `json:"page"`
-	Total     uint64                   `json:"total"`
+type CategoryListResponse = pagination.Page[CategoryDetailResponse]
+
+// CategoryDTO represents a compact category embedded in other resources
+type CategoryDTO struct {
+	ID   string `json:"id"`
+	Code string `json:"code"`
+	Name string `json:"name"`
 }
+
+// CategoryTrashResponse represents a deleted category in the trash
+type CategoryTrashResponse struct {
+	CategoryDetailResponse
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// CategoryTrashListResponse represents a page of deleted categories
+type CategoryTrashListResponse = pagination.Page[CategoryTrashResponse]
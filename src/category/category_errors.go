@@ -0,0 +1,16 @@
+package category
+
+import "github.com/tedysaputro/book-catalog-with-go/src/apperror"
+
+var (
+	// ErrCategoryNotFound is returned when no live category has the requested ID
+	ErrCategoryNotFound = apperror.NotFound("category not found")
+	// ErrCategoryNotInTrash is returned when no deleted category has the requested ID
+	ErrCategoryNotInTrash = apperror.NotFound("category not found in trash")
+	// ErrCodeRequired is returned when a category has no code
+	ErrCodeRequired = apperror.Validation("code", "code is required")
+	// ErrNameRequired is returned when a category has no name
+	ErrNameRequired = apperror.Validation("name", "name is required")
+	// ErrCodeTaken is returned when another category already uses the code
+	ErrCodeTaken = apperror.Conflict("a category with this code already exists")
+)
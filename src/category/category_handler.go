@@ -4,6 +4,13 @@ import (
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/fuzzy"
+	"github.com/tedysaputro/book-catalog-with-go/src/history"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/patch"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+	"github.com/tedysaputro/book-catalog-with-go/src/version"
 )
 
 // CategoryHandler handles HTTP requests for categories
@@ -20,91 +27,150 @@ func NewCategoryHandler(service CategoryService) *CategoryHandler {
 func (h *CategoryHandler) CreateCategory(c *fiber.Ctx) error {
 	var request CategoryRequest
 	if err := c.BodyParser(&request); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	category, err := h.service.CreateCategory(request)
+	category, err := h.service.CreateCategory(c.UserContext(), request)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(category)
 }
 
-// GetCategory handles GET /categories/:id request
+// RevertCategory handles POST /categories/:id/revert request
+func (h *CategoryHandler) RevertCategory(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid category ID")
+	}
+
+	var request history.RevertRequest
+	if err := c.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	match, err := version.IfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	dto, err := h.service.RevertCategory(c.UserContext(), uint(id), request.Version, match)
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderETag, version.ETag(dto.Version))
+	return c.JSON(dto)
+}
+
+// GetCategory handles GET /categories/:id request, returning the state at as_of when given
 func (h *CategoryHandler) GetCategory(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid category ID",
-		})
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid category ID")
 	}
 
-	category, err := h.service.GetCategory(uint(id))
+	at, ok, err := history.AsOf(c)
 	if err != nil {
-		if err.Error() == "category not found" {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": err.Error(),
-			})
+		return err
+	}
+	if ok {
+		dto, err := h.service.GetCategoryAsOf(c.UserContext(), uint(id), at)
+		if err != nil {
+			return err
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.JSON(dto)
 	}
 
-	return c.JSON(category)
+	category, err := h.service.GetCategory(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return version.Send(c, category.Version, category)
 }
 
 // GetCategories handles GET /categories request
 func (h *CategoryHandler) GetCategories(c *fiber.Ctx) error {
-	page := uint(c.QueryInt("pages", 1))
-	limit := uint(c.QueryInt("limit", 10))
-	sortBy := c.Query("sortBy", "id")
-	direction := c.Query("direction", "asc")
+	sort, err := sorting.FromQuery(c.Query("sort"), c.Query("sortBy"), c.Query("direction"), SortFields)
+	if err != nil {
+		return apperror.BadRequest("sort", err)
+	}
+	sort = sort.Stable(SortFields)
 	categoryName := c.Query("categoryName", "")
+	match := fuzzy.Options{
+		Enabled:   c.QueryBool("fuzzy"),
+		Threshold: c.QueryFloat("threshold", fuzzy.DefaultThreshold),
+	}
+	if err := match.Validate(); err != nil {
+		return apperror.BadRequest("threshold", err)
+	}
+	page, err := pagination.FromQuery(c, sort)
+	if err != nil {
+		return err
+	}
+	if match.Enabled && page.Cursor != nil {
+		return apperror.BadRequest("cursor", pagination.ErrCursorUnsupported)
+	}
 
-	categories, err := h.service.GetCategories(page, limit, sortBy, direction, categoryName)
+	categories, err := h.service.GetCategories(c.UserContext(), page, sort, categoryName, match)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
 	}
 
-	return c.JSON(categories)
+	return c.JSON(categories.WithLinks(c))
 }
 
 // UpdateCategory handles PUT /categories/:id request
 func (h *CategoryHandler) UpdateCategory(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid category ID",
-		})
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid category ID")
 	}
 
 	var request CategoryRequest
 	if err := c.BodyParser(&request); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	category, err := h.service.UpdateCategory(uint(id), request)
+	match, err := version.IfMatch(c)
 	if err != nil {
-		if err.Error() == "category not found" {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": err.Error(),
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
+	}
+
+	category, err := h.service.UpdateCategory(c.UserContext(), uint(id), request, match)
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderETag, version.ETag(category.Version))
+	return c.JSON(category)
+}
+
+// PatchCategory handles PATCH /categories/:id request
+func (h *CategoryHandler) PatchCategory(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid category ID")
+	}
+
+	p, err := patch.FromRequest(c)
+	if err != nil {
+		return err
 	}
 
+	match, err := version.IfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	category, err := h.service.PatchCategory(c.UserContext(), uint(id), p, match)
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderETag, version.ETag(category.Version))
 	return c.JSON(category)
 }
 
@@ -112,20 +178,65 @@ func (h *CategoryHandler) UpdateCategory(c *fiber.Ctx) error {
 func (h *CategoryHandler) DeleteCategory(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid category ID",
-		})
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid category ID")
 	}
 
-	if err := h.service.DeleteCategory(uint(id)); err != nil {
-		if err.Error() == "category not found" {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": err.Error(),
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+	match, err := version.IfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.DeleteCategory(c.UserContext(), uint(id), match); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetDeletedCategories handles GET /categories/trash request
+func (h *CategoryHandler) GetDeletedCategories(c *fiber.Ctx) error {
+	sort, err := sorting.FromQuery(c.Query("sort"), c.Query("sortBy"), c.Query("direction"), SortFields)
+	if err != nil {
+		return apperror.BadRequest("sort", err)
+	}
+	sort = sort.Stable(SortFields)
+	page, err := pagination.FromQuery(c, sort)
+	if err != nil {
+		return err
+	}
+
+	categories, err := h.service.GetDeletedCategories(c.UserContext(), page, sort)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(categories.WithLinks(c))
+}
+
+// RestoreCategory handles POST /categories/:id/restore request
+func (h *CategoryHandler) RestoreCategory(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid category ID")
+	}
+
+	category, err := h.service.RestoreCategory(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(category)
+}
+
+// PurgeCategory handles DELETE /categories/:id/purge request
+func (h *CategoryHandler) PurgeCategory(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid category ID")
+	}
+
+	if err := h.service.PurgeCategory(c.UserContext(), uint(id)); err != nil {
+		return err
 	}
 
 	return c.SendStatus(fiber.StatusNoContent)
@@ -133,10 +244,19 @@ func (h *CategoryHandler) DeleteCategory(c *fiber.Ctx) error {
 
 // RegisterRoutes registers the category routes
 func (h *CategoryHandler) RegisterRoutes(app *fiber.App) {
-	categories := app.Group("/categories")
+	categories := app.Group("/api/v1/categories")
 	categories.Post("/", h.CreateCategory)
 	categories.Get("/", h.GetCategories)
+	categories.Get("/trash", h.GetDeletedCategories)
 	categories.Get("/:id", h.GetCategory)
 	categories.Put("/:id", h.UpdateCategory)
+	categories.Patch("/:id", h.PatchCategory)
 	categories.Delete("/:id", h.DeleteCategory)
+	categories.Post("/:id/restore", h.RestoreCategory)
+	categories.Post("/:id/revert", h.RevertCategory)
+}
+
+// RegisterAdminRoutes registers the category routes that are only open to admins, guarded by admin
+func (h *CategoryHandler) RegisterAdminRoutes(app *fiber.App, admin fiber.Handler) {
+	app.Delete("/api/v1/categories/:id/purge", admin, h.PurgeCategory)
 }
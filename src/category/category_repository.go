@@ -0,0 +1,205 @@
+package category
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/fuzzy"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+	"github.com/tedysaputro/book-catalog-with-go/src/transaction"
+	"github.com/tedysaputro/book-catalog-with-go/src/version"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CategoryRepository defines the persistence operations for categories
+type CategoryRepository interface {
+	Create(ctx context.Context, category *Category) error
+	Update(ctx context.Context, category *Category) error
+	FindByID(ctx context.Context, id uint) (*Category, error)
+	FindByIDs(ctx context.Context, ids []uint) ([]Category, error)
+	FindByCode(ctx context.Context, code string) (*Category, error)
+	FindAll(ctx context.Context, page pagination.Request, sort sorting.Spec, categoryName string, match fuzzy.Options) ([]Category, uint64, error)
+	SoftDelete(ctx context.Context, category *Category) error
+	FindAllDeleted(ctx context.Context, page pagination.Request, sort sorting.Spec) ([]Category, uint64, error)
+	FindDeletedByID(ctx context.Context, id uint) (*Category, error)
+	Restore(ctx context.Context, category *Category) error
+	Purge(ctx context.Context, category *Category) error
+	FindAllDeletedBefore(ctx context.Context, before time.Time) ([]Category, error)
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type gormCategoryRepository struct {
+	db *gorm.DB
+}
+
+// NewGormCategoryRepository creates a CategoryRepository backed by GORM
+func NewGormCategoryRepository(db *gorm.DB) CategoryRepository {
+	return &gormCategoryRepository{db: db}
+}
+
+// Transaction runs fn in a database transaction that the writes of every repository made with
+// the context passed to fn join, keeping them only if fn returns nil
+func (r *gormCategoryRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction.Run(ctx, r.db, fn)
+}
+
+// Create inserts a new Category record
+func (r *gormCategoryRepository) Create(ctx context.Context, category *Category) error {
+	if err := category.Validate(); err != nil {
+		return err
+	}
+	return translateError(transaction.DB(ctx, r.db).Create(category).Error)
+}
+
+// Update modifies an existing Category record
+func (r *gormCategoryRepository) Update(ctx context.Context, category *Category) error {
+	if err := category.Validate(); err != nil {
+		return err
+	}
+	return translateError(version.Save(transaction.DB(ctx, r.db), category, &category.Version))
+}
+
+// FindByID retrieves a Category by ID while deleted_at is null
+func (r *gormCategoryRepository) FindByID(ctx context.Context, id uint) (*Category, error) {
+	var category Category
+	err := transaction.DB(ctx, r.db).First(&category, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, err
+	}
+	return &category, nil
+}
+
+// FindByCode retrieves the live Category with the given code
+func (r *gormCategoryRepository) FindByCode(ctx context.Context, code string) (*Category, error) {
+	var category Category
+	err := transaction.DB(ctx, r.db).Where("categories.code = ?", code).First(&category).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, err
+	}
+	return &category, nil
+}
+
+// FindByIDs retrieves the Categories with the given IDs, skipping IDs that do not exist
+func (r *gormCategoryRepository) FindByIDs(ctx context.Context, ids []uint) ([]Category, error) {
+	var categories []Category
+	if len(ids) == 0 {
+		return categories, nil
+	}
+	if err := transaction.DB(ctx, r.db).Find(&categories, ids).Error; err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// FindAll retrieves all Categories while deleted_at is null
+func (r *gormCategoryRepository) FindAll(ctx context.Context, page pagination.Request, sort sorting.Spec, categoryName string, match fuzzy.Options) ([]Category, uint64, error) {
+	var categories []Category
+	var total int64
+
+	err := fuzzy.Run(ctx, r.db, match, func(ctx context.Context) error {
+		// Count total records
+		query, rank := filterByName(transaction.DB(ctx, r.db).Model(&Category{}), categoryName, match)
+		if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+			return err
+		}
+
+		// Get records with pagination
+		return page.Apply(query, sort, rank...).Find(&categories).Error
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return categories, uint64(total), nil
+}
+
+// SoftDelete performs a soft delete on the Category record
+func (r *gormCategoryRepository) SoftDelete(ctx context.Context, category *Category) error {
+	return version.SoftDelete(transaction.DB(ctx, r.db), category, category.Version)
+}
+
+// FindAllDeleted retrieves the soft deleted Category records
+func (r *gormCategoryRepository) FindAllDeleted(ctx context.Context, page pagination.Request, sort sorting.Spec) ([]Category, uint64, error) {
+	var categories []Category
+	var total int64
+
+	query := transaction.DB(ctx, r.db).Unscoped().Model(&Category{}).Where("categories.deleted_at IS NOT NULL")
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := page.Apply(query, sort).Find(&categories).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return categories, uint64(total), nil
+}
+
+// FindDeletedByID retrieves a soft deleted Category by ID
+func (r *gormCategoryRepository) FindDeletedByID(ctx context.Context, id uint) (*Category, error) {
+	var category Category
+	err := transaction.DB(ctx, r.db).Unscoped().Where("deleted_at IS NOT NULL").First(&category, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCategoryNotInTrash
+		}
+		return nil, err
+	}
+	return &category, nil
+}
+
+// Restore clears the deletion time of a soft deleted Category record and increments its version
+func (r *gormCategoryRepository) Restore(ctx context.Context, category *Category) error {
+	if err := translateError(version.Restore(transaction.DB(ctx, r.db), category, &category.Version)); err != nil {
+		return err
+	}
+	category.DeletedAt = gorm.DeletedAt{}
+	return nil
+}
+
+// Purge permanently deletes a Category record and unlinks it from its books
+func (r *gormCategoryRepository) Purge(ctx context.Context, category *Category) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM book_categories WHERE category_id = ?", category.ID).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(category).Error
+	})
+}
+
+// FindAllDeletedBefore retrieves the Category records soft deleted before the given time, ordered by ID
+func (r *gormCategoryRepository) FindAllDeletedBefore(ctx context.Context, before time.Time) ([]Category, error) {
+	var categories []Category
+	err := transaction.DB(ctx, r.db).Unscoped().Where("deleted_at < ?", before).Order("id").Find(&categories).Error
+	return categories, err
+}
+
+// translateError reports a unique violation of categories.code as ErrCodeTaken
+func translateError(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrCodeTaken
+	}
+	return err
+}
+
+// filterByName keeps the categories whose name contains name, or resembles it when match is enabled,
+// and returns the similarity ranking of fuzzy matches
+func filterByName(query *gorm.DB, name string, match fuzzy.Options) (*gorm.DB, []clause.Expr) {
+	if name == "" {
+		return query, nil
+	}
+	if match.Enabled {
+		return fuzzy.Where(query, "categories.name", name), []clause.Expr{fuzzy.Rank("categories.name", name)}
+	}
+	return query.Where("UPPER(categories.name) LIKE ?", "%"+strings.ToUpper(name)+"%"), nil
+}
@@ -0,0 +1,248 @@
+package category
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/fuzzy"
+	"github.com/tedysaputro/book-catalog-with-go/src/memory"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+	"github.com/tedysaputro/book-catalog-with-go/src/version"
+	"gorm.io/gorm"
+)
+
+type memoryCategoryRepository struct {
+	mu         sync.RWMutex
+	categories map[uint]Category
+	nextID     uint
+}
+
+// NewMemoryCategoryRepository creates a CategoryRepository that keeps categories in memory
+func NewMemoryCategoryRepository() CategoryRepository {
+	return &memoryCategoryRepository{categories: make(map[uint]Category)}
+}
+
+// Transaction runs fn; the writes it made before a failure are not rolled back
+func (r *memoryCategoryRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// Create stores a new Category
+func (r *memoryCategoryRepository) Create(ctx context.Context, category *Category) error {
+	if err := category.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.codeTaken(category.Code, 0) {
+		return ErrCodeTaken
+	}
+
+	r.nextID++
+	now := time.Now()
+	category.ID = r.nextID
+	category.Version = 1
+	category.CreatedAt = now
+	category.UpdatedAt = now
+	r.categories[category.ID] = *category
+	return nil
+}
+
+// Update replaces a stored Category
+func (r *memoryCategoryRepository) Update(ctx context.Context, category *Category) error {
+	if err := category.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.codeTaken(category.Code, category.ID) {
+		return ErrCodeTaken
+	}
+
+	if stored, ok := r.categories[category.ID]; !ok || stored.Version != category.Version {
+		return version.ErrStale
+	}
+	category.Version++
+	category.UpdatedAt = time.Now()
+	r.categories[category.ID] = *category
+	return nil
+}
+
+// FindByID retrieves a live Category by ID
+func (r *memoryCategoryRepository) FindByID(ctx context.Context, id uint) (*Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	category, ok := r.categories[id]
+	if !ok || category.DeletedAt.Valid {
+		return nil, ErrCategoryNotFound
+	}
+	return &category, nil
+}
+
+// FindByCode retrieves the live Category with the given code
+func (r *memoryCategoryRepository) FindByCode(ctx context.Context, code string) (*Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, category := range r.categories {
+		if !category.DeletedAt.Valid && category.Code == code {
+			return &category, nil
+		}
+	}
+	return nil, ErrCategoryNotFound
+}
+
+// FindByIDs retrieves the live Categories with the given IDs, skipping IDs that do not exist
+func (r *memoryCategoryRepository) FindByIDs(ctx context.Context, ids []uint) ([]Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	categories := []Category{}
+	for _, id := range ids {
+		if category, ok := r.categories[id]; ok && !category.DeletedAt.Valid {
+			categories = append(categories, category)
+		}
+	}
+	return categories, nil
+}
+
+// FindAll retrieves all live Categories matching categoryName
+func (r *memoryCategoryRepository) FindAll(ctx context.Context, page pagination.Request, sort sorting.Spec, categoryName string, match fuzzy.Options) ([]Category, uint64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	categories := []Category{}
+	for _, category := range r.categories {
+		if category.DeletedAt.Valid {
+			continue
+		}
+		switch {
+		case categoryName == "":
+		case match.Enabled && fuzzy.Similarity(categoryName, category.Name) < match.Threshold:
+			continue
+		case !match.Enabled && !memory.ContainsFold(category.Name, categoryName):
+			continue
+		}
+		categories = append(categories, category)
+	}
+
+	var score func(Category) float64
+	if categoryName != "" && match.Enabled {
+		score = func(c Category) float64 { return fuzzy.Similarity(categoryName, c.Name) }
+	}
+	value := func(c Category, field string) interface{} { return c.SortValue(field) }
+	memory.Sort(categories, sort, value, score)
+
+	return memory.Paginate(categories, page, sort, value), uint64(len(categories)), nil
+}
+
+// SoftDelete soft deletes a stored Category
+func (r *memoryCategoryRepository) SoftDelete(ctx context.Context, category *Category) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.categories[category.ID]
+	if !ok {
+		return nil
+	}
+	if stored.Version != category.Version {
+		return version.ErrStale
+	}
+	stored.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	r.categories[category.ID] = stored
+	return nil
+}
+
+// FindAllDeleted retrieves the soft deleted Category records
+func (r *memoryCategoryRepository) FindAllDeleted(ctx context.Context, page pagination.Request, sort sorting.Spec) ([]Category, uint64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	categories := []Category{}
+	for _, category := range r.categories {
+		if category.DeletedAt.Valid {
+			categories = append(categories, category)
+		}
+	}
+
+	value := func(c Category, field string) interface{} { return c.SortValue(field) }
+	memory.Sort(categories, sort, value, nil)
+
+	return memory.Paginate(categories, page, sort, value), uint64(len(categories)), nil
+}
+
+// FindDeletedByID retrieves a soft deleted Category by ID
+func (r *memoryCategoryRepository) FindDeletedByID(ctx context.Context, id uint) (*Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	category, ok := r.categories[id]
+	if !ok || !category.DeletedAt.Valid {
+		return nil, ErrCategoryNotInTrash
+	}
+	return &category, nil
+}
+
+// Restore clears the deletion time of a stored Category
+func (r *memoryCategoryRepository) Restore(ctx context.Context, category *Category) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.categories[category.ID]
+	if !ok {
+		return nil
+	}
+	if r.codeTaken(stored.Code, stored.ID) {
+		return ErrCodeTaken
+	}
+	stored.DeletedAt = gorm.DeletedAt{}
+	stored.Version++
+	stored.UpdatedAt = time.Now()
+	r.categories[category.ID] = stored
+	*category = stored
+	return nil
+}
+
+// Purge permanently removes a stored Category
+func (r *memoryCategoryRepository) Purge(ctx context.Context, category *Category) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.categories, category.ID)
+	return nil
+}
+
+// FindAllDeletedBefore retrieves the stored Categories soft deleted before the given time, ordered by ID
+func (r *memoryCategoryRepository) FindAllDeletedBefore(ctx context.Context, before time.Time) ([]Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	categories := []Category{}
+	for _, category := range r.categories {
+		if category.DeletedAt.Valid && category.DeletedAt.Time.Before(before) {
+			categories = append(categories, category)
+		}
+	}
+	slices.SortFunc(categories, func(a, b Category) int { return cmp.Compare(a.ID, b.ID) })
+	return categories, nil
+}
+
+// codeTaken reports whether a live category other than excludeID uses code,
+// mirroring the unique index on categories.code
+func (r *memoryCategoryRepository) codeTaken(code string, excludeID uint) bool {
+	for _, c := range r.categories {
+		if !c.DeletedAt.Valid && c.ID != excludeID && c.Code == code {
+			return true
+		}
+	}
+	return false
+}
@@ -1,122 +1,328 @@
 package category
 
+import (
+	"context"
+	"time"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/deletion"
+	"github.com/tedysaputro/book-catalog-with-go/src/fuzzy"
+	"github.com/tedysaputro/book-catalog-with-go/src/history"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/patch"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+	"github.com/tedysaputro/book-catalog-with-go/src/version"
+)
+
 // CategoryService defines the interface for category operations
 type CategoryService interface {
-	CreateCategory(request CategoryRequest) (*CategoryDetailResponse, error)
-	GetCategory(id uint) (*CategoryDetailResponse, error)
-	GetCategories(p uint, limit uint, sortBy string, direction string, categoryName string) (*CategoryListResponse, error)
-	UpdateCategory(id uint, request CategoryRequest) (*CategoryDetailResponse, error)
-	DeleteCategory(id uint) error
+	CreateCategory(ctx context.Context, request CategoryRequest) (*CategoryDetailResponse, error)
+	GetCategory(ctx context.Context, id uint) (*CategoryDetailResponse, error)
+	GetCategoriesByIDs(ctx context.Context, ids []uint) ([]CategoryDetailResponse, error)
+	GetCategoryAsOf(ctx context.Context, id uint, at time.Time) (*CategoryDetailResponse, error)
+	GetCategories(ctx context.Context, page pagination.Request, sort sorting.Spec, categoryName string, match fuzzy.Options) (*CategoryListResponse, error)
+	UpdateCategory(ctx context.Context, id uint, request CategoryRequest, match version.Precondition) (*CategoryDetailResponse, error)
+	PatchCategory(ctx context.Context, id uint, p patch.Patch, match version.Precondition) (*CategoryDetailResponse, error)
+	RevertCategory(ctx context.Context, id uint, to uint, match version.Precondition) (*CategoryDetailResponse, error)
+	DeleteCategory(ctx context.Context, id uint, match version.Precondition) error
+	GetDeletedCategories(ctx context.Context, page pagination.Request, sort sorting.Spec) (*CategoryTrashListResponse, error)
+	RestoreCategory(ctx context.Context, id uint) (*CategoryDetailResponse, error)
+	PurgeCategory(ctx context.Context, id uint) error
+	PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error)
 }
 
-type categoryServiceImpl struct{}
+// entityType names categories in their change history
+const entityType = "category"
+
+type categoryServiceImpl struct {
+	repo    CategoryRepository
+	books   deletion.Links
+	history history.HistoryService
+}
 
-// NewCategoryService creates a new instance of CategoryService
-func NewCategoryService() CategoryService {
-	return &categoryServiceImpl{}
+// NewCategoryService creates a new instance of CategoryService.
+// books are the records showing categories, history records every change of a category.
+func NewCategoryService(repo CategoryRepository, books deletion.Links, history history.HistoryService) CategoryService {
+	return &categoryServiceImpl{repo: repo, books: books, history: history}
 }
 
 // CreateCategory creates a new category
-func (s *categoryServiceImpl) CreateCategory(request CategoryRequest) (*CategoryDetailResponse, error) {
+func (s *categoryServiceImpl) CreateCategory(ctx context.Context, request CategoryRequest) (*CategoryDetailResponse, error) {
 	category := Category{
 		Code:        request.Code,
 		Name:        request.Name,
 		Description: request.Description,
 	}
 
-	if err := category.Create(); err != nil {
+	var dto *CategoryDetailResponse
+	err := s.repo.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, &category); err != nil {
+			return err
+		}
+		dto = toCategoryDetailResponse(&category)
+		return s.history.Record(ctx, entityType, category.ID, category.Version, history.ActionCreate, nil, dto)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return &CategoryDetailResponse{
-		ID:          category.ID,
-		Code:        category.Code,
-		Name:        category.Name,
-		Description: category.Description,
-		CreatedAt:   category.CreatedAt,
-		UpdatedAt:   category.UpdatedAt,
-	}, nil
+	return dto, nil
 }
 
 // GetCategory retrieves a category by ID
-func (s *categoryServiceImpl) GetCategory(id uint) (*CategoryDetailResponse, error) {
-	category, err := FindByID(id)
+func (s *categoryServiceImpl) GetCategory(ctx context.Context, id uint) (*CategoryDetailResponse, error) {
+	category, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	return &CategoryDetailResponse{
-		ID:          category.ID,
-		Code:        category.Code,
-		Name:        category.Name,
-		Description: category.Description,
-		CreatedAt:   category.CreatedAt,
-		UpdatedAt:   category.UpdatedAt,
-	}, nil
+	return toCategoryDetailResponse(category), nil
+}
+
+// GetCategoriesByIDs retrieves the categories with the given IDs, skipping IDs that do not exist
+func (s *categoryServiceImpl) GetCategoriesByIDs(ctx context.Context, ids []uint) ([]CategoryDetailResponse, error) {
+	categories, err := s.repo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryDTOs := make([]CategoryDetailResponse, len(categories))
+	for i := range categories {
+		categoryDTOs[i] = *toCategoryDetailResponse(&categories[i])
+	}
+
+	return categoryDTOs, nil
+}
+
+// GetCategoryAsOf retrieves the state a category had at the given time by ID
+func (s *categoryServiceImpl) GetCategoryAsOf(ctx context.Context, id uint, at time.Time) (*CategoryDetailResponse, error) {
+	var state CategoryDetailResponse
+	if err := s.history.StateAsOf(ctx, entityType, id, at, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
 }
 
 // GetCategories retrieves a list of categories with pagination
-func (s *categoryServiceImpl) GetCategories(p uint, limit uint, sortBy string, direction string, categoryName string) (*CategoryListResponse, error) {
-	categories, page, total, err := FindAll(p, limit, sortBy, direction, categoryName)
+func (s *categoryServiceImpl) GetCategories(ctx context.Context, page pagination.Request, sort sorting.Spec, categoryName string, match fuzzy.Options) (*CategoryListResponse, error) {
+	categories, total, err := s.repo.FindAll(ctx, page, sort, categoryName, match)
 	if err != nil {
 		return nil, err
 	}
 
-	var categoryDTOs []CategoryDetailResponse
-	for _, category := range categories {
-		dto := CategoryDetailResponse{
+	result := pagination.New(categories, page, total).
+		WithCursors(sort, func(c Category, field string) interface{} { return c.SortValue(field) })
+
+	return pagination.Map(result, func(category Category) CategoryDetailResponse {
+		return CategoryDetailResponse{
 			ID:          category.ID,
+			Version:     category.Version,
 			Code:        category.Code,
 			Name:        category.Name,
 			Description: category.Description,
 			CreatedAt:   category.CreatedAt,
 			UpdatedAt:   category.UpdatedAt,
 		}
-		categoryDTOs = append(categoryDTOs, dto)
+	}), nil
+}
+
+// UpdateCategory updates a category by ID
+func (s *categoryServiceImpl) UpdateCategory(ctx context.Context, id uint, request CategoryRequest, match version.Precondition) (*CategoryDetailResponse, error) {
+	category, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := match.Check(category.Version); err != nil {
+		return nil, err
 	}
 
-	return &CategoryListResponse{
-		Categories: categoryDTOs,
-		Page:      page,
-		Total:     total,
-	}, nil
+	return s.update(ctx, category, request, history.ActionUpdate)
 }
 
-// UpdateCategory updates a category by ID
-func (s *categoryServiceImpl) UpdateCategory(id uint, request CategoryRequest) (*CategoryDetailResponse, error) {
-	category, err := FindByID(id)
+// PatchCategory applies a merge patch or JSON patch to the fields of a category by ID
+func (s *categoryServiceImpl) PatchCategory(ctx context.Context, id uint, p patch.Patch, match version.Precondition) (*CategoryDetailResponse, error) {
+	category, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if err := match.Check(category.Version); err != nil {
+		return nil, err
+	}
+
+	request := CategoryRequest{Code: category.Code, Name: category.Name, Description: category.Description}
+	if err := p.Apply(&request); err != nil {
+		return nil, err
+	}
+
+	return s.update(ctx, category, request, history.ActionUpdate)
+}
+
+// RevertCategory restores the fields a category had at a previous version by ID
+func (s *categoryServiceImpl) RevertCategory(ctx context.Context, id uint, to uint, match version.Precondition) (*CategoryDetailResponse, error) {
+	category, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := match.Check(category.Version); err != nil {
+		return nil, err
+	}
+
+	var state CategoryDetailResponse
+	if err := s.history.StateAtVersion(ctx, entityType, id, to, &state); err != nil {
+		return nil, err
+	}
+
+	request := CategoryRequest{Code: state.Code, Name: state.Name, Description: state.Description}
+	return s.update(ctx, category, request, history.ActionRevert)
+}
 
+// update replaces the fields of category with request, stores it and records the change as action
+func (s *categoryServiceImpl) update(ctx context.Context, category *Category, request CategoryRequest, action history.Action) (*CategoryDetailResponse, error) {
+	before := toCategoryDetailResponse(category)
 	category.Code = request.Code
 	category.Name = request.Name
 	category.Description = request.Description
 
-	if err := category.Update(); err != nil {
+	var dto *CategoryDetailResponse
+	err := s.repo.Transaction(ctx, func(ctx context.Context) error {
+		store := func(ctx context.Context) error { return s.repo.Update(ctx, category) }
+		// The books show the code and name of the category, so changing them changes the books as well
+		if category.Code != before.Code || category.Name != before.Name {
+			if err := s.books.Touch(ctx, category.ID, store); err != nil {
+				return err
+			}
+		} else if err := store(ctx); err != nil {
+			return err
+		}
+		dto = toCategoryDetailResponse(category)
+		return s.history.Record(ctx, entityType, category.ID, category.Version, action, before, dto)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return &CategoryDetailResponse{
-		ID:          category.ID,
-		Code:        category.Code,
-		Name:        category.Name,
-		Description: category.Description,
-		CreatedAt:   category.CreatedAt,
-		UpdatedAt:   category.UpdatedAt,
-	}, nil
+	return dto, nil
 }
 
 // DeleteCategory soft delete a category by ID
-func (s *categoryServiceImpl) DeleteCategory(id uint) error {
-	category, err := FindByID(id)
+func (s *categoryServiceImpl) DeleteCategory(ctx context.Context, id uint, match version.Precondition) error {
+	category, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	if err := match.Check(category.Version); err != nil {
+		return err
+	}
+
+	// The books no longer show a deleted category
+	return s.repo.Transaction(ctx, func(ctx context.Context) error {
+		return s.books.Touch(ctx, category.ID, func(ctx context.Context) error {
+			if err := s.repo.SoftDelete(ctx, category); err != nil {
+				return err
+			}
+			return s.history.Record(ctx, entityType, category.ID, category.Version, history.ActionDelete, toCategoryDetailResponse(category), nil)
+		})
+	})
+}
+
+// GetDeletedCategories retrieves the categories in the trash with pagination
+func (s *categoryServiceImpl) GetDeletedCategories(ctx context.Context, page pagination.Request, sort sorting.Spec) (*CategoryTrashListResponse, error) {
+	categories, total, err := s.repo.FindAllDeleted(ctx, page, sort)
+	if err != nil {
+		return nil, err
+	}
 
-	if err := category.SoftDelete(); err != nil {
+	result := pagination.New(categories, page, total).
+		WithCursors(sort, func(c Category, field string) interface{} { return c.SortValue(field) })
+
+	return pagination.Map(result, func(category Category) CategoryTrashResponse {
+		return CategoryTrashResponse{
+			CategoryDetailResponse: CategoryDetailResponse{
+				ID:          category.ID,
+				Version:     category.Version,
+				Code:        category.Code,
+				Name:        category.Name,
+				Description: category.Description,
+				CreatedAt:   category.CreatedAt,
+				UpdatedAt:   category.UpdatedAt,
+			},
+			DeletedAt: category.DeletedAt.Time,
+		}
+	}), nil
+}
+
+// RestoreCategory moves a category out of the trash
+func (s *categoryServiceImpl) RestoreCategory(ctx context.Context, id uint) (*CategoryDetailResponse, error) {
+	category, err := s.repo.FindDeletedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var dto *CategoryDetailResponse
+	// The books show a restored category again
+	err = s.repo.Transaction(ctx, func(ctx context.Context) error {
+		return s.books.Touch(ctx, category.ID, func(ctx context.Context) error {
+			if err := s.repo.Restore(ctx, category); err != nil {
+				return err
+			}
+			dto = toCategoryDetailResponse(category)
+			return s.history.Record(ctx, entityType, category.ID, category.Version, history.ActionRestore, nil, dto)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto, nil
+}
+
+// PurgeCategory permanently deletes a category from the trash
+func (s *categoryServiceImpl) PurgeCategory(ctx context.Context, id uint) error {
+	category, err := s.repo.FindDeletedByID(ctx, id)
+	if err != nil {
 		return err
 	}
 
-	return nil
+	return s.purge(ctx, category)
+}
+
+// PurgeDeletedBefore permanently deletes the categories soft deleted before the given time and returns how many were purged
+func (s *categoryServiceImpl) PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	categories, err := s.repo.FindAllDeletedBefore(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+
+	var purged int64
+	for i := range categories {
+		if err := s.purge(ctx, &categories[i]); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// purge permanently deletes category and its links to the live books, recording the change of both
+func (s *categoryServiceImpl) purge(ctx context.Context, category *Category) error {
+	return s.repo.Transaction(ctx, func(ctx context.Context) error {
+		return s.books.Unlink(ctx, category.ID, func(ctx context.Context) error {
+			if err := s.repo.Purge(ctx, category); err != nil {
+				return err
+			}
+			return s.history.Record(ctx, entityType, category.ID, category.Version, history.ActionPurge, toCategoryDetailResponse(category), nil)
+		})
+	})
+}
+
+// toCategoryDetailResponse converts a Category into a CategoryDetailResponse
+func toCategoryDetailResponse(category *Category) *CategoryDetailResponse {
+	return &CategoryDetailResponse{
+		ID:          category.ID,
+		Version:     category.Version,
+		Code:        category.Code,
+		Name:        category.Name,
+		Description: category.Description,
+		CreatedAt:   category.CreatedAt,
+		UpdatedAt:   category.UpdatedAt,
+	}
 }
@@ -0,0 +1,117 @@
+package citation
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"golang.org/x/text/unicode/norm"
+)
+
+var bibTeXEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`&`, `\&`,
+	`%`, `\%`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+)
+
+// BibTeX writes a @book entry for each book. Keys shared by several books get a letter suffix.
+func BibTeX(books []book.Book) []byte {
+	var out bytes.Buffer
+	seen := map[string]int{}
+	for i := range books {
+		b := &books[i]
+		key := citeKey(b)
+		seen[key]++
+		if n := seen[key]; n > 1 {
+			key += suffix(n)
+		}
+
+		if i > 0 {
+			out.WriteString("\n")
+		}
+		fmt.Fprintf(&out, "@book{%s,\n", key)
+		field := func(name, value string) {
+			if value != "" {
+				fmt.Fprintf(&out, "  %s = {%s},\n", name, bibTeXEscaper.Replace(value))
+			}
+		}
+		authors := []string{}
+		for _, name := range names(b) {
+			authors = append(authors, join(", ", name.Family, name.Suffix, name.Given))
+		}
+		field("author", strings.Join(authors, " and "))
+		field("title", b.Title)
+		field("publisher", b.Publisher.Name)
+		field("year", number(b.Year))
+		field("isbn", isbn(b))
+		field("pagetotal", number(b.Pages))
+		out.WriteString("}\n")
+	}
+	return out.Bytes()
+}
+
+// citeKey builds a key from the family name of the first author, the year and the first word of the title
+func citeKey(b *book.Book) string {
+	var family string
+	if parsed := names(b); len(parsed) > 0 {
+		family = keyPart(parsed[0].Family)
+	}
+	var word string
+	for _, w := range strings.Fields(b.Title) {
+		if word = keyPart(w); word != "" && !stopWords[word] {
+			break
+		}
+	}
+
+	key := family + number(b.Year) + word
+	if family == "" && word == "" {
+		key = "book" + strconv.FormatUint(uint64(b.ID), 10)
+	}
+	return key
+}
+
+// stopWords are the title words skipped by citeKey
+var stopWords = map[string]bool{"a": true, "an": true, "the": true, "sang": true, "si": true}
+
+// keyPart lowercases value and strips its accents and every character but letters and digits
+func keyPart(value string) string {
+	var key strings.Builder
+	for _, r := range norm.NFD.String(strings.ToLower(value)) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			key.WriteRune(r)
+		}
+	}
+	return key.String()
+}
+
+// suffix returns the letter told the nth book with the same key apart: "b" for the second
+func suffix(n int) string {
+	if n <= 26 {
+		return string(rune('a' + n - 1))
+	}
+	return strconv.Itoa(n)
+}
+
+// number formats n, returning an empty string for 0
+func number(n uint) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.FormatUint(uint64(n), 10)
+}
+
+// isbn returns the ISBN-13 of b, or its ISBN-10 when it has none
+func isbn(b *book.Book) string {
+	if b.ISBN13 != "" {
+		return b.ISBN13
+	}
+	return b.ISBN10
+}
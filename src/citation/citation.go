@@ -0,0 +1,109 @@
+package citation
+
+import (
+	"strings"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+)
+
+// Format is a citation format or style
+type Format string
+
+const (
+	FormatBibTeX  Format = "bibtex"
+	FormatRIS     Format = "ris"
+	FormatCSLJSON Format = "csl-json"
+	FormatAPA     Format = "apa"
+	FormatMLA     Format = "mla"
+)
+
+// Formats lists the supported formats
+var Formats = []Format{FormatBibTeX, FormatRIS, FormatCSLJSON, FormatAPA, FormatMLA}
+
+const (
+	// BibTeXType is the media type of BibTeX
+	BibTeXType = "application/x-bibtex"
+	// RISType is the media type of RIS
+	RISType = "application/x-research-info-systems"
+	// CSLJSONType is the media type of CSL-JSON
+	CSLJSONType = "application/vnd.citationstyles.csl+json"
+	// BibliographyType is the media type of formatted references, whose style parameter names the style
+	BibliographyType = "text/x-bibliography"
+)
+
+// ParseFormat returns the Format named raw
+func ParseFormat(raw string) (Format, error) {
+	for _, format := range Formats {
+		if string(format) == strings.ToLower(raw) {
+			return format, nil
+		}
+	}
+	return "", &FormatError{Format: raw}
+}
+
+// MediaTypes returns the media types of the formats, in the order of Formats, to negotiate with Accept
+func MediaTypes() []string {
+	types := make([]string, len(Formats))
+	for i, format := range Formats {
+		types[i] = format.MediaType()
+	}
+	return types
+}
+
+// FromMediaType returns the format whose media type is mediaType
+func FromMediaType(mediaType string) (Format, bool) {
+	for _, format := range Formats {
+		if format.MediaType() == mediaType {
+			return format, true
+		}
+	}
+	return "", false
+}
+
+// MediaType returns the media type of the format. APA and MLA are formatted references
+// told apart by the style parameter.
+func (f Format) MediaType() string {
+	switch f {
+	case FormatBibTeX:
+		return BibTeXType
+	case FormatRIS:
+		return RISType
+	case FormatCSLJSON:
+		return CSLJSONType
+	default:
+		return BibliographyType + "; style=" + string(f)
+	}
+}
+
+// ContentType returns the media type of the format with its charset
+func (f Format) ContentType() string {
+	if f == FormatCSLJSON {
+		return f.MediaType()
+	}
+	return f.MediaType() + "; charset=utf-8"
+}
+
+// Render writes the citations of books in the format
+func Render(format Format, books []book.Book) ([]byte, error) {
+	switch format {
+	case FormatBibTeX:
+		return BibTeX(books), nil
+	case FormatRIS:
+		return RIS(books), nil
+	case FormatCSLJSON:
+		return CSLJSON(books)
+	case FormatMLA:
+		return Bibliography(books, MLA), nil
+	default:
+		return Bibliography(books, APA), nil
+	}
+}
+
+// names parses the names of the authors of b
+func names(b *book.Book) []Name {
+	parsed := make([]Name, len(b.Authors))
+	for i, a := range b.Authors {
+		parsed[i] = ParseName(a.Name)
+	}
+	return parsed
+}
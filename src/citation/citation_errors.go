@@ -0,0 +1,22 @@
+package citation
+
+import "fmt"
+
+// FormatError describes a citation format that is not supported
+type FormatError struct {
+	Format string
+}
+
+// Error implements the error interface
+func (e *FormatError) Error() string {
+	return fmt.Sprintf("unknown citation format: %q", e.Format)
+}
+
+// AllowedValues returns the supported format names
+func (e *FormatError) AllowedValues() []string {
+	names := make([]string, len(Formats))
+	for i, format := range Formats {
+		names[i] = string(format)
+	}
+	return names
+}
@@ -0,0 +1,88 @@
+package citation
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+)
+
+// CitationHandler handles HTTP requests for citations
+type CitationHandler struct {
+	service CitationService
+}
+
+// NewCitationHandler creates a new instance of CitationHandler
+func NewCitationHandler(service CitationService) *CitationHandler {
+	return &CitationHandler{service: service}
+}
+
+// CiteBook handles GET /books/:id/cite request
+func (h *CitationHandler) CiteBook(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid book ID")
+	}
+	format, err := Negotiate(c)
+	if err != nil {
+		return err
+	}
+
+	citation, err := h.service.CiteBook(c.UserContext(), uint(id), format)
+	if err != nil {
+		return err
+	}
+
+	return send(c, format, citation)
+}
+
+// CiteBooks handles GET /books/cite request, citing every book matching the list filters
+func (h *CitationHandler) CiteBooks(c *fiber.Ctx) error {
+	format, err := Negotiate(c)
+	if err != nil {
+		return err
+	}
+	filter, err := book.FilterFromQuery(c)
+	if err != nil {
+		return err
+	}
+
+	citations, err := h.service.CiteBooks(c.UserContext(), filter, format)
+	if err != nil {
+		return err
+	}
+
+	return send(c, format, citations)
+}
+
+// Negotiate picks the format of a citation request from its format query parameter,
+// or else from its Accept header, BibTeX when it accepts anything
+func Negotiate(c *fiber.Ctx) (Format, error) {
+	if raw := c.Query("format"); raw != "" {
+		format, err := ParseFormat(raw)
+		if err != nil {
+			return "", apperror.BadRequest("format", err)
+		}
+		return format, nil
+	}
+
+	format, ok := FromMediaType(c.Accepts(MediaTypes()...))
+	if !ok {
+		return "", fiber.ErrNotAcceptable
+	}
+	return format, nil
+}
+
+// send writes citations with the content type of format
+func send(c *fiber.Ctx, format Format, citations []byte) error {
+	c.Vary(fiber.HeaderAccept)
+	c.Set(fiber.HeaderContentType, format.ContentType())
+	return c.Send(citations)
+}
+
+// RegisterRoutes registers the citation routes.
+// They must be registered before the book routes so that /books/cite is not taken for a book ID.
+func (h *CitationHandler) RegisterRoutes(app *fiber.App) {
+	books := app.Group("/api/v1/books")
+	books.Get("/cite", h.CiteBooks)
+	books.Get("/:id/cite", h.CiteBook)
+}
@@ -0,0 +1,42 @@
+package citation
+
+import (
+	"context"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+)
+
+// CitationService defines the interface for citing books
+type CitationService interface {
+	CiteBook(ctx context.Context, id uint, format Format) ([]byte, error)
+	CiteBooks(ctx context.Context, filter book.BookFilter, format Format) ([]byte, error)
+}
+
+type citationServiceImpl struct {
+	books book.BookRepository
+}
+
+// NewCitationService creates a new instance of CitationService
+func NewCitationService(books book.BookRepository) CitationService {
+	return &citationServiceImpl{books: books}
+}
+
+// CiteBook renders the citation of the book with the given ID
+func (s *citationServiceImpl) CiteBook(ctx context.Context, id uint, format Format) ([]byte, error) {
+	b, err := s.books.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return Render(format, []book.Book{*b})
+}
+
+// CiteBooks renders the citations of every book matching filter
+func (s *citationServiceImpl) CiteBooks(ctx context.Context, filter book.BookFilter, format Format) ([]byte, error) {
+	books, err := book.FindAllMatching(ctx, s.books, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return Render(format, books)
+}
@@ -0,0 +1,57 @@
+package citation
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+)
+
+// cslItem is a CSL-JSON item
+type cslItem struct {
+	ID            string    `json:"id"`
+	Type          string    `json:"type"`
+	Title         string    `json:"title"`
+	Author        []cslName `json:"author,omitempty"`
+	Publisher     string    `json:"publisher,omitempty"`
+	Issued        *cslDate  `json:"issued,omitempty"`
+	ISBN          string    `json:"ISBN,omitempty"`
+	NumberOfPages uint      `json:"number-of-pages,omitempty"`
+	Abstract      string    `json:"abstract,omitempty"`
+}
+
+// cslName is a CSL-JSON name
+type cslName struct {
+	Family string `json:"family,omitempty"`
+	Given  string `json:"given,omitempty"`
+	Suffix string `json:"suffix,omitempty"`
+}
+
+// cslDate is a CSL-JSON date
+type cslDate struct {
+	DateParts [][]uint `json:"date-parts"`
+}
+
+// CSLJSON writes the books as an array of CSL-JSON items
+func CSLJSON(books []book.Book) ([]byte, error) {
+	items := make([]cslItem, len(books))
+	for i := range books {
+		b := &books[i]
+		items[i] = cslItem{
+			ID:            fmt.Sprintf("book-%d", b.ID),
+			Type:          "book",
+			Title:         b.Title,
+			Publisher:     b.Publisher.Name,
+			ISBN:          isbn(b),
+			NumberOfPages: b.Pages,
+			Abstract:      b.Description,
+		}
+		for _, name := range names(b) {
+			items[i].Author = append(items[i].Author, cslName{Family: name.Family, Given: name.Given, Suffix: name.Suffix})
+		}
+		if b.Year > 0 {
+			items[i].Issued = &cslDate{DateParts: [][]uint{{b.Year}}}
+		}
+	}
+	return json.Marshal(items)
+}
@@ -0,0 +1,109 @@
+package citation
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// particles are the lowercase words that belong to the family name that follows them
+var particles = map[string]bool{
+	"da": true, "das": true, "de": true, "del": true, "della": true, "den": true, "der": true,
+	"di": true, "do": true, "dos": true, "du": true, "la": true, "le": true, "ten": true,
+	"ter": true, "van": true, "von": true,
+}
+
+// suffixes are the generational suffixes that follow a name
+var suffixes = map[string]bool{"jr": true, "jr.": true, "sr": true, "sr.": true, "ii": true, "iii": true, "iv": true}
+
+// Name is the name of an author split into the parts citation styles order differently
+type Name struct {
+	Family string
+	Given  string
+	Suffix string
+}
+
+// ParseName splits the name of an author. Names written "Last, First" or "Last, Suffix, First"
+// keep their order; other names take their last word, with any particles before it, as the
+// family name. A single word is a family name alone.
+func ParseName(raw string) Name {
+	parts := strings.Split(strings.Join(strings.Fields(raw), " "), ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+
+	switch {
+	case len(parts) == 2 && isSuffix(parts[1]):
+		name := parseNatural(parts[0])
+		name.Suffix = parts[1]
+		return name
+	case len(parts) == 2:
+		return Name{Family: parts[0], Given: parts[1]}
+	case len(parts) >= 3:
+		return Name{Family: parts[0], Suffix: parts[1], Given: strings.Join(parts[2:], ", ")}
+	default:
+		return parseNatural(parts[0])
+	}
+}
+
+// parseNatural splits a name written "First von Last Jr."
+func parseNatural(raw string) Name {
+	words := strings.Fields(raw)
+	var name Name
+	if len(words) > 1 && isSuffix(words[len(words)-1]) {
+		name.Suffix = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+	if len(words) == 0 {
+		return name
+	}
+
+	family := len(words) - 1
+	for family > 0 && particles[words[family-1]] {
+		family--
+	}
+	name.Given = strings.Join(words[:family], " ")
+	name.Family = strings.Join(words[family:], " ")
+	return name
+}
+
+// isSuffix reports whether word is a generational suffix
+func isSuffix(word string) bool {
+	return suffixes[strings.ToLower(word)]
+}
+
+// Inverted returns the name as "Family, Given, Suffix", leaving out empty parts
+func (n Name) Inverted() string {
+	return join(", ", n.Family, n.Given, n.Suffix)
+}
+
+// Natural returns the name in reading order, "Given Family Suffix"
+func (n Name) Natural() string {
+	return join(" ", n.Given, n.Family, n.Suffix)
+}
+
+// Initials returns the initials of the given names, e.g. "J.-P. A." for "Jean-Paul Andre"
+func (n Name) Initials() string {
+	words := strings.Fields(n.Given)
+	for i, word := range words {
+		parts := strings.Split(word, "-")
+		for j, part := range parts {
+			if r, _ := utf8.DecodeRuneInString(part); r != utf8.RuneError {
+				parts[j] = string(unicode.ToUpper(r)) + "."
+			}
+		}
+		words[i] = strings.Join(parts, "-")
+	}
+	return strings.Join(words, " ")
+}
+
+// join joins the non-empty values with sep
+func join(sep string, values ...string) string {
+	kept := values[:0:0]
+	for _, value := range values {
+		if value != "" {
+			kept = append(kept, value)
+		}
+	}
+	return strings.Join(kept, sep)
+}
@@ -0,0 +1,32 @@
+package citation
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+)
+
+// RIS writes a BOOK record for each book, with CRLF line endings
+func RIS(books []book.Book) []byte {
+	var out bytes.Buffer
+	for i := range books {
+		b := &books[i]
+		line := func(tag, value string) {
+			if value != "" {
+				fmt.Fprintf(&out, "%s  - %s\r\n", tag, value)
+			}
+		}
+		line("TY", "BOOK")
+		for _, name := range names(b) {
+			line("AU", name.Inverted())
+		}
+		line("TI", b.Title)
+		line("PB", b.Publisher.Name)
+		line("PY", number(b.Year))
+		line("SN", isbn(b))
+		line("AB", b.Description)
+		out.WriteString("ER  - \r\n")
+	}
+	return out.Bytes()
+}
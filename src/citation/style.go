@@ -0,0 +1,82 @@
+package citation
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+)
+
+// Style formats the reference of a book as plain text
+type Style func(b *book.Book) string
+
+// Bibliography writes the references of books in style, one per line in alphabetical order
+func Bibliography(books []book.Book, style Style) []byte {
+	references := make([]string, len(books))
+	for i := range books {
+		references[i] = style(&books[i])
+	}
+	sort.SliceStable(references, func(i, j int) bool {
+		return strings.ToLower(references[i]) < strings.ToLower(references[j])
+	})
+
+	var out strings.Builder
+	for _, reference := range references {
+		out.WriteString(reference)
+		out.WriteString("\n")
+	}
+	return []byte(out.String())
+}
+
+// APA formats a reference in APA style, 7th edition: "Hirata, A. (2005). Laskar Pelangi. Bentang Pustaka."
+func APA(b *book.Book) string {
+	year := "(n.d.)."
+	if b.Year > 0 {
+		year = "(" + number(b.Year) + ")."
+	}
+
+	authors := names(b)
+	if len(authors) == 0 {
+		return join(" ", sentence(b.Title), year, sentence(b.Publisher.Name))
+	}
+
+	listed := make([]string, len(authors))
+	for i, name := range authors {
+		listed[i] = join(", ", name.Family, name.Initials(), name.Suffix)
+	}
+	var lead string
+	switch {
+	case len(listed) == 1:
+		lead = listed[0]
+	case len(listed) <= 20:
+		lead = strings.Join(listed[:len(listed)-1], ", ") + ", & " + listed[len(listed)-1]
+	default:
+		lead = strings.Join(listed[:19], ", ") + ", . . . " + listed[len(listed)-1]
+	}
+	return join(" ", sentence(lead), year, sentence(b.Title), sentence(b.Publisher.Name))
+}
+
+// MLA formats a reference in MLA style, 9th edition: "Hirata, Andrea. Laskar Pelangi. Bentang Pustaka, 2005."
+func MLA(b *book.Book) string {
+	authors := names(b)
+	var lead string
+	switch len(authors) {
+	case 0:
+	case 1:
+		lead = authors[0].Inverted()
+	case 2:
+		lead = authors[0].Inverted() + ", and " + authors[1].Natural()
+	default:
+		lead = authors[0].Inverted() + ", et al"
+	}
+	return join(" ", sentence(lead), sentence(b.Title), sentence(join(", ", b.Publisher.Name, number(b.Year))))
+}
+
+// sentence ends value with a period unless it already ends with punctuation
+func sentence(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.ContainsAny(value[len(value)-1:], ".?!") {
+		return value
+	}
+	return value + "."
+}
@@ -1,24 +1,42 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
 	"time"
 
-	"github.com/tedysaputro/book-catalog-with-go/src/author"
-	"github.com/tedysaputro/book-catalog-with-go/src/book"
-	"github.com/tedysaputro/book-catalog-with-go/src/category"
-	"github.com/tedysaputro/book-catalog-with-go/src/publisher"
+	"github.com/tedysaputro/book-catalog-with-go/src/migrate"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
-var DB *gorm.DB
+// InitDB initializes the database connection and refuses to start
+// against a database with pending migrations
+func InitDB() *gorm.DB {
+	db := OpenDB()
 
-// InitDB initializes the database connection
-func InitDB() {
+	// Check that the schema is up to date
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		log.Fatal("Failed to load migrations:", err)
+	}
+	pending, err := migrator.Pending(context.Background())
+	if err != nil {
+		log.Fatal("Failed to check migrations:", err)
+	}
+	if len(pending) > 0 {
+		log.Fatalf("Database schema is %d migration(s) behind, run `book-catalog migrate up` first", len(pending))
+	}
+
+	log.Println("Database connected and schema is up to date")
+	return db
+}
+
+// OpenDB opens the database connection
+func OpenDB() *gorm.DB {
 	dsn := fmt.Sprintf(
 		"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
 		getEnvOrDefault("DB_HOST", "localhost"),
@@ -32,35 +50,32 @@ func InitDB() {
 	newLogger := logger.New(
 		log.New(os.Stdout, "\r\n", log.LstdFlags), // io writer
 		logger.Config{
-			SlowThreshold:             time.Second,   // Slow SQL threshold
-			LogLevel:                  logger.Info,   // Log level (Silent, Error, Warn, Info)
-			IgnoreRecordNotFoundError: false,        // Include not found error
-			Colorful:                  true,         // Enable color
+			SlowThreshold:             time.Second, // Slow SQL threshold
+			LogLevel:                  logger.Info, // Log level (Silent, Error, Warn, Info)
+			IgnoreRecordNotFoundError: false,       // Include not found error
+			Colorful:                  true,        // Enable color
 		},
 	)
 
 	// Open database connection with logger
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: newLogger,
+		Logger:         newLogger,
+		TranslateError: true, // Report unique violations as gorm.ErrDuplicatedKey
 	})
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
 
-	// Set the database instance for the models
-	author.SetDB(db)
-	publisher.SetDB(db)
-	category.SetDB(db)
-	book.SetDB(db)
-	DB = db
+	return db
+}
 
-	// Auto migrate the database
-	err = DB.AutoMigrate(&author.Author{}, &publisher.Publisher{}, &category.Category{}, &book.Book{})
+// NewMigrator creates a migrator on the connection pool of db
+func NewMigrator(db *gorm.DB) (*migrate.Migrator, error) {
+	sqlDB, err := db.DB()
 	if err != nil {
-		log.Fatal("Failed to migrate database:", err)
+		return nil, err
 	}
-
-	log.Println("Database connected and migrated successfully")
+	return migrate.New(sqlDB)
 }
 
 // getEnvOrDefault returns environment variable value or default if not set
@@ -0,0 +1,136 @@
+package deletion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+)
+
+// Policy decides what happens to the live records that refer to a record being deleted
+type Policy string
+
+const (
+	// PolicyRestrict refuses the delete while live records refer to the record
+	PolicyRestrict Policy = "restrict"
+	// PolicyCascade soft deletes the referring records along with the record
+	PolicyCascade Policy = "cascade"
+	// PolicyReassign points the referring records at another record before the delete
+	PolicyReassign Policy = "reassign"
+)
+
+// Policies lists the supported policies
+var Policies = []Policy{PolicyRestrict, PolicyCascade, PolicyReassign}
+
+// DefaultPolicy is used for deletes that do not ask for a policy
+var DefaultPolicy = PolicyRestrict
+
+// MaxReferences is the largest number of blocking records listed by a restricted delete
+const MaxReferences = 100
+
+// ParsePolicy returns the policy named raw
+func ParsePolicy(raw string) (Policy, error) {
+	for _, policy := range Policies {
+		if string(policy) == raw {
+			return policy, nil
+		}
+	}
+	return "", &PolicyError{Policy: raw}
+}
+
+// PolicyError describes a policy name that is not supported
+type PolicyError struct {
+	Policy string
+}
+
+// Error implements the error interface
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("unknown delete policy: %q", e.Policy)
+}
+
+// AllowedValues returns the supported policy names
+func (e *PolicyError) AllowedValues() []string {
+	names := make([]string, len(Policies))
+	for i, policy := range Policies {
+		names[i] = string(policy)
+	}
+	return names
+}
+
+// Options is the policy a delete request asks for
+type Options struct {
+	Policy Policy
+	// ReassignTo is the record the references move to under PolicyReassign
+	ReassignTo uint
+}
+
+// FromQuery reads the policy and reassign_to query parameters of c
+func FromQuery(c *fiber.Ctx) (Options, error) {
+	options := Options{Policy: DefaultPolicy}
+	if raw := c.Query("policy"); raw != "" {
+		policy, err := ParsePolicy(raw)
+		if err != nil {
+			return Options{}, apperror.BadRequest("policy", err)
+		}
+		options.Policy = policy
+	}
+
+	raw := c.Query("reassign_to")
+	if options.Policy != PolicyReassign {
+		if raw != "" {
+			return Options{}, apperror.BadRequest("reassign_to", errors.New("reassign_to only applies to the reassign policy"))
+		}
+		return options, nil
+	}
+	id, err := strconv.ParseUint(raw, 10, 32)
+	if err != nil || id == 0 {
+		return Options{}, apperror.BadRequest("reassign_to", errors.New("reassign_to must be the ID of the record taking over the references"))
+	}
+	options.ReassignTo = uint(id)
+	return options, nil
+}
+
+// Links manages the live records that show records of another kind
+type Links interface {
+	// Touch runs change, which changes what the live records that refer to the record id show of it,
+	// and records those records as changed
+	Touch(ctx context.Context, id uint, change func(ctx context.Context) error) error
+	// Unlink runs purge, which permanently deletes the record id, and records the change of the
+	// live records that lose their link to it
+	Unlink(ctx context.Context, id uint, purge func(ctx context.Context) error) error
+}
+
+// Dependents manages the live records that refer to records of another kind
+type Dependents interface {
+	Links
+	// Find returns the live records that refer to the record id
+	Find(ctx context.Context, id uint) ([]apperror.Reference, error)
+	// Cascade soft deletes the live records that refer to the record id
+	Cascade(ctx context.Context, id uint) error
+	// Reassign makes the live records that refer to the record id refer to the record to instead
+	Reassign(ctx context.Context, id uint, to uint) error
+}
+
+// Apply handles the dependents of the record id of the given kind according to options,
+// ahead of deleting the record. A restricted delete with dependents fails with a conflict listing them.
+func Apply(ctx context.Context, kind string, dependents Dependents, id uint, options Options) error {
+	switch options.Policy {
+	case PolicyCascade:
+		return dependents.Cascade(ctx, id)
+	case PolicyReassign:
+		return dependents.Reassign(ctx, id, options.ReassignTo)
+	default:
+		references, err := dependents.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+		if len(references) == 0 {
+			return nil
+		}
+		message := fmt.Sprintf("%s is still referenced by %d records, delete with policy cascade or reassign", kind, len(references))
+		return apperror.InUse(message, references[:min(len(references), MaxReferences)])
+	}
+}
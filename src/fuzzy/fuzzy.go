@@ -0,0 +1,136 @@
+package fuzzy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/transaction"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DefaultThreshold is the minimum similarity used when a request does not set one
+var DefaultThreshold = 0.3
+
+// Options controls fuzzy matching of a list filter
+type Options struct {
+	Enabled   bool
+	Threshold float64
+}
+
+// Validate checks that the threshold of enabled options is a similarity between 0 and 1
+func (o Options) Validate() error {
+	// Written to reject NaN as well
+	if o.Enabled && !(o.Threshold > 0 && o.Threshold <= 1) {
+		return errors.New("threshold must be greater than 0 and at most 1")
+	}
+	return nil
+}
+
+// Run runs fn in a transaction on db whose fuzzy matches need the threshold of options, as the
+// operator of Where reads it from the pg_trgm.word_similarity_threshold setting. Queries made by
+// fn must read through transaction.DB. fn runs as is when options are disabled.
+func Run(ctx context.Context, db *gorm.DB, options Options, fn func(ctx context.Context) error) error {
+	if !options.Enabled {
+		return fn(ctx)
+	}
+	return transaction.Run(ctx, db, func(ctx context.Context) error {
+		// SET takes no bind parameters; the threshold is a number, never user text
+		threshold := strconv.FormatFloat(options.Threshold, 'f', -1, 64)
+		if err := transaction.DB(ctx, db).Exec("SET LOCAL pg_trgm.word_similarity_threshold = " + threshold).Error; err != nil {
+			return err
+		}
+		return fn(ctx)
+	})
+}
+
+// similarity returns the SQL expression scoring how well term matches column,
+// ignoring case and accents
+func similarity(column string) string {
+	return fmt.Sprintf("word_similarity(f_unaccent(lower(?)), f_unaccent(lower(%s)))", column)
+}
+
+// Where keeps the rows of query whose column is similar to term by the threshold set by Run.
+// The <% operator lets PostgreSQL use the trigram index on f_unaccent(lower(column)).
+// column must be a trusted column name, never user input.
+func Where(query *gorm.DB, column string, term string) *gorm.DB {
+	return query.Where(fmt.Sprintf("f_unaccent(lower(?)) <%% f_unaccent(lower(%s))", column), term)
+}
+
+// Rank returns an ORDER BY expression sorting rows by descending similarity of column to term,
+// to be passed to sorting.Spec.Apply. column must be a trusted column name, never user input.
+func Rank(column string, term string) clause.Expr {
+	return clause.Expr{SQL: similarity(column) + " DESC", Vars: []interface{}{term}}
+}
+
+// Similarity approximates PostgreSQL's word_similarity(term, text) after removing
+// case and accents, for use by implementations that do not run on PostgreSQL
+func Similarity(term string, text string) float64 {
+	want := trigramSet(trigrams(unaccent(term)))
+	if len(want) == 0 {
+		return 0
+	}
+	have := trigrams(unaccent(text))
+
+	// Find the continuous extent of text trigrams that best matches term
+	best := 0.0
+	for start := range have {
+		extent := make(map[string]bool)
+		for end := start; end < len(have); end++ {
+			extent[have[end]] = true
+			shared := 0
+			for t := range extent {
+				if want[t] {
+					shared++
+				}
+			}
+			score := float64(shared) / float64(len(want)+len(extent)-shared)
+			if score > best {
+				best = score
+			}
+		}
+	}
+	return best
+}
+
+// unaccent lower-cases s and strips its diacritics
+func unaccent(s string) string {
+	stripped, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), s)
+	if err != nil {
+		stripped = s
+	}
+	return strings.ToLower(stripped)
+}
+
+// trigrams returns the trigrams of the words of s in order, padding each word
+// the way pg_trgm does
+func trigrams(s string) []string {
+	var result []string
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			result = append(result, string(padded[i:i+3]))
+		}
+	}
+	return result
+}
+
+// trigramSet returns the distinct trigrams of list
+func trigramSet(list []string) map[string]bool {
+	set := make(map[string]bool, len(list))
+	for _, t := range list {
+		set[t] = true
+	}
+	return set
+}
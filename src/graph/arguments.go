@@ -0,0 +1,241 @@
+package graph
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/author"
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/category"
+	"github.com/tedysaputro/book-catalog-with-go/src/deletion"
+	"github.com/tedysaputro/book-catalog-with-go/src/fuzzy"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/publisher"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+	"github.com/tedysaputro/book-catalog-with-go/src/version"
+)
+
+// listArgs are the arguments of the list queries, matching the query parameters of the REST list endpoints
+var listArgs = graphql.FieldConfigArgument{
+	"page":      {Type: graphql.Int, Description: "Page to return, from 1"},
+	"pageSize":  {Type: graphql.Int, Description: "Items per page, capped at 100"},
+	"cursor":    {Type: graphql.String, Description: "Cursor of the page to return, taken from nextCursor or prevCursor"},
+	"sort":      {Type: graphql.String, Description: "Comma separated sort fields, prefixed with - for descending order"},
+	"fuzzy":     {Type: graphql.Boolean, Description: "Match names or titles by similarity instead of substring"},
+	"threshold": {Type: graphql.Float, Description: "Similarity a fuzzy match needs, between 0 and 1"},
+}
+
+// bookPageArgs are the arguments of the book lists of authors, publishers and categories, which
+// have no cursors as every record pages through its own books
+var bookPageArgs = graphql.FieldConfigArgument{
+	"page":     listArgs["page"],
+	"pageSize": listArgs["pageSize"],
+	"sort":     listArgs["sort"],
+}
+
+// withArgs returns the list arguments along with extra
+func withArgs(extra graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+	args := graphql.FieldConfigArgument{}
+	for name, arg := range listArgs {
+		args[name] = arg
+	}
+	for name, arg := range extra {
+		args[name] = arg
+	}
+	return args
+}
+
+// listQuery reads the sort, page and fuzzy matching arguments of a list query on fields
+func listQuery(args map[string]interface{}, fields sorting.Fields) (sorting.Spec, pagination.Request, fuzzy.Options, error) {
+	raw, _ := args["sort"].(string)
+	sort, err := sorting.FromQuery(raw, "", "", fields)
+	if err != nil {
+		return nil, pagination.Request{}, fuzzy.Options{}, apperror.BadRequest("sort", err)
+	}
+	sort = sort.Stable(fields)
+
+	match := fuzzy.Options{Threshold: fuzzy.DefaultThreshold}
+	match.Enabled, _ = args["fuzzy"].(bool)
+	if threshold, ok := args["threshold"].(float64); ok {
+		match.Threshold = threshold
+	}
+	if err := match.Validate(); err != nil {
+		return nil, pagination.Request{}, fuzzy.Options{}, apperror.BadRequest("threshold", err)
+	}
+
+	page, err := pageRequest(args, sort)
+	if err != nil {
+		return nil, pagination.Request{}, fuzzy.Options{}, err
+	}
+	if match.Enabled && page.Cursor != nil {
+		return nil, pagination.Request{}, fuzzy.Options{}, apperror.BadRequest("cursor", pagination.ErrCursorUnsupported)
+	}
+	return sort, page, match, nil
+}
+
+// booksArgs reads the arguments of the book list of a record of grouping
+func booksArgs(args map[string]interface{}, grouping book.Grouping) (booksQuery, error) {
+	raw, _ := args["sort"].(string)
+	sort, err := sorting.FromQuery(raw, "", "", book.SortFields)
+	if err != nil {
+		return booksQuery{}, apperror.BadRequest("sort", err)
+	}
+	sort = sort.Stable(book.SortFields)
+
+	page, err := pageRequest(args, sort)
+	if err != nil {
+		return booksQuery{}, err
+	}
+	return booksQuery{grouping: grouping, page: page.Page, pageSize: page.PageSize, sort: sort.String()}, nil
+}
+
+// pageRequest reads the page, pageSize and cursor arguments like pagination.FromQuery
+func pageRequest(args map[string]interface{}, sort sorting.Spec) (pagination.Request, error) {
+	request := pagination.Request{Page: 1, PageSize: pagination.DefaultPageSize}
+	for name, value := range map[string]*uint{"page": &request.Page, "pageSize": &request.PageSize} {
+		if raw, ok := args[name].(int); ok {
+			if raw < 1 {
+				return pagination.Request{}, apperror.BadRequest(name, errors.New("must be a positive integer"))
+			}
+			*value = uint(raw)
+		}
+	}
+	request.PageSize = min(request.PageSize, pagination.MaxPageSize)
+
+	raw, _ := args["cursor"].(string)
+	if raw == "" {
+		return request, nil
+	}
+	if _, ok := args["page"].(int); ok {
+		return pagination.Request{}, apperror.BadRequest("cursor", errors.New("cursor cannot be combined with page"))
+	}
+	cursor, err := pagination.DecodeCursor(raw, sort)
+	if err != nil {
+		return pagination.Request{}, apperror.BadRequest("cursor", err)
+	}
+	request.Cursor = cursor
+	return request, nil
+}
+
+// idArg reads the ID argument name
+func idArg(args map[string]interface{}, name string) (uint, error) {
+	raw, _ := args[name].(string)
+	id, err := strconv.ParseUint(raw, 10, 32)
+	if err != nil || id == 0 {
+		return 0, apperror.BadRequest(name, errors.New("must be a positive integer ID"))
+	}
+	return uint(id), nil
+}
+
+// idsArg reads the list of IDs argument name
+func idsArg(args map[string]interface{}, name string) ([]uint, error) {
+	raw, _ := args[name].([]interface{})
+	ids := make([]uint, 0, len(raw))
+	for _, item := range raw {
+		id, err := idArg(map[string]interface{}{name: item}, name)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// stringsArg reads the list of strings argument name
+func stringsArg(args map[string]interface{}, name string) []string {
+	raw, _ := args[name].([]interface{})
+	items := make([]string, 0, len(raw))
+	for _, item := range raw {
+		if s, ok := item.(string); ok && s != "" {
+			items = append(items, s)
+		}
+	}
+	return items
+}
+
+// precondition reads the version argument of a mutation, which plays the part of the If-Match header
+func precondition(args map[string]interface{}) version.Precondition {
+	v, _ := args["version"].(int)
+	return version.Precondition{Versions: []uint{uint(v)}}
+}
+
+// deletionOptions reads the policy and reassignTo arguments like deletion.FromQuery
+func deletionOptions(args map[string]interface{}) (deletion.Options, error) {
+	options := deletion.Options{Policy: deletion.DefaultPolicy}
+	if policy, ok := args["policy"].(deletion.Policy); ok {
+		options.Policy = policy
+	}
+	if options.Policy != deletion.PolicyReassign {
+		if args["reassignTo"] != nil {
+			return deletion.Options{}, apperror.BadRequest("reassignTo", errors.New("reassignTo only applies to the reassign policy"))
+		}
+		return options, nil
+	}
+	id, err := idArg(args, "reassignTo")
+	if err != nil {
+		return deletion.Options{}, apperror.BadRequest("reassignTo", errors.New("reassignTo must be the ID of the record taking over the references"))
+	}
+	options.ReassignTo = id
+	return options, nil
+}
+
+// input returns the input object argument of a mutation
+func input(args map[string]interface{}) map[string]interface{} {
+	in, _ := args["input"].(map[string]interface{})
+	return in
+}
+
+// bookRequest reads a BookInput
+func bookRequest(args map[string]interface{}) (book.BookRequest, error) {
+	in := input(args)
+	request := book.BookRequest{}
+	request.Title, _ = in["title"].(string)
+	request.Description, _ = in["description"].(string)
+	request.ISBN10, _ = in["isbn10"].(string)
+	request.ISBN13, _ = in["isbn13"].(string)
+	pages, _ := in["pages"].(int)
+	year, _ := in["year"].(int)
+	request.Pages, request.Year = uint(max(pages, 0)), uint(max(year, 0))
+
+	var err error
+	if request.PublisherID, err = idArg(in, "publisherId"); err != nil {
+		return book.BookRequest{}, err
+	}
+	if request.AuthorIDs, err = idsArg(in, "authorIds"); err != nil {
+		return book.BookRequest{}, err
+	}
+	if request.CategoryIDs, err = idsArg(in, "categoryIds"); err != nil {
+		return book.BookRequest{}, err
+	}
+	return request, nil
+}
+
+// authorRequest reads an AuthorInput
+func authorRequest(args map[string]interface{}) author.AuthorRequest {
+	in := input(args)
+	request := author.AuthorRequest{}
+	request.Name, _ = in["name"].(string)
+	request.Description, _ = in["description"].(string)
+	return request
+}
+
+// publisherRequest reads a PublisherInput
+func publisherRequest(args map[string]interface{}) publisher.PublisherRequest {
+	in := input(args)
+	request := publisher.PublisherRequest{}
+	request.Name, _ = in["name"].(string)
+	request.Description, _ = in["description"].(string)
+	return request
+}
+
+// categoryRequest reads a CategoryInput
+func categoryRequest(args map[string]interface{}) category.CategoryRequest {
+	in := input(args)
+	request := category.CategoryRequest{}
+	request.Code, _ = in["code"].(string)
+	request.Name, _ = in["name"].(string)
+	request.Description, _ = in["description"].(string)
+	return request
+}
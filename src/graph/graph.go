@@ -0,0 +1,553 @@
+package graph
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/tedysaputro/book-catalog-with-go/src/author"
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/category"
+	"github.com/tedysaputro/book-catalog-with-go/src/deletion"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/publisher"
+)
+
+// page is the GraphQL shape of a pagination.Page, whose empty cursors and missing page number are null
+type page struct {
+	Data       interface{}
+	Page       *uint
+	PageSize   uint
+	Total      uint64
+	TotalPages uint64
+	NextCursor *string
+	PrevCursor *string
+}
+
+// toPage converts a page of the service layer
+func toPage[T any](p *pagination.Page[T]) *page {
+	result := &page{Data: p.Data, PageSize: p.PageSize, Total: p.Total, TotalPages: p.TotalPages}
+	if p.Page > 0 {
+		result.Page = &p.Page
+	}
+	if p.NextCursor != "" {
+		result.NextCursor = &p.NextCursor
+	}
+	if p.PrevCursor != "" {
+		result.PrevCursor = &p.PrevCursor
+	}
+	return result
+}
+
+// source returns the object a field resolves on, which the executor passes by value or by pointer
+func source[T any](p graphql.ResolveParams) *T {
+	switch s := p.Source.(type) {
+	case *T:
+		return s
+	case T:
+		return &s
+	}
+	return nil
+}
+
+// booksOf resolves the page of books the arguments of p request from the record id of grouping,
+// fetching it along with the same page of the other records of the query level
+func booksOf(p graphql.ResolveParams, grouping book.Grouping, id uint) (interface{}, error) {
+	query, err := booksArgs(p.Args, grouping)
+	if err != nil {
+		return nil, err
+	}
+	thunk := loadersFrom(p.Context).booksOf(query).load(p.Context, id)
+	return func() (interface{}, error) {
+		result, err := thunk()
+		if err != nil {
+			return nil, err
+		}
+		return toPage(result.(*book.BookListResponse)), nil
+	}, nil
+}
+
+// pageType creates the type of a page of items
+func pageType(name string, item *graphql.Object) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: name,
+		Fields: graphql.Fields{
+			"data":       {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(item)))},
+			"page":       {Type: graphql.Int, Description: "Page number, null for pages fetched by cursor"},
+			"pageSize":   {Type: graphql.NewNonNull(graphql.Int)},
+			"total":      {Type: graphql.NewNonNull(graphql.Int)},
+			"totalPages": {Type: graphql.NewNonNull(graphql.Int)},
+			"nextCursor": {Type: graphql.String},
+			"prevCursor": {Type: graphql.String},
+		},
+	})
+}
+
+// deletionPolicy is the enum of the deletion.Policies
+var deletionPolicy = graphql.NewEnum(graphql.EnumConfig{
+	Name: "DeletionPolicy",
+	Values: graphql.EnumValueConfigMap{
+		"RESTRICT": {Value: deletion.PolicyRestrict, Description: "Refuse the delete while books refer to the record"},
+		"CASCADE":  {Value: deletion.PolicyCascade, Description: "Delete the books referring to the record along with it"},
+		"REASSIGN": {Value: deletion.PolicyReassign, Description: "Move the books to the record reassignTo before the delete"},
+	},
+})
+
+// newSchema creates the schema of the endpoint, resolving through the services of s
+func newSchema(s *graphServiceImpl) (graphql.Schema, error) {
+	var authorType, publisherType, categoryType *graphql.Object
+
+	bookType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Book",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":          {Type: graphql.NewNonNull(graphql.ID)},
+				"version":     {Type: graphql.NewNonNull(graphql.Int)},
+				"title":       {Type: graphql.NewNonNull(graphql.String)},
+				"description": {Type: graphql.NewNonNull(graphql.String)},
+				"isbn10":      {Type: graphql.NewNonNull(graphql.String)},
+				"isbn13":      {Type: graphql.NewNonNull(graphql.String)},
+				"pages":       {Type: graphql.NewNonNull(graphql.Int)},
+				"year":        {Type: graphql.NewNonNull(graphql.Int)},
+				"createdAt":   {Type: graphql.NewNonNull(graphql.DateTime)},
+				"updatedAt":   {Type: graphql.NewNonNull(graphql.DateTime)},
+				"publisher": {
+					Type: publisherType,
+					Resolve: resolver(func(p graphql.ResolveParams) (interface{}, error) {
+						return loadersFrom(p.Context).publishers.load(p.Context, parseID(source[book.BookDetailResponse](p).Publisher.ID)), nil
+					}),
+				},
+				"authors": {
+					Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(authorType))),
+					Resolve: resolver(func(p graphql.ResolveParams) (interface{}, error) {
+						b := source[book.BookDetailResponse](p)
+						ids := make([]uint, len(b.Authors))
+						for i, a := range b.Authors {
+							ids[i] = parseID(a.ID)
+						}
+						return loadersFrom(p.Context).authors.loadMany(p.Context, ids), nil
+					}),
+				},
+				"categories": {
+					Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(categoryType))),
+					Resolve: resolver(func(p graphql.ResolveParams) (interface{}, error) {
+						b := source[book.BookDetailResponse](p)
+						ids := make([]uint, len(b.Categories))
+						for i, c := range b.Categories {
+							ids[i] = parseID(c.ID)
+						}
+						return loadersFrom(p.Context).categories.loadMany(p.Context, ids), nil
+					}),
+				},
+			}
+		}),
+	})
+	bookPage := pageType("BookPage", bookType)
+	books := graphql.NewNonNull(bookPage)
+
+	authorType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Author",
+		Fields: graphql.Fields{
+			"id":          {Type: graphql.NewNonNull(graphql.ID)},
+			"version":     {Type: graphql.NewNonNull(graphql.Int)},
+			"name":        {Type: graphql.NewNonNull(graphql.String)},
+			"description": {Type: graphql.NewNonNull(graphql.String)},
+			"books": {
+				Type:        books,
+				Description: "Books written by the author",
+				Args:        bookPageArgs,
+				Resolve: resolver(func(p graphql.ResolveParams) (interface{}, error) {
+					return booksOf(p, book.GroupByAuthor, source[author.AuthorDetailResponse](p).ID)
+				}),
+			},
+		},
+	})
+	publisherType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Publisher",
+		Fields: graphql.Fields{
+			"id":          {Type: graphql.NewNonNull(graphql.ID)},
+			"version":     {Type: graphql.NewNonNull(graphql.Int)},
+			"name":        {Type: graphql.NewNonNull(graphql.String)},
+			"description": {Type: graphql.NewNonNull(graphql.String)},
+			"books": {
+				Type:        books,
+				Description: "Books published by the publisher",
+				Args:        bookPageArgs,
+				Resolve: resolver(func(p graphql.ResolveParams) (interface{}, error) {
+					return booksOf(p, book.GroupByPublisher, source[publisher.PublisherDetailResponse](p).ID)
+				}),
+			},
+		},
+	})
+	categoryType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Category",
+		Fields: graphql.Fields{
+			"id":          {Type: graphql.NewNonNull(graphql.ID)},
+			"version":     {Type: graphql.NewNonNull(graphql.Int)},
+			"code":        {Type: graphql.NewNonNull(graphql.String)},
+			"name":        {Type: graphql.NewNonNull(graphql.String)},
+			"description": {Type: graphql.NewNonNull(graphql.String)},
+			"createdAt":   {Type: graphql.NewNonNull(graphql.DateTime)},
+			"updatedAt":   {Type: graphql.NewNonNull(graphql.DateTime)},
+			"books": {
+				Type:        books,
+				Description: "Books in the category",
+				Args:        bookPageArgs,
+				Resolve: resolver(func(p graphql.ResolveParams) (interface{}, error) {
+					return booksOf(p, book.GroupByCategory, source[category.CategoryDetailResponse](p).ID)
+				}),
+			},
+		},
+	})
+
+	id := &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}
+	nameArgs := withArgs(graphql.FieldConfigArgument{"name": {Type: graphql.String, Description: "Matches names containing it"}})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"book": {
+				Type: bookType,
+				Args: graphql.FieldConfigArgument{"id": id},
+				Resolve: resolver(func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := idArg(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					return s.books.GetBook(p.Context, id)
+				}),
+			},
+			"books": {
+				Type: books,
+				Args: withArgs(graphql.FieldConfigArgument{
+					"title":    {Type: graphql.String, Description: "Matches titles containing it"},
+					"category": {Type: graphql.NewList(graphql.NewNonNull(graphql.String)), Description: "Matches books in at least one of the category codes"},
+				}),
+				Resolve: resolver(func(p graphql.ResolveParams) (interface{}, error) {
+					sort, page, match, err := listQuery(p.Args, book.SortFields)
+					if err != nil {
+						return nil, err
+					}
+					title, _ := p.Args["title"].(string)
+					filter := book.BookFilter{Title: title, CategoryCodes: stringsArg(p.Args, "category"), Fuzzy: match}
+					result, err := s.books.GetBooks(p.Context, page, sort, filter)
+					if err != nil {
+						return nil, err
+					}
+					return toPage(result), nil
+				}),
+			},
+			"author": {
+				Type: authorType,
+				Args: graphql.FieldConfigArgument{"id": id},
+				Resolve: resolver(func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := idArg(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					return s.authors.GetAuthor(p.Context, id)
+				}),
+			},
+			"authors": {
+				Type: graphql.NewNonNull(pageType("AuthorPage", authorType)),
+				Args: nameArgs,
+				Resolve: resolver(func(p graphql.ResolveParams) (interface{}, error) {
+					sort, page, match, err := listQuery(p.Args, author.SortFields)
+					if err != nil {
+						return nil, err
+					}
+					name, _ := p.Args["name"].(string)
+					result, err := s.authors.GetAuthors(p.Context, page, sort, name, match)
+					if err != nil {
+						return nil, err
+					}
+					return detailPage(result, func(a author.AuthorDTO) string { return a.ID }, func(ids []uint) ([]author.AuthorDetailResponse, error) {
+						return s.authors.GetAuthorsByIDs(p.Context, ids)
+					}, func(a *author.AuthorDetailResponse) uint { return a.ID })
+				}),
+			},
+			"publisher": {
+				Type: publisherType,
+				Args: graphql.FieldConfigArgument{"id": id},
+				Resolve: resolver(func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := idArg(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					return s.publishers.GetPublisher(p.Context, id)
+				}),
+			},
+			"publishers": {
+				Type: graphql.NewNonNull(pageType("PublisherPage", publisherType)),
+				Args: nameArgs,
+				Resolve: resolver(func(p graphql.ResolveParams) (interface{}, error) {
+					sort, page, match, err := listQuery(p.Args, publisher.SortFields)
+					if err != nil {
+						return nil, err
+					}
+					name, _ := p.Args["name"].(string)
+					result, err := s.publishers.GetPublishers(p.Context, page, sort, name, match)
+					if err != nil {
+						return nil, err
+					}
+					return detailPage(result, func(pub publisher.PublisherDTO) string { return pub.ID }, func(ids []uint) ([]publisher.PublisherDetailResponse, error) {
+						return s.publishers.GetPublishersByIDs(p.Context, ids)
+					}, func(pub *publisher.PublisherDetailResponse) uint { return pub.ID })
+				}),
+			},
+			"category": {
+				Type: categoryType,
+				Args: graphql.FieldConfigArgument{"id": id},
+				Resolve: resolver(func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := idArg(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					return s.categories.GetCategory(p.Context, id)
+				}),
+			},
+			"categories": {
+				Type: graphql.NewNonNull(pageType("CategoryPage", categoryType)),
+				Args: nameArgs,
+				Resolve: resolver(func(p graphql.ResolveParams) (interface{}, error) {
+					sort, page, match, err := listQuery(p.Args, category.SortFields)
+					if err != nil {
+						return nil, err
+					}
+					name, _ := p.Args["name"].(string)
+					result, err := s.categories.GetCategories(p.Context, page, sort, name, match)
+					if err != nil {
+						return nil, err
+					}
+					return toPage(result), nil
+				}),
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query:    query,
+		Mutation: newMutation(s, bookType, authorType, publisherType, categoryType),
+	})
+}
+
+// newMutation creates the mutation type, with a create, update and delete mutation for each entity.
+// Updates and deletes take the version they expect to change, like the If-Match header of the REST endpoints.
+func newMutation(s *graphServiceImpl, bookType, authorType, publisherType, categoryType *graphql.Object) *graphql.Object {
+	id := &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}
+	expected := &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int), Description: "Version the record is expected to be at"}
+	nameInput := func(name string) *graphql.ArgumentConfig {
+		return &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.NewInputObject(graphql.InputObjectConfig{
+			Name: name,
+			Fields: graphql.InputObjectConfigFieldMap{
+				"name":        {Type: graphql.NewNonNull(graphql.String)},
+				"description": {Type: graphql.String},
+			},
+		}))}
+	}
+	bookInput := &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "BookInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"title":       {Type: graphql.NewNonNull(graphql.String)},
+			"description": {Type: graphql.String},
+			"isbn10":      {Type: graphql.String},
+			"isbn13":      {Type: graphql.String},
+			"pages":       {Type: graphql.Int},
+			"year":        {Type: graphql.Int},
+			"publisherId": {Type: graphql.NewNonNull(graphql.ID)},
+			"authorIds":   {Type: graphql.NewList(graphql.NewNonNull(graphql.ID))},
+			"categoryIds": {Type: graphql.NewList(graphql.NewNonNull(graphql.ID))},
+		},
+	}))}
+	authorInput, publisherInput := nameInput("AuthorInput"), nameInput("PublisherInput")
+	categoryInput := &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "CategoryInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"code":        {Type: graphql.NewNonNull(graphql.String)},
+			"name":        {Type: graphql.NewNonNull(graphql.String)},
+			"description": {Type: graphql.String},
+		},
+	}))}
+	deleteArgs := graphql.FieldConfigArgument{
+		"id":         id,
+		"version":    expected,
+		"policy":     {Type: deletionPolicy, Description: "What happens to the books referring to the record, RESTRICT by default"},
+		"reassignTo": {Type: graphql.ID, Description: "Record the books move to under the REASSIGN policy"},
+	}
+
+	// deleted is the result of the delete mutations
+	deleted := func(err error) (interface{}, error) {
+		return err == nil, err
+	}
+
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createBook": {
+				Type: graphql.NewNonNull(bookType),
+				Args: graphql.FieldConfigArgument{"input": bookInput},
+				Resolve: resolver(func(p graphql.ResolveParams) (interface{}, error) {
+					request, err := bookRequest(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					created, err := s.books.CreateBook(p.Context, request)
+					if err != nil {
+						return nil, err
+					}
+					return s.books.GetBook(p.Context, created.ID)
+				}),
+			},
+			"updateBook": {
+				Type: graphql.NewNonNull(bookType),
+				Args: graphql.FieldConfigArgument{"id": id, "version": expected, "input": bookInput},
+				Resolve: resolver(func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := idArg(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					request, err := bookRequest(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					return s.books.UpdateBook(p.Context, id, request, precondition(p.Args))
+				}),
+			},
+			"deleteBook": {
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: graphql.FieldConfigArgument{"id": id, "version": expected},
+				Resolve: resolver(func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := idArg(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					return deleted(s.books.DeleteBook(p.Context, id, precondition(p.Args)))
+				}),
+			},
+			"createAuthor": {
+				Type: graphql.NewNonNull(authorType),
+				Args: graphql.FieldConfigArgument{"input": authorInput},
+				Resolve: resolver(func(p graphql.ResolveParams) (interface{}, error) {
+					created, err := s.authors.CreateAuthor(p.Context, authorRequest(p.Args))
+					if err != nil {
+						return nil, err
+					}
+					return s.authors.GetAuthor(p.Context, created.ID)
+				}),
+			},
+			"updateAuthor": {
+				Type: graphql.NewNonNull(authorType),
+				Args: graphql.FieldConfigArgument{"id": id, "version": expected, "input": authorInput},
+				Resolve: resolver(func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := idArg(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					return s.authors.UpdateAuthor(p.Context, id, authorRequest(p.Args), precondition(p.Args))
+				}),
+			},
+			"deleteAuthor": {
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: deleteArgs,
+				Resolve: resolver(func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := idArg(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					options, err := deletionOptions(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					return deleted(s.authors.DeleteAuthor(p.Context, id, precondition(p.Args), options))
+				}),
+			},
+			"createPublisher": {
+				Type: graphql.NewNonNull(publisherType),
+				Args: graphql.FieldConfigArgument{"input": publisherInput},
+				Resolve: resolver(func(p graphql.ResolveParams) (interface{}, error) {
+					created, err := s.publishers.CreatePublisher(p.Context, publisherRequest(p.Args))
+					if err != nil {
+						return nil, err
+					}
+					return s.publishers.GetPublisher(p.Context, created.ID)
+				}),
+			},
+			"updatePublisher": {
+				Type: graphql.NewNonNull(publisherType),
+				Args: graphql.FieldConfigArgument{"id": id, "version": expected, "input": publisherInput},
+				Resolve: resolver(func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := idArg(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					return s.publishers.UpdatePublisher(p.Context, id, publisherRequest(p.Args), precondition(p.Args))
+				}),
+			},
+			"deletePublisher": {
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: deleteArgs,
+				Resolve: resolver(func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := idArg(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					options, err := deletionOptions(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					return deleted(s.publishers.DeletePublisher(p.Context, id, precondition(p.Args), options))
+				}),
+			},
+			"createCategory": {
+				Type: graphql.NewNonNull(categoryType),
+				Args: graphql.FieldConfigArgument{"input": categoryInput},
+				Resolve: resolver(func(p graphql.ResolveParams) (interface{}, error) {
+					return s.categories.CreateCategory(p.Context, categoryRequest(p.Args))
+				}),
+			},
+			"updateCategory": {
+				Type: graphql.NewNonNull(categoryType),
+				Args: graphql.FieldConfigArgument{"id": id, "version": expected, "input": categoryInput},
+				Resolve: resolver(func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := idArg(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					return s.categories.UpdateCategory(p.Context, id, categoryRequest(p.Args), precondition(p.Args))
+				}),
+			},
+			"deleteCategory": {
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: graphql.FieldConfigArgument{"id": id, "version": expected},
+				Resolve: resolver(func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := idArg(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					return deleted(s.categories.DeleteCategory(p.Context, id, precondition(p.Args)))
+				}),
+			},
+		},
+	})
+}
+
+// detailPage converts a page of reference DTOs into a page of the details of the records,
+// fetched together by ID in the order of the page
+func detailPage[T any, D any](p *pagination.Page[T], idOf func(T) string, fetch func(ids []uint) ([]D, error), detailID func(*D) uint) (*page, error) {
+	ids := make([]uint, len(p.Data))
+	for i, item := range p.Data {
+		ids[i] = parseID(idOf(item))
+	}
+	details, err := fetch(ids)
+	if err != nil {
+		return nil, err
+	}
+	index := byID(details, detailID)
+
+	result := toPage(p)
+	data := make([]D, 0, len(ids))
+	for _, id := range ids {
+		if detail, ok := index[id]; ok {
+			data = append(data, *detail)
+		}
+	}
+	result.Data = data
+	return result, nil
+}
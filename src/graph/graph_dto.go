@@ -0,0 +1,8 @@
+package graph
+
+// Request is a GraphQL request, read from a JSON body or from the query string of a GET request
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
@@ -0,0 +1,101 @@
+package graph
+
+import (
+	"errors"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+)
+
+// Codes reported in the extensions of resolver errors, by apperror.Kind
+var codes = map[apperror.Kind]string{
+	apperror.KindBadRequest:           "BAD_REQUEST",
+	apperror.KindNotFound:             "NOT_FOUND",
+	apperror.KindValidation:           "VALIDATION_FAILED",
+	apperror.KindConflict:             "CONFLICT",
+	apperror.KindPreconditionFailed:   "PRECONDITION_FAILED",
+	apperror.KindPreconditionRequired: "PRECONDITION_REQUIRED",
+}
+
+// CodeInternal is the code of the errors that are not domain errors; their details are only logged
+const CodeInternal = "INTERNAL_SERVER_ERROR"
+
+// Error is the error of a resolver, carrying the code, HTTP status and invalid fields of its
+// domain error in its extensions
+type Error struct {
+	Message    string
+	extensions map[string]interface{}
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Extensions implements gqlerrors.ExtendedError
+func (e *Error) Extensions() map[string]interface{} {
+	return e.extensions
+}
+
+// newError converts the error of a service into an Error
+func newError(err error) *Error {
+	var appErr *apperror.Error
+	if !errors.As(err, &appErr) {
+		log.Printf("graphql resolver error: %v", err)
+		return &Error{Message: "internal server error", extensions: map[string]interface{}{"code": CodeInternal, "status": fiber.StatusInternalServerError}}
+	}
+
+	extensions := map[string]interface{}{"code": codes[appErr.Kind], "status": appErr.Kind.Status()}
+	if len(appErr.Fields) > 0 {
+		extensions["fields"] = appErr.Fields
+	}
+	if len(appErr.References) > 0 {
+		extensions["references"] = appErr.References
+	}
+	return &Error{Message: appErr.Message, extensions: extensions}
+}
+
+// resolver wraps fn so the errors it returns, directly or from a thunk, become Errors
+func resolver(fn graphql.FieldResolveFn) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		value, err := fn(p)
+		if err != nil {
+			return nil, newError(err)
+		}
+		if thunk, ok := value.(func() (interface{}, error)); ok {
+			return func() (interface{}, error) {
+				value, err := thunk()
+				if err != nil {
+					return nil, newError(err)
+				}
+				return value, nil
+			}, nil
+		}
+		return value, nil
+	}
+}
+
+// withExtensions sets the extensions of the errors of result raised by resolvers. Errors of
+// thunks reach the result wrapped in formatted errors that drop their extensions.
+func withExtensions(result *graphql.Result) {
+	for i, formatted := range result.Errors {
+		var err error = formatted
+		for err != nil {
+			if resolverErr, ok := err.(*Error); ok {
+				result.Errors[i].Extensions = resolverErr.extensions
+				break
+			}
+			switch e := err.(type) {
+			case gqlerrors.FormattedError:
+				err = e.OriginalError()
+			case *gqlerrors.Error:
+				err = e.OriginalError
+			default:
+				err = nil
+			}
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package graph
+
+import (
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GraphHandler handles the GraphQL endpoint
+type GraphHandler struct {
+	service GraphService
+}
+
+// NewGraphHandler creates a new instance of GraphHandler
+func NewGraphHandler(service GraphService) *GraphHandler {
+	return &GraphHandler{service: service}
+}
+
+// Execute handles POST /graphql request, running the query or mutation of its JSON body.
+// GraphQL errors are reported in a successful response next to the data resolved.
+func (h *GraphHandler) Execute(c *fiber.Ctx) error {
+	var request Request
+	if err := json.Unmarshal(c.Body(), &request); err != nil || request.Query == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid GraphQL request body")
+	}
+
+	return c.JSON(h.service.Execute(c.UserContext(), request))
+}
+
+// ExecuteQuery handles GET /graphql request, running the query of its query string
+func (h *GraphHandler) ExecuteQuery(c *fiber.Ctx) error {
+	request := Request{Query: c.Query("query"), OperationName: c.Query("operationName")}
+	if request.Query == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Missing GraphQL query")
+	}
+	if variables := c.Query("variables"); variables != "" {
+		if err := json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid GraphQL variables")
+		}
+	}
+
+	return c.JSON(h.service.ExecuteQuery(c.UserContext(), request))
+}
+
+// RegisterRoutes registers all routes for the GraphQL endpoint
+func (h *GraphHandler) RegisterRoutes(app *fiber.App) {
+	app.Get("/graphql", h.ExecuteQuery)
+	app.Post("/graphql", h.Execute)
+}
@@ -0,0 +1,105 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/tedysaputro/book-catalog-with-go/src/author"
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/category"
+	"github.com/tedysaputro/book-catalog-with-go/src/publisher"
+)
+
+// GraphService defines the interface for running GraphQL requests.
+// Errors are reported in the result, along with the data resolved.
+type GraphService interface {
+	// Execute runs a query or mutation
+	Execute(ctx context.Context, request Request) *graphql.Result
+	// ExecuteQuery runs a query, refusing mutations
+	ExecuteQuery(ctx context.Context, request Request) *graphql.Result
+}
+
+type graphServiceImpl struct {
+	books      book.BookService
+	authors    author.AuthorService
+	publishers publisher.PublisherService
+	categories category.CategoryService
+	schema     graphql.Schema
+	limits     Limits
+}
+
+// NewGraphService creates a new instance of GraphService resolving through the services of each entity
+func NewGraphService(books book.BookService, authors author.AuthorService, publishers publisher.PublisherService, categories category.CategoryService) (GraphService, error) {
+	s := &graphServiceImpl{books: books, authors: authors, publishers: publishers, categories: categories, limits: DefaultLimits}
+	schema, err := newSchema(s)
+	if err != nil {
+		return nil, err
+	}
+	s.schema = schema
+	return s, nil
+}
+
+// Execute runs a query or mutation
+func (s *graphServiceImpl) Execute(ctx context.Context, request Request) *graphql.Result {
+	return s.execute(ctx, request, true)
+}
+
+// ExecuteQuery runs a query, refusing mutations
+func (s *graphServiceImpl) ExecuteQuery(ctx context.Context, request Request) *graphql.Result {
+	return s.execute(ctx, request, false)
+}
+
+// execute parses and validates a request, checks it against the limits and runs it with
+// the loaders of the request in its context
+func (s *graphServiceImpl) execute(ctx context.Context, request Request, mutations bool) *graphql.Result {
+	document, err := parser.Parse(parser.ParseParams{Source: request.Query})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+	if validation := graphql.ValidateDocument(&s.schema, document, nil); !validation.IsValid {
+		return &graphql.Result{Errors: validation.Errors}
+	}
+
+	if operation := findOperation(document, request.OperationName); operation != nil {
+		if operation.Operation == ast.OperationTypeMutation && !mutations {
+			return &graphql.Result{Errors: []gqlerrors.FormattedError{gqlerrors.NewFormattedError("mutations are only accepted in POST requests")}}
+		}
+		if errs := s.limits.check(&s.schema, document, operation, request.Variables); len(errs) > 0 {
+			return &graphql.Result{Errors: errs}
+		}
+	}
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           document,
+		OperationName: request.OperationName,
+		Args:          request.Variables,
+		Context:       context.WithValue(ctx, loadersKey{}, s.newLoaders()),
+	})
+	withExtensions(result)
+	return result
+}
+
+// findOperation returns the operation of document named name, or its only operation when name
+// is empty. Execute reports the error of a missing or ambiguous operation.
+func findOperation(document *ast.Document, name string) *ast.OperationDefinition {
+	var found *ast.OperationDefinition
+	for _, definition := range document.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if name == "" {
+			if found != nil {
+				return nil
+			}
+			found = operation
+		} else if operation.Name != nil && operation.Name.Value == name {
+			return operation
+		}
+	}
+	return found
+}
@@ -0,0 +1,161 @@
+package graph
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+)
+
+// Limits bound the cost of the queries the endpoint runs
+type Limits struct {
+	// MaxDepth is the deepest nesting of fields a query may select
+	MaxDepth int
+	// MaxComplexity is the largest number of fields a query may resolve, counting each field of a
+	// list once per item the list may hold: the page size of paged fields, otherwise ListSize
+	MaxComplexity int
+}
+
+// ListSize is the estimated length of the lists that are not paged, such as the authors of a book
+const ListSize = 5
+
+// DefaultLimits are the limits of the GraphQL endpoint
+var DefaultLimits = Limits{MaxDepth: 8, MaxComplexity: 5000}
+
+// cost is the depth and complexity of a selection set
+type cost struct {
+	depth      int
+	complexity int
+}
+
+// check reports an error for each limit the operation breaks. Introspection fields are not counted.
+func (l Limits) check(schema *graphql.Schema, document *ast.Document, operation *ast.OperationDefinition, variables map[string]interface{}) []gqlerrors.FormattedError {
+	root := schema.QueryType()
+	if operation.Operation == ast.OperationTypeMutation {
+		root = schema.MutationType()
+	}
+	walker := &costWalker{schema: schema, fragments: map[string]*ast.FragmentDefinition{}, variables: variables}
+	for _, definition := range document.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok {
+			walker.fragments[fragment.Name.Value] = fragment
+		}
+	}
+	c := walker.selectionSet(operation.SelectionSet, root, 0)
+
+	var errs []gqlerrors.FormattedError
+	if c.depth > l.MaxDepth {
+		errs = append(errs, limitError(fmt.Sprintf("query depth %d exceeds the maximum depth of %d", c.depth, l.MaxDepth), "MAX_DEPTH_EXCEEDED"))
+	}
+	if c.complexity > l.MaxComplexity {
+		errs = append(errs, limitError(fmt.Sprintf("query complexity %d exceeds the maximum complexity of %d", c.complexity, l.MaxComplexity), "MAX_COMPLEXITY_EXCEEDED"))
+	}
+	return errs
+}
+
+// limitError creates the error of a broken limit
+func limitError(message, code string) gqlerrors.FormattedError {
+	err := gqlerrors.NewFormattedError(message)
+	err.Extensions = map[string]interface{}{"code": code}
+	return err
+}
+
+// costWalker measures the selection sets of a validated document
+type costWalker struct {
+	schema    *graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+}
+
+// selectionSet measures the selections of set on parent. pageSize is the size of the page
+// selected by the enclosing paged field, which the next list field holds.
+func (w *costWalker) selectionSet(set *ast.SelectionSet, parent graphql.Type, pageSize int) cost {
+	var total cost
+	if set == nil {
+		return total
+	}
+	object, _ := parent.(*graphql.Object)
+
+	for _, selection := range set.Selections {
+		var c cost
+		switch selection := selection.(type) {
+		case *ast.Field:
+			c = w.field(selection, object, pageSize)
+		case *ast.InlineFragment:
+			c = w.selectionSet(selection.SelectionSet, w.typeCondition(selection.TypeCondition, parent), pageSize)
+		case *ast.FragmentSpread:
+			if fragment, ok := w.fragments[selection.Name.Value]; ok {
+				c = w.selectionSet(fragment.SelectionSet, w.typeCondition(fragment.TypeCondition, parent), pageSize)
+			}
+		}
+		total.depth = max(total.depth, c.depth)
+		total.complexity += c.complexity
+	}
+	return total
+}
+
+// field measures a field and its selections
+func (w *costWalker) field(field *ast.Field, parent *graphql.Object, pageSize int) cost {
+	if strings.HasPrefix(field.Name.Value, "__") || parent == nil {
+		return cost{}
+	}
+	definition, ok := parent.Fields()[field.Name.Value]
+	if !ok {
+		return cost{}
+	}
+
+	childPageSize := 0
+	for _, argument := range definition.Args {
+		if argument.Name() == "pageSize" {
+			childPageSize = w.pageSize(field)
+		}
+	}
+	multiplier := 1
+	if _, ok := graphql.GetNullable(definition.Type).(*graphql.List); ok {
+		multiplier = ListSize
+		if pageSize > 0 {
+			multiplier = pageSize
+		}
+		childPageSize = 0
+	}
+
+	named, _ := graphql.GetNamed(definition.Type).(graphql.Type)
+	children := w.selectionSet(field.SelectionSet, named, childPageSize)
+	return cost{depth: children.depth + 1, complexity: 1 + multiplier*children.complexity}
+}
+
+// pageSize returns the page size a paged field asks for, capped like the REST endpoints
+func (w *costWalker) pageSize(field *ast.Field) int {
+	size := int(pagination.DefaultPageSize)
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != "pageSize" {
+			continue
+		}
+		switch value := argument.Value.(type) {
+		case *ast.IntValue:
+			size, _ = strconv.Atoi(value.Value)
+		case *ast.Variable:
+			switch variable := w.variables[value.Name.Value].(type) {
+			case int:
+				size = variable
+			case float64:
+				size = int(variable)
+			}
+		}
+	}
+	return min(max(size, 1), int(pagination.MaxPageSize))
+}
+
+// typeCondition returns the type a fragment applies to
+func (w *costWalker) typeCondition(condition *ast.Named, parent graphql.Type) graphql.Type {
+	if condition == nil {
+		return parent
+	}
+	if t := w.schema.Type(condition.Name.Value); t != nil {
+		return t
+	}
+	return parent
+}
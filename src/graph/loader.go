@@ -0,0 +1,188 @@
+package graph
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/author"
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/category"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/publisher"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+)
+
+// loader batches the keys requested by the resolvers of a query level into a single fetch.
+// Resolvers get a thunk from load; the executor runs the thunks once every field of the level
+// has been resolved, so the first thunk run fetches the keys of all of them.
+type loader[K comparable, V any] struct {
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	pending []K
+	fetched map[K]bool
+	values  map[K]V
+	errs    map[K]error
+}
+
+// newLoader creates a loader fetching its keys with fetch
+func newLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{fetch: fetch, fetched: map[K]bool{}, values: map[K]V{}, errs: map[K]error{}}
+}
+
+// load queues key for the next fetch and returns the thunk resolving its value.
+// A key missing from the fetch result resolves to the zero value of V.
+func (l *loader[K, V]) load(ctx context.Context, key K) func() (interface{}, error) {
+	l.queue(key)
+	return func() (interface{}, error) {
+		value, _, err := l.get(ctx, key)
+		return value, err
+	}
+}
+
+// loadMany queues keys for the next fetch and returns the thunk resolving the values of the
+// keys found, in the order of keys
+func (l *loader[K, V]) loadMany(ctx context.Context, keys []K) func() (interface{}, error) {
+	l.queue(keys...)
+	return func() (interface{}, error) {
+		values := make([]V, 0, len(keys))
+		for _, key := range keys {
+			value, ok, err := l.get(ctx, key)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				values = append(values, value)
+			}
+		}
+		return values, nil
+	}
+}
+
+// queue adds the keys not fetched yet to the next fetch
+func (l *loader[K, V]) queue(keys ...K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, key := range keys {
+		if !l.fetched[key] {
+			l.pending = append(l.pending, key)
+		}
+	}
+}
+
+// get returns the value of key and whether it was found, fetching the pending keys first when
+// key has not been fetched yet
+func (l *loader[K, V]) get(ctx context.Context, key K) (V, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.fetched[key] {
+		keys := unique(append(l.pending, key))
+		l.pending = nil
+		values, err := l.fetch(ctx, keys)
+		for _, k := range keys {
+			l.fetched[k] = true
+			if err != nil {
+				l.errs[k] = err
+			} else if value, ok := values[k]; ok {
+				l.values[k] = value
+			}
+		}
+	}
+	value, ok := l.values[key]
+	return value, ok, l.errs[key]
+}
+
+// unique returns keys without duplicates, in their first order
+func unique[K comparable](keys []K) []K {
+	seen := make(map[K]bool, len(keys))
+	result := make([]K, 0, len(keys))
+	for _, key := range keys {
+		if !seen[key] {
+			seen[key] = true
+			result = append(result, key)
+		}
+	}
+	return result
+}
+
+// loaders are the loaders of a single request, so values are never cached across requests
+type loaders struct {
+	authors    *loader[uint, *author.AuthorDetailResponse]
+	publishers *loader[uint, *publisher.PublisherDetailResponse]
+	categories *loader[uint, *category.CategoryDetailResponse]
+
+	fetchBooks func(ctx context.Context, query booksQuery, ids []uint) (map[uint]*book.BookListResponse, error)
+	mu         sync.Mutex
+	books      map[booksQuery]*loader[uint, *book.BookListResponse]
+}
+
+// booksQuery is the page of books requested from each record of a grouping. The books of the
+// records sharing one are fetched together.
+type booksQuery struct {
+	grouping book.Grouping
+	page     uint
+	pageSize uint
+	sort     string
+}
+
+// loadersKey is the context key of the loaders of a request
+type loadersKey struct{}
+
+// newLoaders creates the loaders of a request, reading through the services of s
+func (s *graphServiceImpl) newLoaders() *loaders {
+	return &loaders{
+		authors: newLoader(func(ctx context.Context, ids []uint) (map[uint]*author.AuthorDetailResponse, error) {
+			authors, err := s.authors.GetAuthorsByIDs(ctx, ids)
+			return byID(authors, func(a *author.AuthorDetailResponse) uint { return a.ID }), err
+		}),
+		publishers: newLoader(func(ctx context.Context, ids []uint) (map[uint]*publisher.PublisherDetailResponse, error) {
+			publishers, err := s.publishers.GetPublishersByIDs(ctx, ids)
+			return byID(publishers, func(p *publisher.PublisherDetailResponse) uint { return p.ID }), err
+		}),
+		categories: newLoader(func(ctx context.Context, ids []uint) (map[uint]*category.CategoryDetailResponse, error) {
+			categories, err := s.categories.GetCategoriesByIDs(ctx, ids)
+			return byID(categories, func(c *category.CategoryDetailResponse) uint { return c.ID }), err
+		}),
+		fetchBooks: func(ctx context.Context, query booksQuery, ids []uint) (map[uint]*book.BookListResponse, error) {
+			// The sort was validated by booksArgs, which formatted it back
+			sort, _ := sorting.Parse(query.sort, book.SortFields)
+			page := pagination.Request{Page: query.page, PageSize: query.pageSize}
+			return s.books.GetBooksByGroup(ctx, query.grouping, ids, page, sort)
+		},
+		books: map[booksQuery]*loader[uint, *book.BookListResponse]{},
+	}
+}
+
+// booksOf returns the loader of the page of books query requests from each record
+func (l *loaders) booksOf(query booksQuery) *loader[uint, *book.BookListResponse] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.books[query]; !ok {
+		l.books[query] = newLoader(func(ctx context.Context, ids []uint) (map[uint]*book.BookListResponse, error) {
+			return l.fetchBooks(ctx, query, ids)
+		})
+	}
+	return l.books[query]
+}
+
+// loadersFrom returns the loaders of the request of ctx
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// byID indexes records by their ID
+func byID[T any](records []T, id func(*T) uint) map[uint]*T {
+	index := make(map[uint]*T, len(records))
+	for i := range records {
+		index[id(&records[i])] = &records[i]
+	}
+	return index
+}
+
+// parseID returns the ID of a reference DTO, whose IDs are strings
+func parseID(raw string) uint {
+	id, _ := strconv.ParseUint(raw, 10, 32)
+	return uint(id)
+}
@@ -0,0 +1,152 @@
+package history
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+)
+
+// Action is the kind of change an Entry records
+type Action string
+
+const (
+	ActionCreate  Action = "create"
+	ActionUpdate  Action = "update"
+	ActionRevert  Action = "revert"
+	ActionDelete  Action = "delete"
+	ActionRestore Action = "restore"
+	ActionPurge   Action = "purge"
+)
+
+// Removes reports whether the record no longer exists after the action
+func (a Action) Removes() bool {
+	return a == ActionDelete || a == ActionPurge
+}
+
+// SortFields lists the fields history entries can be sorted by
+var SortFields = sorting.Fields{
+	"id":         {Name: "history.id", Type: sorting.Integer},
+	"changed_at": {Name: "history.changed_at", Type: sorting.Time},
+}
+
+// Entry records one change of a catalog record with its state before and after the change
+type Entry struct {
+	ID         uint   `gorm:"primaryKey"`
+	EntityType string `gorm:"type:varchar(20);not null"`
+	EntityID   uint   `gorm:"not null"`
+	// Version is the version of the record after the change, or of the removed record
+	Version   uint      `gorm:"not null"`
+	Action    Action    `gorm:"type:varchar(10);not null"`
+	Actor     string    `gorm:"type:varchar(100);not null"`
+	ChangedAt time.Time `gorm:"not null"`
+	Before    Snapshot  `gorm:"column:before_state;type:jsonb"`
+	After     Snapshot  `gorm:"column:after_state;type:jsonb"`
+}
+
+// TableName specifies the table name for Entry model
+func (Entry) TableName() string {
+	return "history"
+}
+
+// SortValue returns the value of a field of SortFields
+func (e *Entry) SortValue(field string) interface{} {
+	if field == "changed_at" {
+		return e.ChangedAt
+	}
+	return e.ID
+}
+
+// Snapshot is the JSON state of a record at one point in time, empty when there is none
+type Snapshot []byte
+
+// NewSnapshot encodes state, which nil leaves empty
+func NewSnapshot(state interface{}) (Snapshot, error) {
+	if state == nil {
+		return nil, nil
+	}
+	return json.Marshal(state)
+}
+
+// Decode unmarshals the snapshot into state
+func (s Snapshot) Decode(state interface{}) error {
+	return json.Unmarshal(s, state)
+}
+
+// MarshalJSON writes the snapshot as is, or null when it is empty
+func (s Snapshot) MarshalJSON() ([]byte, error) {
+	if len(s) == 0 {
+		return []byte("null"), nil
+	}
+	return s, nil
+}
+
+// UnmarshalJSON keeps a copy of data, leaving the snapshot empty for null
+func (s *Snapshot) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*s = nil
+		return nil
+	}
+	*s = append(Snapshot(nil), data...)
+	return nil
+}
+
+// Value implements driver.Valuer, storing an empty snapshot as NULL
+func (s Snapshot) Value() (driver.Value, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	return string(s), nil
+}
+
+// Scan implements sql.Scanner
+func (s *Snapshot) Scan(value interface{}) error {
+	switch value := value.(type) {
+	case nil:
+		*s = nil
+	case []byte:
+		*s = append(Snapshot(nil), value...)
+	case string:
+		*s = Snapshot(value)
+	default:
+		return fmt.Errorf("cannot scan %T into a history snapshot", value)
+	}
+	return nil
+}
+
+type actorKey struct{}
+
+// Anonymous is the actor of changes made by requests that do not name one
+const Anonymous = "anonymous"
+
+// MaxActorLength is the longest actor name, in characters, the history can store
+const MaxActorLength = 100
+
+// ErrActorTooLong is returned by CheckActor for a name the history cannot store
+var ErrActorTooLong = fmt.Errorf("actor must be at most %d characters", MaxActorLength)
+
+// CheckActor returns ErrActorTooLong when actor is longer than MaxActorLength, so requests
+// naming it can be refused before they make changes that could not be recorded
+func CheckActor(actor string) error {
+	if utf8.RuneCountInString(actor) > MaxActorLength {
+		return ErrActorTooLong
+	}
+	return nil
+}
+
+// WithActor returns a copy of ctx naming the actor whose changes are recorded
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom returns the actor named by ctx, or Anonymous
+func ActorFrom(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return Anonymous
+}
@@ -0,0 +1,26 @@
+package history
+
+import (
+	"time"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+)
+
+// EntryDTO represents a change in the history of a record
+type EntryDTO struct {
+	ID        uint      `json:"id"`
+	Version   uint      `json:"version"`
+	Action    Action    `json:"action"`
+	Actor     string    `json:"actor"`
+	ChangedAt time.Time `json:"changed_at"`
+	Before    Snapshot  `json:"before"`
+	After     Snapshot  `json:"after"`
+}
+
+// HistoryListResponse represents a page of the history of a record, newest change first
+type HistoryListResponse = pagination.Page[EntryDTO]
+
+// RevertRequest represents the request payload to revert a record to a previous version
+type RevertRequest struct {
+	Version uint `json:"version"`
+}
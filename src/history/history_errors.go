@@ -0,0 +1,12 @@
+package history
+
+import "github.com/tedysaputro/book-catalog-with-go/src/apperror"
+
+var (
+	// ErrNoStateAsOf is returned when a record did not exist at the requested time
+	ErrNoStateAsOf = apperror.NotFound("the record did not exist at that time")
+	// ErrVersionNotFound is returned when the history of a record has no state of the requested version
+	ErrVersionNotFound = apperror.NotFound("version not found in the history of the record")
+	// ErrVersionRequired is returned when a revert does not name the version to go back to
+	ErrVersionRequired = apperror.Validation("version", "version must be a previous version of the record")
+)
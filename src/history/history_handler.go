@@ -0,0 +1,76 @@
+package history
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+)
+
+// Resources maps the path of each resource with a history to the entity type of its entries
+var Resources = map[string]string{
+	"authors":    "author",
+	"publishers": "publisher",
+	"categories": "category",
+	"books":      "book",
+}
+
+// HistoryHandler handles HTTP requests for the history of catalog records
+type HistoryHandler struct {
+	service HistoryService
+}
+
+// NewHistoryHandler creates a new instance of HistoryHandler
+func NewHistoryHandler(service HistoryService) *HistoryHandler {
+	return &HistoryHandler{service: service}
+}
+
+// GetHistory returns the handler of GET /<resource>/:id/history for records of entityType
+func (h *HistoryHandler) GetHistory(entityType string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid ID")
+		}
+
+		sort, err := sorting.FromQuery(c.Query("sort", "-id"), "", "", SortFields)
+		if err != nil {
+			return apperror.BadRequest("sort", err)
+		}
+		sort = sort.Stable(SortFields)
+		page, err := pagination.FromQuery(c, sort)
+		if err != nil {
+			return err
+		}
+
+		entries, err := h.service.GetHistory(c.UserContext(), entityType, uint(id), page, sort)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(entries.WithLinks(c))
+	}
+}
+
+// AsOf parses the as_of query parameter, an RFC 3339 time, and reports whether it was given
+func AsOf(c *fiber.Ctx) (time.Time, bool, error) {
+	raw := c.Query("as_of")
+	if raw == "" {
+		return time.Time{}, false, nil
+	}
+	at, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, false, apperror.BadRequest("as_of", err)
+	}
+	return at, true, nil
+}
+
+// RegisterRoutes registers the history routes of every resource
+func (h *HistoryHandler) RegisterRoutes(app *fiber.App) {
+	for resource, entityType := range Resources {
+		app.Get("/api/v1/"+resource+"/:id/history", h.GetHistory(entityType))
+	}
+}
@@ -0,0 +1,94 @@
+package history
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+	"github.com/tedysaputro/book-catalog-with-go/src/transaction"
+	"gorm.io/gorm"
+)
+
+// HistoryRepository defines the persistence operations for history entries
+type HistoryRepository interface {
+	Create(ctx context.Context, entry *Entry) error
+	FindAll(ctx context.Context, entityType string, entityID uint, page pagination.Request, sort sorting.Spec) ([]Entry, uint64, error)
+	FindAsOf(ctx context.Context, entityType string, entityID uint, at time.Time) (*Entry, error)
+	FindVersion(ctx context.Context, entityType string, entityID uint, version uint) (*Entry, error)
+	FindSince(ctx context.Context, entityType string, since time.Time) ([]Entry, error)
+}
+
+type gormHistoryRepository struct {
+	db *gorm.DB
+}
+
+// NewGormHistoryRepository creates a HistoryRepository backed by GORM
+func NewGormHistoryRepository(db *gorm.DB) HistoryRepository {
+	return &gormHistoryRepository{db: db}
+}
+
+// Create inserts a new Entry record
+func (r *gormHistoryRepository) Create(ctx context.Context, entry *Entry) error {
+	return transaction.DB(ctx, r.db).Create(entry).Error
+}
+
+// FindAll retrieves the history entries of a record with pagination
+func (r *gormHistoryRepository) FindAll(ctx context.Context, entityType string, entityID uint, page pagination.Request, sort sorting.Spec) ([]Entry, uint64, error) {
+	var entries []Entry
+	var total int64
+
+	query := transaction.DB(ctx, r.db).Model(&Entry{}).Where("entity_type = ? AND entity_id = ?", entityType, entityID)
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := page.Apply(query, sort).Find(&entries).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return entries, uint64(total), nil
+}
+
+// FindAsOf retrieves the last Entry of a record made at or before the given time
+func (r *gormHistoryRepository) FindAsOf(ctx context.Context, entityType string, entityID uint, at time.Time) (*Entry, error) {
+	var entry Entry
+	err := transaction.DB(ctx, r.db).
+		Where("entity_type = ? AND entity_id = ? AND changed_at <= ?", entityType, entityID, at).
+		Order("changed_at DESC, id DESC").
+		First(&entry).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNoStateAsOf
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// FindVersion retrieves the last Entry of a record that left it at the given version
+func (r *gormHistoryRepository) FindVersion(ctx context.Context, entityType string, entityID uint, version uint) (*Entry, error) {
+	var entry Entry
+	err := transaction.DB(ctx, r.db).
+		Where("entity_type = ? AND entity_id = ? AND version = ? AND after_state IS NOT NULL", entityType, entityID, version).
+		Order("id DESC").
+		First(&entry).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVersionNotFound
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// FindSince retrieves the entries of every record of entityType made at or after since, in the order they were made
+func (r *gormHistoryRepository) FindSince(ctx context.Context, entityType string, since time.Time) ([]Entry, error) {
+	var entries []Entry
+	err := transaction.DB(ctx, r.db).
+		Where("entity_type = ? AND changed_at >= ?", entityType, since).
+		Order("id").
+		Find(&entries).Error
+	return entries, err
+}
@@ -0,0 +1,87 @@
+package history
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/memory"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+)
+
+type memoryHistoryRepository struct {
+	mu      sync.RWMutex
+	entries []Entry
+}
+
+// NewMemoryHistoryRepository creates a HistoryRepository that keeps entries in memory
+func NewMemoryHistoryRepository() HistoryRepository {
+	return &memoryHistoryRepository{}
+}
+
+// Create stores a new Entry
+func (r *memoryHistoryRepository) Create(ctx context.Context, entry *Entry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry.ID = uint(len(r.entries) + 1)
+	r.entries = append(r.entries, *entry)
+	return nil
+}
+
+// FindAll retrieves the history entries of a record with pagination
+func (r *memoryHistoryRepository) FindAll(ctx context.Context, entityType string, entityID uint, page pagination.Request, sort sorting.Spec) ([]Entry, uint64, error) {
+	entries := r.find(entityType, entityID, func(e Entry) bool { return true })
+
+	value := func(e Entry, field string) interface{} { return e.SortValue(field) }
+	memory.Sort(entries, sort, value, nil)
+
+	return memory.Paginate(entries, page, sort, value), uint64(len(entries)), nil
+}
+
+// FindAsOf retrieves the last Entry of a record made at or before the given time
+func (r *memoryHistoryRepository) FindAsOf(ctx context.Context, entityType string, entityID uint, at time.Time) (*Entry, error) {
+	entries := r.find(entityType, entityID, func(e Entry) bool { return !e.ChangedAt.After(at) })
+	if len(entries) == 0 {
+		return nil, ErrNoStateAsOf
+	}
+	return &entries[len(entries)-1], nil
+}
+
+// FindVersion retrieves the last Entry of a record that left it at the given version
+func (r *memoryHistoryRepository) FindVersion(ctx context.Context, entityType string, entityID uint, version uint) (*Entry, error) {
+	entries := r.find(entityType, entityID, func(e Entry) bool { return e.Version == version && len(e.After) > 0 })
+	if len(entries) == 0 {
+		return nil, ErrVersionNotFound
+	}
+	return &entries[len(entries)-1], nil
+}
+
+// FindSince retrieves the entries of every record of entityType made at or after since, in the order they were made
+func (r *memoryHistoryRepository) FindSince(ctx context.Context, entityType string, since time.Time) ([]Entry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := []Entry{}
+	for _, e := range r.entries {
+		if e.EntityType == entityType && !e.ChangedAt.Before(since) {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+// find returns the entries of a record accepted by keep in the order they were made
+func (r *memoryHistoryRepository) find(entityType string, entityID uint, keep func(e Entry) bool) []Entry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := []Entry{}
+	for _, e := range r.entries {
+		if e.EntityType == entityType && e.EntityID == entityID && keep(e) {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
@@ -0,0 +1,98 @@
+package history
+
+import (
+	"context"
+	"time"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+)
+
+// HistoryService defines the interface for recording and reading the history of catalog records
+type HistoryService interface {
+	Record(ctx context.Context, entityType string, entityID uint, version uint, action Action, before interface{}, after interface{}) error
+	GetHistory(ctx context.Context, entityType string, entityID uint, page pagination.Request, sort sorting.Spec) (*HistoryListResponse, error)
+	StateAsOf(ctx context.Context, entityType string, entityID uint, at time.Time, state interface{}) error
+	StateAtVersion(ctx context.Context, entityType string, entityID uint, version uint, state interface{}) error
+}
+
+type historyServiceImpl struct {
+	repo HistoryRepository
+	now  func() time.Time
+}
+
+// NewHistoryService creates a new instance of HistoryService
+func NewHistoryService(repo HistoryRepository) HistoryService {
+	return &historyServiceImpl{repo: repo, now: time.Now}
+}
+
+// Record stores a change of a record made by the actor of ctx.
+// before and after are the states of the record around the change, nil when it did not exist.
+func (s *historyServiceImpl) Record(ctx context.Context, entityType string, entityID uint, version uint, action Action, before interface{}, after interface{}) error {
+	beforeState, err := NewSnapshot(before)
+	if err != nil {
+		return err
+	}
+	afterState, err := NewSnapshot(after)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.Create(ctx, &Entry{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Version:    version,
+		Action:     action,
+		Actor:      ActorFrom(ctx),
+		ChangedAt:  s.now(),
+		Before:     beforeState,
+		After:      afterState,
+	})
+}
+
+// GetHistory retrieves the changes of a record with pagination
+func (s *historyServiceImpl) GetHistory(ctx context.Context, entityType string, entityID uint, page pagination.Request, sort sorting.Spec) (*HistoryListResponse, error) {
+	entries, total, err := s.repo.FindAll(ctx, entityType, entityID, page, sort)
+	if err != nil {
+		return nil, err
+	}
+
+	result := pagination.New(entries, page, total).
+		WithCursors(sort, func(e Entry, field string) interface{} { return e.SortValue(field) })
+
+	return pagination.Map(result, func(e Entry) EntryDTO {
+		return EntryDTO{
+			ID:        e.ID,
+			Version:   e.Version,
+			Action:    e.Action,
+			Actor:     e.Actor,
+			ChangedAt: e.ChangedAt,
+			Before:    e.Before,
+			After:     e.After,
+		}
+	}), nil
+}
+
+// StateAsOf decodes the state a record had at the given time into state
+func (s *historyServiceImpl) StateAsOf(ctx context.Context, entityType string, entityID uint, at time.Time, state interface{}) error {
+	entry, err := s.repo.FindAsOf(ctx, entityType, entityID, at)
+	if err != nil {
+		return err
+	}
+	if entry.Action.Removes() {
+		return ErrNoStateAsOf
+	}
+	return entry.After.Decode(state)
+}
+
+// StateAtVersion decodes the state a record had at the given version into state
+func (s *historyServiceImpl) StateAtVersion(ctx context.Context, entityType string, entityID uint, version uint, state interface{}) error {
+	if version == 0 {
+		return ErrVersionRequired
+	}
+	entry, err := s.repo.FindVersion(ctx, entityType, entityID, version)
+	if err != nil {
+		return err
+	}
+	return entry.After.Decode(state)
+}
@@ -0,0 +1,277 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+)
+
+// Column is a field of the books read from a CSV file
+type Column string
+
+const (
+	ColumnTitle       Column = "title"
+	ColumnDescription Column = "description"
+	ColumnISBN10      Column = "isbn10"
+	ColumnISBN13      Column = "isbn13"
+	ColumnPages       Column = "pages"
+	ColumnYear        Column = "year"
+	// ColumnPublisher is the name of the publisher of the book
+	ColumnPublisher Column = "publisher"
+	// ColumnAuthors are the names of the authors of the book separated by ListSeparator
+	ColumnAuthors Column = "authors"
+	// ColumnCategories are the codes of the categories of the book separated by ListSeparator
+	ColumnCategories Column = "categories"
+)
+
+// Columns lists the columns an import reads
+var Columns = []Column{
+	ColumnTitle, ColumnDescription, ColumnISBN10, ColumnISBN13, ColumnPages, ColumnYear,
+	ColumnPublisher, ColumnAuthors, ColumnCategories,
+}
+
+// RequiredColumns lists the columns a CSV file must have
+var RequiredColumns = []Column{ColumnTitle, ColumnPages, ColumnYear, ColumnPublisher}
+
+// RowField is the field of row errors that concern the row as a whole, such as an unreadable line
+const RowField = "row"
+
+// ListSeparator separates the names in the authors and categories columns
+const ListSeparator = ";"
+
+// Mode decides what an import does with its valid rows when other rows fail
+type Mode string
+
+const (
+	// ModeAtomic imports every row or, when any of them fails, none
+	ModeAtomic Mode = "atomic"
+	// ModeBestEffort imports the rows that are valid and reports the others
+	ModeBestEffort Mode = "best_effort"
+)
+
+// Modes lists the supported modes
+var Modes = []Mode{ModeAtomic, ModeBestEffort}
+
+// ParseMode returns the mode named raw
+func ParseMode(raw string) (Mode, error) {
+	for _, mode := range Modes {
+		if string(mode) == raw {
+			return mode, nil
+		}
+	}
+	return "", &ModeError{Mode: raw}
+}
+
+// Mapping maps columns to the header of the CSV column they are read from.
+// Columns it leaves out are read from the CSV column of the same name.
+type Mapping map[Column]string
+
+// ParseMapping parses a mapping written as "column:Header,column:Header"
+func ParseMapping(raw string) (Mapping, error) {
+	mapping := Mapping{}
+	if strings.TrimSpace(raw) == "" {
+		return mapping, nil
+	}
+	for _, pair := range strings.Split(raw, ",") {
+		name, header, ok := strings.Cut(pair, ":")
+		column := Column(strings.TrimSpace(name))
+		if !ok || strings.TrimSpace(header) == "" {
+			return nil, &ColumnError{Column: string(column), Message: "mapping must be written as column:Header"}
+		}
+		if !isColumn(column) {
+			return nil, &ColumnError{Column: string(column), Message: "unknown column"}
+		}
+		mapping[column] = strings.TrimSpace(header)
+	}
+	return mapping, nil
+}
+
+// header returns the header of the CSV column column is read from
+func (m Mapping) header(column Column) string {
+	if header, ok := m[column]; ok {
+		return header
+	}
+	return string(column)
+}
+
+// Options are the choices of an import request
+type Options struct {
+	Mode Mode
+	// DryRun checks every row without writing anything
+	DryRun bool
+	// CreateMissing creates the publishers and authors that no live record is named after,
+	// which are reported as row errors otherwise
+	CreateMissing bool
+	Columns       Mapping
+}
+
+// FromQuery reads the mode, dry_run, create_missing and columns query parameters of c
+func FromQuery(c *fiber.Ctx) (Options, error) {
+	options := Options{
+		Mode:          ModeAtomic,
+		DryRun:        c.QueryBool("dry_run"),
+		CreateMissing: c.QueryBool("create_missing"),
+	}
+	if raw := c.Query("mode"); raw != "" {
+		mode, err := ParseMode(raw)
+		if err != nil {
+			return Options{}, apperror.BadRequest("mode", err)
+		}
+		options.Mode = mode
+	}
+	columns, err := ParseMapping(c.Query("columns"))
+	if err != nil {
+		return Options{}, apperror.BadRequest("columns", err)
+	}
+	options.Columns = columns
+	return options, nil
+}
+
+// Row is a book read from a line of a CSV file
+type Row struct {
+	// Line is the line of the file the row starts on
+	Line        int
+	Title       string
+	Description string
+	ISBN10      string
+	ISBN13      string
+	Pages       uint
+	Year        uint
+	Publisher   string
+	Authors     []string
+	Categories  []string
+	// Errors are the problems found while reading the line
+	Errors []apperror.FieldError
+}
+
+// ReadRows reads the rows of a CSV file whose first line names its columns
+func ReadRows(r io.Reader, mapping Mapping) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	headers, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, apperror.BadRequest("file", ErrEmptyFile)
+	}
+	if err != nil {
+		return nil, apperror.BadRequest("file", err)
+	}
+
+	index, err := columnIndex(headers, mapping)
+	if err != nil {
+		return nil, apperror.BadRequest("columns", err)
+	}
+
+	rows := []Row{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if errors.Is(err, csv.ErrFieldCount) {
+			rows = append(rows, Row{Line: line, Errors: []apperror.FieldError{{
+				Field:   RowField,
+				Message: fmt.Sprintf("line has %d fields, the header has %d", len(record), len(headers)),
+			}}})
+			continue
+		}
+		if err != nil {
+			return nil, apperror.BadRequest("file", err)
+		}
+		rows = append(rows, readRow(line, record, index))
+	}
+
+	if len(rows) == 0 {
+		return nil, apperror.BadRequest("file", ErrNoRows)
+	}
+	return rows, nil
+}
+
+// columnIndex finds the position of each mapped column among headers, ignoring case
+func columnIndex(headers []string, mapping Mapping) (map[Column]int, error) {
+	positions := make(map[string]int, len(headers))
+	for i, header := range headers {
+		// Spreadsheets often start UTF-8 files with a byte order mark
+		header = strings.TrimPrefix(header, "\ufeff")
+		positions[strings.ToLower(strings.TrimSpace(header))] = i
+	}
+
+	index := make(map[Column]int, len(Columns))
+	for _, column := range Columns {
+		if i, ok := positions[strings.ToLower(mapping.header(column))]; ok {
+			index[column] = i
+		}
+	}
+	for _, column := range RequiredColumns {
+		if _, ok := index[column]; !ok {
+			return nil, &ColumnError{Column: string(column), Message: fmt.Sprintf("the file has no %q column", mapping.header(column))}
+		}
+	}
+	return index, nil
+}
+
+// readRow converts the fields of a CSV record into a Row
+func readRow(line int, record []string, index map[Column]int) Row {
+	value := func(column Column) string {
+		if i, ok := index[column]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	row := Row{
+		Line:        line,
+		Title:       value(ColumnTitle),
+		Description: value(ColumnDescription),
+		ISBN10:      value(ColumnISBN10),
+		ISBN13:      value(ColumnISBN13),
+		Publisher:   value(ColumnPublisher),
+		Authors:     splitList(value(ColumnAuthors)),
+		Categories:  splitList(value(ColumnCategories)),
+	}
+	row.Pages = row.number(ColumnPages, value(ColumnPages))
+	row.Year = row.number(ColumnYear, value(ColumnYear))
+	return row
+}
+
+// number parses the whole number in the field of column, noting an error on the row when it is not one.
+// An empty field is 0, which validation rejects where a number is required.
+func (r *Row) number(column Column, field string) uint {
+	if field == "" {
+		return 0
+	}
+	n, err := strconv.ParseUint(field, 10, 32)
+	if err != nil {
+		r.Errors = append(r.Errors, apperror.FieldError{Field: string(column), Message: fmt.Sprintf("%s must be a whole number", column)})
+		return 0
+	}
+	return uint(n)
+}
+
+// splitList splits a list of names separated by ListSeparator, dropping empty names
+func splitList(field string) []string {
+	names := []string{}
+	for _, name := range strings.Split(field, ListSeparator) {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// isColumn reports whether column is one of Columns
+func isColumn(column Column) bool {
+	for _, c := range Columns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package importer
+
+import "github.com/tedysaputro/book-catalog-with-go/src/apperror"
+
+// RowStatus is the outcome of a row of an import
+type RowStatus string
+
+const (
+	// StatusValid marks a row a dry run would import
+	StatusValid RowStatus = "valid"
+	// StatusImported marks a row whose book was created
+	StatusImported RowStatus = "imported"
+	// StatusFailed marks a row that cannot be imported, see its errors
+	StatusFailed RowStatus = "failed"
+	// StatusSkipped marks a valid row an atomic import left out because another row failed
+	StatusSkipped RowStatus = "skipped"
+)
+
+// RowResult reports the outcome of a row of an import
+type RowResult struct {
+	// Row is the line of the CSV file the row starts on
+	Row    int                   `json:"row"`
+	Status RowStatus             `json:"status"`
+	BookID uint                  `json:"book_id,omitempty"`
+	Errors []apperror.FieldError `json:"errors,omitempty"`
+}
+
+// ImportReport represents the response payload of a book import
+type ImportReport struct {
+	DryRun   bool `json:"dry_run"`
+	Mode     Mode `json:"mode"`
+	Total    int  `json:"total"`
+	Valid    int  `json:"valid"`
+	Imported int  `json:"imported"`
+	Failed   int  `json:"failed"`
+	Skipped  int  `json:"skipped"`
+	// CreatedPublishers and CreatedAuthors name the records the import created,
+	// or would create in a dry run
+	CreatedPublishers []string    `json:"created_publishers"`
+	CreatedAuthors    []string    `json:"created_authors"`
+	Rows              []RowResult `json:"rows"`
+}
+
+// tally counts the rows of the report by status
+func (r *ImportReport) tally() {
+	r.Total = len(r.Rows)
+	r.Valid, r.Imported, r.Failed, r.Skipped = 0, 0, 0, 0
+	for _, row := range r.Rows {
+		switch row.Status {
+		case StatusValid:
+			r.Valid++
+		case StatusImported:
+			r.Imported++
+		case StatusFailed:
+			r.Failed++
+		case StatusSkipped:
+			r.Skipped++
+		}
+	}
+}
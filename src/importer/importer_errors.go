@@ -0,0 +1,54 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrEmptyFile is returned when an import has no CSV content
+	ErrEmptyFile = errors.New("the csv file is empty")
+	// ErrNoRows is returned when a CSV file has a header but no rows
+	ErrNoRows = errors.New("the csv file has no rows below its header")
+	// ErrNoRecords is returned when a MARC file has no records
+	ErrNoRecords = errors.New("the MARC file has no records")
+)
+
+// ModeError describes a mode name that is not supported
+type ModeError struct {
+	Mode string
+}
+
+// Error implements the error interface
+func (e *ModeError) Error() string {
+	return fmt.Sprintf("unknown import mode: %q", e.Mode)
+}
+
+// AllowedValues returns the supported mode names
+func (e *ModeError) AllowedValues() []string {
+	names := make([]string, len(Modes))
+	for i, mode := range Modes {
+		names[i] = string(mode)
+	}
+	return names
+}
+
+// ColumnError describes a column that cannot be mapped or is missing from a CSV file
+type ColumnError struct {
+	Column  string
+	Message string
+}
+
+// Error implements the error interface
+func (e *ColumnError) Error() string {
+	return fmt.Sprintf("column %q: %s", e.Column, e.Message)
+}
+
+// AllowedValues returns the names of the columns
+func (e *ColumnError) AllowedValues() []string {
+	names := make([]string, len(Columns))
+	for i, column := range Columns {
+		names[i] = string(column)
+	}
+	return names
+}
@@ -0,0 +1,99 @@
+package importer
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"path/filepath"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/tedysaputro/book-catalog-with-go/src/marc"
+)
+
+// ImportHandler handles HTTP requests for imports
+type ImportHandler struct {
+	service ImportService
+}
+
+// NewImportHandler creates a new instance of ImportHandler
+func NewImportHandler(service ImportService) *ImportHandler {
+	return &ImportHandler{service: service}
+}
+
+// ImportBooks handles POST /import/books request.
+// The file is the request body, or the file field of a multipart form. It is read as CSV
+// unless its content type is that of MARC 21 or MARCXML.
+func (h *ImportHandler) ImportBooks(c *fiber.Ctx) error {
+	options, err := FromQuery(c)
+	if err != nil {
+		return err
+	}
+
+	file, contentType, err := requestFile(c)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	rows, err := readFile(file, contentType, options)
+	if err != nil {
+		return err
+	}
+
+	report, err := h.service.ImportBooks(c.UserContext(), rows, options)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case options.DryRun:
+		return c.JSON(report)
+	case report.Imported == 0:
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(report)
+	default:
+		return c.Status(fiber.StatusCreated).JSON(report)
+	}
+}
+
+// requestFile opens the file of an import request and returns its content type
+func requestFile(c *fiber.Ctx) (io.ReadCloser, string, error) {
+	contentType := c.Get(fiber.HeaderContentType)
+	if !strings.HasPrefix(contentType, fiber.MIMEMultipartForm) {
+		return io.NopCloser(bytes.NewReader(c.Body())), contentType, nil
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		return nil, "", fiber.NewError(fiber.StatusBadRequest, "Missing file field")
+	}
+	file, err := header.Open()
+	if err != nil {
+		return nil, "", err
+	}
+	switch strings.ToLower(filepath.Ext(header.Filename)) {
+	case ".mrc":
+		return file, marc.ContentType, nil
+	case ".xml":
+		return file, marc.XMLContentType, nil
+	}
+	return file, header.Header.Get(fiber.HeaderContentType), nil
+}
+
+// readFile reads the rows of an import file, choosing the parser by its content type
+func readFile(file io.Reader, contentType string, options Options) ([]Row, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case marc.ContentType:
+		return ReadRecords(file, marc.FormatMARC21)
+	case marc.XMLContentType:
+		return ReadRecords(file, marc.FormatMARCXML)
+	default:
+		return ReadRows(file, options.Columns)
+	}
+}
+
+// RegisterRoutes registers the import routes
+func (h *ImportHandler) RegisterRoutes(app *fiber.App) {
+	app.Post("/api/v1/import/books", h.ImportBooks)
+}
@@ -0,0 +1,44 @@
+package importer
+
+import (
+	"io"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/marc"
+)
+
+// ReadRecords reads the rows of a MARC file in format, one row per record numbered from 1
+func ReadRecords(r io.Reader, format marc.Format) ([]Row, error) {
+	decode := marc.Decode
+	if format == marc.FormatMARCXML {
+		decode = marc.DecodeXML
+	}
+	records, err := decode(r)
+	if err != nil {
+		return nil, apperror.BadRequest("file", err)
+	}
+	if len(records) == 0 {
+		return nil, apperror.BadRequest("file", ErrNoRecords)
+	}
+
+	rows := make([]Row, len(records))
+	for i, record := range records {
+		b := marc.ToBook(record)
+		rows[i] = Row{
+			Line:        i + 1,
+			Title:       b.Title,
+			Description: b.Description,
+			ISBN10:      b.ISBN10,
+			ISBN13:      b.ISBN13,
+			Pages:       b.Pages,
+			Year:        b.Year,
+			Publisher:   b.Publisher.Name,
+			Authors:     []string{},
+			Categories:  []string{},
+		}
+		for _, a := range b.Authors {
+			rows[i].Authors = append(rows[i].Authors, a.Name)
+		}
+	}
+	return rows, nil
+}
@@ -0,0 +1,400 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/author"
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/category"
+	"github.com/tedysaputro/book-catalog-with-go/src/publisher"
+)
+
+// ImportService defines the interface for importing catalog records
+type ImportService interface {
+	ImportBooks(ctx context.Context, rows []Row, options Options) (*ImportReport, error)
+}
+
+type importServiceImpl struct {
+	store Store
+}
+
+// NewImportService creates a new instance of ImportService
+func NewImportService(store Store) ImportService {
+	return &importServiceImpl{store: store}
+}
+
+// names collects the publisher or author names an import creates, once each ignoring case
+type names struct {
+	list []string
+	seen map[string]bool
+}
+
+// add notes name unless it was noted before
+func (n *names) add(name string) {
+	if n.seen == nil {
+		n.seen = map[string]bool{}
+	}
+	if key := strings.ToLower(name); !n.seen[key] {
+		n.seen[key] = true
+		n.list = append(n.list, name)
+	}
+}
+
+// has reports whether name was noted
+func (n *names) has(name string) bool {
+	return n.seen[strings.ToLower(name)]
+}
+
+// pending is what the rows checked so far would add to the catalog
+type pending struct {
+	publishers names
+	authors    names
+	// isbns maps the ISBN-13 of each checked row to its line
+	isbns map[string]int
+}
+
+// ImportBooks creates the books of the rows read from a file. Every row is checked before anything is written,
+// so dry runs and atomic imports report the problems of all rows at once.
+func (s *importServiceImpl) ImportBooks(ctx context.Context, rows []Row, options Options) (*ImportReport, error) {
+	report := &ImportReport{
+		DryRun:            options.DryRun,
+		Mode:              options.Mode,
+		Rows:              make([]RowResult, len(rows)),
+		CreatedPublishers: []string{},
+		CreatedAuthors:    []string{},
+	}
+
+	planned := &pending{isbns: map[string]int{}}
+	for i := range rows {
+		errs, err := s.check(ctx, s.store.Repositories(), &rows[i], options, planned)
+		if err != nil {
+			return nil, err
+		}
+		report.Rows[i] = RowResult{Row: rows[i].Line, Status: StatusValid, Errors: errs}
+		if len(errs) > 0 {
+			report.Rows[i].Status = StatusFailed
+		}
+	}
+	report.tally()
+
+	var err error
+	switch {
+	case options.DryRun:
+		report.CreatedPublishers = append(report.CreatedPublishers, planned.publishers.list...)
+		report.CreatedAuthors = append(report.CreatedAuthors, planned.authors.list...)
+	case options.Mode == ModeAtomic:
+		err = s.importAll(ctx, rows, options, report)
+	default:
+		err = s.importEach(ctx, rows, options, report)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	report.tally()
+	return report, nil
+}
+
+// importAll writes every row in one transaction, or none of them when a row fails
+func (s *importServiceImpl) importAll(ctx context.Context, rows []Row, options Options, report *ImportReport) error {
+	if report.Failed > 0 {
+		skip(report)
+		return nil
+	}
+
+	var publishers, authors names
+	failed := -1
+	err := s.store.Transaction(ctx, func(ctx context.Context, repos Repositories) error {
+		for i := range rows {
+			id, err := s.write(ctx, repos, &rows[i], options, &publishers, &authors)
+			if err != nil {
+				failed = i
+				return err
+			}
+			report.Rows[i].Status, report.Rows[i].BookID = StatusImported, id
+		}
+		return nil
+	})
+	if err == nil {
+		report.CreatedPublishers = append(report.CreatedPublishers, publishers.list...)
+		report.CreatedAuthors = append(report.CreatedAuthors, authors.list...)
+		return nil
+	}
+
+	errs, ok := rowErrors(err)
+	if failed < 0 || !ok {
+		return err
+	}
+	for i := range report.Rows {
+		report.Rows[i].BookID = 0
+	}
+	report.Rows[failed].Status, report.Rows[failed].Errors = StatusFailed, errs
+	skip(report)
+	return nil
+}
+
+// importEach writes each valid row in its own transaction, reporting the rows that fail
+func (s *importServiceImpl) importEach(ctx context.Context, rows []Row, options Options, report *ImportReport) error {
+	for i := range rows {
+		if report.Rows[i].Status == StatusFailed {
+			continue
+		}
+
+		var publishers, authors names
+		var id uint
+		err := s.store.Transaction(ctx, func(ctx context.Context, repos Repositories) error {
+			var err error
+			id, err = s.write(ctx, repos, &rows[i], options, &publishers, &authors)
+			return err
+		})
+		if err != nil {
+			errs, ok := rowErrors(err)
+			if !ok {
+				return err
+			}
+			report.Rows[i].Status, report.Rows[i].Errors = StatusFailed, errs
+			continue
+		}
+
+		report.Rows[i].Status, report.Rows[i].BookID = StatusImported, id
+		report.CreatedPublishers = append(report.CreatedPublishers, publishers.list...)
+		report.CreatedAuthors = append(report.CreatedAuthors, authors.list...)
+	}
+	return nil
+}
+
+// skip marks the rows of a failed atomic import that did not fail themselves as skipped
+func skip(report *ImportReport) {
+	for i := range report.Rows {
+		if report.Rows[i].Status != StatusFailed {
+			report.Rows[i].Status = StatusSkipped
+		}
+	}
+}
+
+// check validates a row against the catalog without writing, taking into account
+// what the rows checked before it would add, and notes what the row would add in turn
+func (s *importServiceImpl) check(ctx context.Context, repos Repositories, row *Row, options Options, planned *pending) ([]apperror.FieldError, error) {
+	if slices.ContainsFunc(row.Errors, func(e apperror.FieldError) bool { return e.Field == RowField }) {
+		return row.Errors, nil
+	}
+	errs := append([]apperror.FieldError{}, row.Errors...)
+
+	var newPublisher string
+	if row.Publisher != "" {
+		_, err := repos.Publishers.FindByName(ctx, row.Publisher)
+		switch {
+		case err == nil:
+		case !errors.Is(err, publisher.ErrPublisherNotFound):
+			return nil, err
+		case options.CreateMissing || planned.publishers.has(row.Publisher):
+			newPublisher = row.Publisher
+		default:
+			errs = append(errs, notFound(ColumnPublisher, "publisher", row.Publisher))
+		}
+	}
+
+	var newAuthors []string
+	for _, name := range row.Authors {
+		if _, err := repos.Authors.FindByName(ctx, name); err != nil {
+			switch {
+			case !errors.Is(err, author.ErrAuthorNotFound):
+				return nil, err
+			case options.CreateMissing || planned.authors.has(name):
+				newAuthors = append(newAuthors, name)
+			default:
+				errs = append(errs, notFound(ColumnAuthors, "author", name))
+			}
+		}
+	}
+
+	for _, code := range row.Categories {
+		if _, err := repos.Categories.FindByCode(ctx, code); err != nil {
+			if !errors.Is(err, category.ErrCategoryNotFound) {
+				return nil, err
+			}
+			errs = append(errs, notFound(ColumnCategories, "category", code))
+		}
+	}
+
+	candidate := book.Book{
+		Title:       row.Title,
+		Description: row.Description,
+		ISBN10:      row.ISBN10,
+		ISBN13:      row.ISBN13,
+		Pages:       row.Pages,
+		Year:        row.Year,
+	}
+	if row.Publisher != "" {
+		// Validate only needs to know a publisher is named, the lookup above checked it exists
+		candidate.PublisherID = 1
+	}
+	if err := candidate.Validate(); err != nil {
+		invalid, ok := rowErrors(err)
+		if !ok {
+			return nil, err
+		}
+		// A field that could not be read is already reported, e.g. pages that are not a number
+		for _, e := range invalid {
+			if !slices.ContainsFunc(row.Errors, func(read apperror.FieldError) bool { return read.Field == e.Field }) {
+				errs = append(errs, e)
+			}
+		}
+	} else if candidate.ISBN13 != "" {
+		if line, ok := planned.isbns[candidate.ISBN13]; ok {
+			errs = append(errs, apperror.FieldError{Field: string(ColumnISBN13), Message: fmt.Sprintf("the isbn is also used on line %d", line)})
+		} else {
+			taken, err := repos.Books.ExistsByISBN(ctx, candidate.ISBN13, 0)
+			if err != nil {
+				return nil, err
+			}
+			if taken {
+				errs = append(errs, apperror.FieldError{Field: string(ColumnISBN13), Message: book.ErrISBNTaken.Message})
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs, nil
+	}
+	if newPublisher != "" {
+		planned.publishers.add(newPublisher)
+	}
+	for _, name := range newAuthors {
+		planned.authors.add(name)
+	}
+	if candidate.ISBN13 != "" {
+		planned.isbns[candidate.ISBN13] = row.Line
+	}
+	return nil, nil
+}
+
+// write creates the book of a row, first creating its missing publisher and authors,
+// and notes the names it created
+func (s *importServiceImpl) write(ctx context.Context, repos Repositories, row *Row, options Options, publishers *names, authors *names) (uint, error) {
+	publisherID, err := s.publisher(ctx, repos, row.Publisher, options, publishers)
+	if err != nil {
+		return 0, err
+	}
+
+	authorIDs := make([]uint, len(row.Authors))
+	for i, name := range row.Authors {
+		if authorIDs[i], err = s.author(ctx, repos, name, options, authors); err != nil {
+			return 0, err
+		}
+	}
+
+	categoryIDs := make([]uint, len(row.Categories))
+	for i, code := range row.Categories {
+		found, err := repos.Categories.FindByCode(ctx, code)
+		if err != nil {
+			if errors.Is(err, category.ErrCategoryNotFound) {
+				return 0, apperror.Validation(string(ColumnCategories), fmt.Sprintf("category %q not found", code))
+			}
+			return 0, err
+		}
+		categoryIDs[i] = found.ID
+	}
+
+	books := book.NewBookService(repos.Books, repos.Authors, repos.Publishers, repos.Categories, repos.History)
+	created, err := books.CreateBook(ctx, book.BookRequest{
+		Title:       row.Title,
+		Description: row.Description,
+		ISBN10:      row.ISBN10,
+		ISBN13:      row.ISBN13,
+		Pages:       row.Pages,
+		Year:        row.Year,
+		PublisherID: publisherID,
+		AuthorIDs:   authorIDs,
+		CategoryIDs: categoryIDs,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return created.ID, nil
+}
+
+// publisher returns the ID of the live publisher named name, creating it when options allow
+func (s *importServiceImpl) publisher(ctx context.Context, repos Repositories, name string, options Options, created *names) (uint, error) {
+	found, err := repos.Publishers.FindByName(ctx, name)
+	if err == nil {
+		return found.ID, nil
+	}
+	if !errors.Is(err, publisher.ErrPublisherNotFound) {
+		return 0, err
+	}
+	if !options.CreateMissing {
+		return 0, apperror.Validation(string(ColumnPublisher), fmt.Sprintf("publisher %q not found", name))
+	}
+
+	publishers := publisher.NewPublisherService(repos.Publishers, book.NewPublisherDependents(repos.Books, repos.History), repos.History)
+	record, err := publishers.CreatePublisher(ctx, publisher.PublisherRequest{Name: name})
+	if err != nil {
+		return 0, err
+	}
+	created.add(name)
+	return record.ID, nil
+}
+
+// author returns the ID of the live author named name, creating it when options allow
+func (s *importServiceImpl) author(ctx context.Context, repos Repositories, name string, options Options, created *names) (uint, error) {
+	found, err := repos.Authors.FindByName(ctx, name)
+	if err == nil {
+		return found.ID, nil
+	}
+	if !errors.Is(err, author.ErrAuthorNotFound) {
+		return 0, err
+	}
+	if !options.CreateMissing {
+		return 0, apperror.Validation(string(ColumnAuthors), fmt.Sprintf("author %q not found", name))
+	}
+
+	authors := author.NewAuthorService(repos.Authors, book.NewAuthorDependents(repos.Books, repos.History), repos.History)
+	record, err := authors.CreateAuthor(ctx, author.AuthorRequest{Name: name})
+	if err != nil {
+		return 0, err
+	}
+	created.add(name)
+	return record.ID, nil
+}
+
+// notFound reports a name in column that no live record has
+func notFound(column Column, kind string, name string) apperror.FieldError {
+	return apperror.FieldError{Field: string(column), Message: fmt.Sprintf("%s %q not found", kind, name)}
+}
+
+// fieldColumns maps the request fields named by book errors to the columns they are read from
+var fieldColumns = map[string]Column{
+	"publisher_id": ColumnPublisher,
+	"author_ids":   ColumnAuthors,
+	"category_ids": ColumnCategories,
+}
+
+// rowErrors lists the field errors of a domain error under the columns of the CSV file,
+// reporting false for other errors, which abort the import
+func rowErrors(err error) ([]apperror.FieldError, bool) {
+	var domain *apperror.Error
+	if !errors.As(err, &domain) {
+		return nil, false
+	}
+	if errors.Is(err, book.ErrISBNTaken) {
+		return []apperror.FieldError{{Field: string(ColumnISBN13), Message: domain.Message}}, true
+	}
+	if len(domain.Fields) == 0 {
+		return []apperror.FieldError{{Field: RowField, Message: domain.Message}}, true
+	}
+
+	errs := make([]apperror.FieldError, len(domain.Fields))
+	for i, field := range domain.Fields {
+		if column, ok := fieldColumns[field.Field]; ok {
+			field.Field = string(column)
+		}
+		errs[i] = field
+	}
+	return errs, true
+}
@@ -0,0 +1,58 @@
+package importer
+
+import (
+	"context"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/author"
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/category"
+	"github.com/tedysaputro/book-catalog-with-go/src/history"
+	"github.com/tedysaputro/book-catalog-with-go/src/publisher"
+	"github.com/tedysaputro/book-catalog-with-go/src/transaction"
+	"gorm.io/gorm"
+)
+
+// Repositories are the records an import reads and writes
+type Repositories struct {
+	Books      book.BookRepository
+	Authors    author.AuthorRepository
+	Publishers publisher.PublisherRepository
+	Categories category.CategoryRepository
+	History    history.HistoryService
+}
+
+// Store provides the Repositories of an import
+type Store interface {
+	// Repositories returns Repositories outside of any transaction, for checks
+	Repositories() Repositories
+	// Transaction runs fn with Repositories whose writes made with the context passed to fn
+	// are kept only if fn returns nil
+	Transaction(ctx context.Context, fn func(ctx context.Context, repos Repositories) error) error
+}
+
+type gormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore creates a Store backed by GORM
+func NewGormStore(db *gorm.DB) Store {
+	return &gormStore{db: db}
+}
+
+// Repositories returns Repositories on the database
+func (s *gormStore) Repositories() Repositories {
+	return Repositories{
+		Books:      book.NewGormBookRepository(s.db),
+		Authors:    author.NewGormAuthorRepository(s.db),
+		Publishers: publisher.NewGormPublisherRepository(s.db),
+		Categories: category.NewGormCategoryRepository(s.db),
+		History:    history.NewHistoryService(history.NewGormHistoryRepository(s.db)),
+	}
+}
+
+// Transaction runs fn with Repositories on a database transaction carried by its context
+func (s *gormStore) Transaction(ctx context.Context, fn func(ctx context.Context, repos Repositories) error) error {
+	return transaction.Run(ctx, s.db, func(ctx context.Context) error {
+		return fn(ctx, s.Repositories())
+	})
+}
@@ -0,0 +1,23 @@
+package importer
+
+import "context"
+
+type memoryStore struct {
+	repos Repositories
+}
+
+// NewMemoryStore creates a Store over in-memory repositories for tests.
+// Its transactions do not roll back, so a failed atomic import keeps the rows written before the failure.
+func NewMemoryStore(repos Repositories) Store {
+	return &memoryStore{repos: repos}
+}
+
+// Repositories returns the repositories of the store
+func (s *memoryStore) Repositories() Repositories {
+	return s.repos
+}
+
+// Transaction runs fn with the repositories of the store
+func (s *memoryStore) Transaction(ctx context.Context, fn func(ctx context.Context, repos Repositories) error) error {
+	return fn(ctx, s.repos)
+}
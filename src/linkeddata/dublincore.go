@@ -0,0 +1,85 @@
+package linkeddata
+
+import (
+	"encoding/xml"
+	"strconv"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+)
+
+const (
+	// RDFNamespace is the XML namespace of RDF
+	RDFNamespace = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	// DCNamespace is the XML namespace of the Dublin Core elements
+	DCNamespace = "http://purl.org/dc/elements/1.1/"
+)
+
+// DublinCore holds the Dublin Core elements describing a book, written with the dc prefix
+type DublinCore struct {
+	Title       []string `xml:"dc:title"`
+	Creator     []string `xml:"dc:creator"`
+	Subject     []string `xml:"dc:subject"`
+	Description []string `xml:"dc:description"`
+	Publisher   []string `xml:"dc:publisher"`
+	Date        []string `xml:"dc:date"`
+	Type        []string `xml:"dc:type"`
+	Format      []string `xml:"dc:format"`
+	Identifier  []string `xml:"dc:identifier"`
+}
+
+// RDF is an RDF/XML document describing a book with Dublin Core
+type RDF struct {
+	XMLName     xml.Name       `xml:"rdf:RDF"`
+	RDF         string         `xml:"xmlns:rdf,attr"`
+	DC          string         `xml:"xmlns:dc,attr"`
+	Description RDFDescription `xml:"rdf:Description"`
+}
+
+// RDFDescription is the description of the resource at About
+type RDFDescription struct {
+	About string `xml:"rdf:about,attr"`
+	DublinCore
+}
+
+// DublinCoreOf maps a book with its publisher, authors and categories to Dublin Core elements
+func DublinCoreOf(b *book.Book, base string) DublinCore {
+	dc := DublinCore{
+		Title:      []string{b.Title},
+		Type:       []string{"Text"},
+		Identifier: []string{BookURL(base, b.ID)},
+	}
+	for _, a := range b.Authors {
+		dc.Creator = append(dc.Creator, a.Name)
+	}
+	for _, c := range b.Categories {
+		dc.Subject = append(dc.Subject, c.Name)
+	}
+	if b.Description != "" {
+		dc.Description = []string{b.Description}
+	}
+	if b.Publisher.Name != "" {
+		dc.Publisher = []string{b.Publisher.Name}
+	}
+	if b.Year > 0 {
+		dc.Date = []string{strconv.FormatUint(uint64(b.Year), 10)}
+	}
+	if b.Pages > 0 {
+		dc.Format = []string{strconv.FormatUint(uint64(b.Pages), 10) + " pages"}
+	}
+	if b.ISBN13 != "" {
+		dc.Identifier = append(dc.Identifier, "urn:isbn:"+b.ISBN13)
+	}
+	if b.ISBN10 != "" {
+		dc.Identifier = append(dc.Identifier, "urn:isbn:"+b.ISBN10)
+	}
+	return dc
+}
+
+// RDFOf describes a book in RDF/XML with Dublin Core
+func RDFOf(b *book.Book, base string) *RDF {
+	return &RDF{
+		RDF:         RDFNamespace,
+		DC:          DCNamespace,
+		Description: RDFDescription{About: BookURL(base, b.ID), DublinCore: DublinCoreOf(b, base)},
+	}
+}
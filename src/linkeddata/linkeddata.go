@@ -0,0 +1,157 @@
+package linkeddata
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/author"
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/publisher"
+)
+
+const (
+	// JSONLDType is the media type of JSON-LD documents
+	JSONLDType = "application/ld+json"
+	// RDFXMLType is the media type of RDF/XML documents
+	RDFXMLType = "application/rdf+xml"
+	// SchemaContext is the JSON-LD context of schema.org
+	SchemaContext = "https://schema.org"
+)
+
+// BookDocument is a schema.org Book
+type BookDocument struct {
+	Context       string        `json:"@context,omitempty"`
+	ID            string        `json:"@id"`
+	Type          string        `json:"@type"`
+	Name          string        `json:"name"`
+	Description   string        `json:"description,omitempty"`
+	ISBN          string        `json:"isbn,omitempty"`
+	NumberOfPages uint          `json:"numberOfPages,omitempty"`
+	DatePublished string        `json:"datePublished,omitempty"`
+	Genre         []string      `json:"genre,omitempty"`
+	Author        []Person      `json:"author,omitempty"`
+	Publisher     *Organization `json:"publisher,omitempty"`
+	DateModified  string        `json:"dateModified,omitempty"`
+}
+
+// Person is a schema.org Person, the JSON-LD document of an author
+type Person struct {
+	Context     string    `json:"@context,omitempty"`
+	ID          string    `json:"@id"`
+	Type        string    `json:"@type"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Reverse     *Backlink `json:"@reverse,omitempty"`
+}
+
+// Organization is a schema.org Organization, the JSON-LD document of a publisher
+type Organization struct {
+	Context     string    `json:"@context,omitempty"`
+	ID          string    `json:"@id"`
+	Type        string    `json:"@type"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Reverse     *Backlink `json:"@reverse,omitempty"`
+}
+
+// Backlink lists the books that name an author or publisher, as the reverse of their properties
+type Backlink struct {
+	Author    []BookLink `json:"author,omitempty"`
+	Publisher []BookLink `json:"publisher,omitempty"`
+}
+
+// BookLink is a reference to a Book from another document
+type BookLink struct {
+	ID   string `json:"@id"`
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+// BookURL returns the URL of a book under base, the URL of the API such as "https://host/api/v1"
+func BookURL(base string, id uint) string {
+	return fmt.Sprintf("%s/books/%d", base, id)
+}
+
+// AuthorURL returns the URL of an author under base
+func AuthorURL(base string, id uint) string {
+	return fmt.Sprintf("%s/authors/%d", base, id)
+}
+
+// PublisherURL returns the URL of a publisher under base
+func PublisherURL(base string, id uint) string {
+	return fmt.Sprintf("%s/publishers/%d", base, id)
+}
+
+// FromBook maps a book with its publisher, authors and categories to a schema.org Book
+func FromBook(b *book.Book, base string) *BookDocument {
+	doc := &BookDocument{
+		Context:       SchemaContext,
+		ID:            BookURL(base, b.ID),
+		Type:          "Book",
+		Name:          b.Title,
+		Description:   b.Description,
+		ISBN:          isbn(b),
+		NumberOfPages: b.Pages,
+		DateModified:  b.UpdatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+	}
+	if b.Year > 0 {
+		doc.DatePublished = strconv.FormatUint(uint64(b.Year), 10)
+	}
+	for _, c := range b.Categories {
+		doc.Genre = append(doc.Genre, c.Name)
+	}
+	for _, a := range b.Authors {
+		doc.Author = append(doc.Author, Person{ID: AuthorURL(base, a.ID), Type: "Person", Name: a.Name})
+	}
+	if b.Publisher.ID != 0 {
+		doc.Publisher = &Organization{ID: PublisherURL(base, b.Publisher.ID), Type: "Organization", Name: b.Publisher.Name}
+	}
+	return doc
+}
+
+// FromAuthor maps an author to a schema.org Person linked back from the books naming them
+func FromAuthor(a *author.Author, books []book.Book, base string) *Person {
+	person := &Person{
+		Context:     SchemaContext,
+		ID:          AuthorURL(base, a.ID),
+		Type:        "Person",
+		Name:        a.Name,
+		Description: a.Description,
+	}
+	if len(books) > 0 {
+		person.Reverse = &Backlink{Author: bookLinks(books, base)}
+	}
+	return person
+}
+
+// FromPublisher maps a publisher to a schema.org Organization linked back from its books
+func FromPublisher(p *publisher.Publisher, books []book.Book, base string) *Organization {
+	organization := &Organization{
+		Context:     SchemaContext,
+		ID:          PublisherURL(base, p.ID),
+		Type:        "Organization",
+		Name:        p.Name,
+		Description: p.Description,
+	}
+	if len(books) > 0 {
+		organization.Reverse = &Backlink{Publisher: bookLinks(books, base)}
+	}
+	return organization
+}
+
+// bookLinks references each of books
+func bookLinks(books []book.Book, base string) []BookLink {
+	links := make([]BookLink, len(books))
+	for i, b := range books {
+		links[i] = BookLink{ID: BookURL(base, b.ID), Type: "Book", Name: b.Title}
+	}
+	return links
+}
+
+// isbn returns the ISBN-13 of b, or its ISBN-10 when it has none
+func isbn(b *book.Book) string {
+	if b.ISBN13 != "" {
+		return b.ISBN13
+	}
+	return b.ISBN10
+}
@@ -0,0 +1,119 @@
+package linkeddata
+
+import (
+	"encoding/json"
+	"encoding/xml"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LinkedDataHandler handles the requests for linked data representations of catalog records.
+// Its routes share the paths of the JSON ones and pass every other request on to them.
+type LinkedDataHandler struct {
+	service LinkedDataService
+}
+
+// NewLinkedDataHandler creates a new instance of LinkedDataHandler
+func NewLinkedDataHandler(service LinkedDataService) *LinkedDataHandler {
+	return &LinkedDataHandler{service: service}
+}
+
+// GetBook handles GET /books/:id request accepting JSON-LD or RDF/XML
+func (h *LinkedDataHandler) GetBook(c *fiber.Ctx) error {
+	id, mediaType := negotiate(c, JSONLDType, RDFXMLType)
+	switch mediaType {
+	case JSONLDType:
+		doc, err := h.service.GetBook(c.UserContext(), id, baseURL(c))
+		if err != nil {
+			return err
+		}
+		return sendJSONLD(c, doc)
+	case RDFXMLType:
+		doc, err := h.service.GetBookRDF(c.UserContext(), id, baseURL(c))
+		if err != nil {
+			return err
+		}
+		return sendRDF(c, doc)
+	default:
+		return c.Next()
+	}
+}
+
+// GetAuthor handles GET /authors/:id request accepting JSON-LD
+func (h *LinkedDataHandler) GetAuthor(c *fiber.Ctx) error {
+	id, mediaType := negotiate(c, JSONLDType)
+	if mediaType != JSONLDType {
+		return c.Next()
+	}
+
+	doc, err := h.service.GetAuthor(c.UserContext(), id, baseURL(c))
+	if err != nil {
+		return err
+	}
+	return sendJSONLD(c, doc)
+}
+
+// GetPublisher handles GET /publishers/:id request accepting JSON-LD
+func (h *LinkedDataHandler) GetPublisher(c *fiber.Ctx) error {
+	id, mediaType := negotiate(c, JSONLDType)
+	if mediaType != JSONLDType {
+		return c.Next()
+	}
+
+	doc, err := h.service.GetPublisher(c.UserContext(), id, baseURL(c))
+	if err != nil {
+		return err
+	}
+	return sendJSONLD(c, doc)
+}
+
+// negotiate returns the ID of the requested record and the media type among offers the request
+// prefers to JSON. It returns no media type for paths that are not record IDs, such as /trash,
+// and for reads of past states with as_of, which only JSON supports.
+func negotiate(c *fiber.Ctx, offers ...string) (uint, string) {
+	c.Vary(fiber.HeaderAccept)
+	id, err := c.ParamsInt("id")
+	if err != nil || id < 0 || c.Query("as_of") != "" {
+		return 0, ""
+	}
+
+	mediaType := c.Accepts(append([]string{fiber.MIMEApplicationJSON}, offers...)...)
+	if mediaType == fiber.MIMEApplicationJSON {
+		return 0, ""
+	}
+	return uint(id), mediaType
+}
+
+// baseURL returns the URL of the API the request was sent to
+func baseURL(c *fiber.Ctx) string {
+	return c.BaseURL() + "/api/v1"
+}
+
+// sendJSONLD writes doc as JSON-LD
+func sendJSONLD(c *fiber.Ctx, doc interface{}) error {
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	c.Set(fiber.HeaderContentType, JSONLDType)
+	return c.Send(body)
+}
+
+// sendRDF writes doc as RDF/XML
+func sendRDF(c *fiber.Ctx, doc *RDF) error {
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	c.Set(fiber.HeaderContentType, RDFXMLType+"; charset=utf-8")
+	return c.Send(append([]byte(xml.Header), body...))
+}
+
+// RegisterRoutes registers the linked data routes.
+// They must be registered before the routes of books, authors and publishers, which they fall through to.
+func (h *LinkedDataHandler) RegisterRoutes(app *fiber.App) {
+	v1 := app.Group("/api/v1")
+	v1.Get("/books/:id", h.GetBook)
+	v1.Get("/authors/:id", h.GetAuthor)
+	v1.Get("/publishers/:id", h.GetPublisher)
+}
@@ -0,0 +1,73 @@
+package linkeddata
+
+import (
+	"context"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/author"
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/publisher"
+)
+
+// LinkedDataService defines the interface for the linked data documents of catalog records.
+// Documents identify records by their URL under base, the URL of the API.
+type LinkedDataService interface {
+	GetBook(ctx context.Context, id uint, base string) (*BookDocument, error)
+	GetBookRDF(ctx context.Context, id uint, base string) (*RDF, error)
+	GetAuthor(ctx context.Context, id uint, base string) (*Person, error)
+	GetPublisher(ctx context.Context, id uint, base string) (*Organization, error)
+}
+
+type linkedDataServiceImpl struct {
+	books      book.BookRepository
+	authors    author.AuthorRepository
+	publishers publisher.PublisherRepository
+}
+
+// NewLinkedDataService creates a new instance of LinkedDataService
+func NewLinkedDataService(books book.BookRepository, authors author.AuthorRepository, publishers publisher.PublisherRepository) LinkedDataService {
+	return &linkedDataServiceImpl{books: books, authors: authors, publishers: publishers}
+}
+
+// GetBook retrieves a book as a schema.org Book
+func (s *linkedDataServiceImpl) GetBook(ctx context.Context, id uint, base string) (*BookDocument, error) {
+	b, err := s.books.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return FromBook(b, base), nil
+}
+
+// GetBookRDF retrieves a book as RDF/XML with Dublin Core
+func (s *linkedDataServiceImpl) GetBookRDF(ctx context.Context, id uint, base string) (*RDF, error) {
+	b, err := s.books.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return RDFOf(b, base), nil
+}
+
+// GetAuthor retrieves an author as a schema.org Person with the books naming them
+func (s *linkedDataServiceImpl) GetAuthor(ctx context.Context, id uint, base string) (*Person, error) {
+	a, err := s.authors.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	books, err := s.books.FindAllByAuthor(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return FromAuthor(a, books, base), nil
+}
+
+// GetPublisher retrieves a publisher as a schema.org Organization with its books
+func (s *linkedDataServiceImpl) GetPublisher(ctx context.Context, id uint, base string) (*Organization, error) {
+	p, err := s.publishers.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	books, err := s.books.FindAllByPublisher(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return FromPublisher(p, books, base), nil
+}
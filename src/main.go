@@ -1,22 +1,126 @@
 package main
 
 import (
+	"context"
 	"log"
+	"net"
+	"os"
+	"strconv"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/deletion"
+	"github.com/tedysaputro/book-catalog-with-go/src/fuzzy"
+	"github.com/tedysaputro/book-catalog-with-go/src/graph"
+	"github.com/tedysaputro/book-catalog-with-go/src/middleware"
+	"github.com/tedysaputro/book-catalog-with-go/src/oai"
+	"github.com/tedysaputro/book-catalog-with-go/src/rpc"
+	"github.com/tedysaputro/book-catalog-with-go/src/trash"
 )
 
 func main() {
+	// Run database migrations instead of the server
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(os.Args[2:])
+		return
+	}
+
 	// Initialize database
-	InitDB()
+	db := InitDB()
+
+	// Configure the default similarity threshold of fuzzy filters
+	threshold, err := strconv.ParseFloat(getEnvOrDefault("FUZZY_THRESHOLD", "0.3"), 64)
+	if err == nil {
+		err = fuzzy.Options{Enabled: true, Threshold: threshold}.Validate()
+	}
+	if err != nil {
+		log.Fatal("Invalid FUZZY_THRESHOLD:", err)
+	}
+	fuzzy.DefaultThreshold = threshold
+
+	// Configure what happens to the books of deleted publishers and authors by default
+	deletePolicy, err := deletion.ParsePolicy(getEnvOrDefault("DELETE_POLICY", string(deletion.PolicyRestrict)))
+	if err != nil || deletePolicy == deletion.PolicyReassign {
+		log.Fatal("Invalid DELETE_POLICY:", os.Getenv("DELETE_POLICY"))
+	}
+	deletion.DefaultPolicy = deletePolicy
+
+	// Describe the repository harvested over OAI-PMH
+	oai.DefaultRepository.AdminEmail = getEnvOrDefault("OAI_ADMIN_EMAIL", oai.DefaultRepository.AdminEmail)
+	oai.DefaultRepository.Identifier = getEnvOrDefault("OAI_REPOSITORY_IDENTIFIER", oai.DefaultRepository.Identifier)
+
+	// Bound the depth and complexity of GraphQL queries
+	maxDepth, err := strconv.Atoi(getEnvOrDefault("GRAPHQL_MAX_DEPTH", strconv.Itoa(graph.DefaultLimits.MaxDepth)))
+	if err != nil || maxDepth < 1 {
+		log.Fatal("Invalid GRAPHQL_MAX_DEPTH:", os.Getenv("GRAPHQL_MAX_DEPTH"))
+	}
+	maxComplexity, err := strconv.Atoi(getEnvOrDefault("GRAPHQL_MAX_COMPLEXITY", strconv.Itoa(graph.DefaultLimits.MaxComplexity)))
+	if err != nil || maxComplexity < 1 {
+		log.Fatal("Invalid GRAPHQL_MAX_COMPLEXITY:", os.Getenv("GRAPHQL_MAX_COMPLEXITY"))
+	}
+	graph.DefaultLimits = graph.Limits{MaxDepth: maxDepth, MaxComplexity: maxComplexity}
+
+	// Configure the database deadline of each request
+	requestTimeout, err := time.ParseDuration(getEnvOrDefault("REQUEST_TIMEOUT", "5s"))
+	if err != nil {
+		log.Fatal("Invalid REQUEST_TIMEOUT:", err)
+	}
+	routeTimeouts, err := middleware.ParseRouteTimeouts(os.Getenv("ROUTE_TIMEOUTS"))
+	if err != nil {
+		log.Fatal("Invalid ROUTE_TIMEOUTS:", err)
+	}
+
+	services := NewServices(db)
+
+	// Purge records that stay in the trash longer than TRASH_RETENTION_DAYS, 0 keeps them forever
+	retentionDays, err := strconv.Atoi(getEnvOrDefault("TRASH_RETENTION_DAYS", "30"))
+	if err != nil || retentionDays < 0 {
+		log.Fatal("Invalid TRASH_RETENTION_DAYS:", os.Getenv("TRASH_RETENTION_DAYS"))
+	}
+	if retentionDays > 0 {
+		retention := trash.Retention{
+			Period:   time.Duration(retentionDays) * 24 * time.Hour,
+			Interval: time.Hour,
+			// Books go first so they no longer hold on to the records they refer to
+			Purgers: []trash.Purger{
+				// The services record the purges and the change of the books losing their links
+				services.Books,
+				services.Authors,
+				services.Categories,
+				services.Publishers,
+			},
+		}
+		go retention.Run(context.Background())
+	}
 
 	// Create Fiber app
 	app := fiber.New(fiber.Config{
-		AppName: "Book Catalog API",
+		AppName:      "Book Catalog API",
+		ErrorHandler: apperror.Handler,
 	})
 
+	// Cancel database queries of requests that run past their timeout
+	app.Use(middleware.Timeout(middleware.TimeoutConfig{
+		Default: requestTimeout,
+		Routes:  routeTimeouts,
+	}))
+
+	// Record changes under the actor named by the X-Actor header
+	app.Use(middleware.Actor())
+
 	// Setup routes
-	SetupRoutes(app)
+	SetupRoutes(app, db, services, middleware.Admin(os.Getenv("ADMIN_TOKEN")))
+
+	// Serve the gRPC API on its own port, on the same services as the HTTP API
+	grpcListener, err := net.Listen("tcp", ":"+getEnvOrDefault("GRPC_PORT", "50051"))
+	if err != nil {
+		log.Fatal("Invalid GRPC_PORT:", err)
+	}
+	grpcServer := rpc.NewServer(rpc.Config{Timeout: requestTimeout}, services.Books, services.Authors, services.Publishers, services.Categories)
+	go func() {
+		log.Fatal(grpcServer.Serve(grpcListener))
+	}()
 
 	// Start server
 	log.Fatal(app.Listen(":8080"))
@@ -0,0 +1,133 @@
+package marc
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/author"
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+)
+
+var (
+	isbnPattern   = regexp.MustCompile(`[0-9][0-9-]{8,16}[0-9Xx]`)
+	numberPattern = regexp.MustCompile(`[0-9]+`)
+)
+
+// FromBook maps a book with its publisher and authors to a MARC 21 bibliographic record
+func FromBook(b *book.Book) Record {
+	record := Record{
+		Leader: DefaultLeader,
+		ControlFields: []ControlField{
+			{Tag: "001", Value: strconv.FormatUint(uint64(b.ID), 10)},
+			{Tag: "005", Value: b.UpdatedAt.UTC().Format("20060102150405.0")},
+		},
+	}
+	add := func(tag, ind1, ind2 string, subfields ...Subfield) {
+		record.DataFields = append(record.DataFields, DataField{Tag: tag, Ind1: ind1, Ind2: ind2, Subfields: subfields})
+	}
+
+	for _, isbn := range []string{b.ISBN13, b.ISBN10} {
+		if isbn != "" {
+			add("020", " ", " ", Subfield{Code: "a", Value: isbn})
+		}
+	}
+	for i, a := range b.Authors {
+		tag := "700"
+		if i == 0 {
+			tag = "100"
+		}
+		add(tag, "0", " ", Subfield{Code: "a", Value: a.Name}, Subfield{Code: "e", Value: "author"})
+	}
+	titleAdded := "0"
+	if len(b.Authors) > 0 {
+		titleAdded = "1"
+	}
+	add("245", titleAdded, "0", Subfield{Code: "a", Value: b.Title})
+	publication := []Subfield{{Code: "b", Value: b.Publisher.Name}}
+	if b.Year > 0 {
+		publication = append(publication, Subfield{Code: "c", Value: strconv.FormatUint(uint64(b.Year), 10)})
+	}
+	add("264", " ", "1", publication...)
+	if b.Pages > 0 {
+		add("300", " ", " ", Subfield{Code: "a", Value: fmt.Sprintf("%d pages", b.Pages)})
+	}
+	if b.Description != "" {
+		add("520", " ", " ", Subfield{Code: "a", Value: b.Description})
+	}
+
+	sort.SliceStable(record.DataFields, func(i, j int) bool {
+		return record.DataFields[i].Tag < record.DataFields[j].Tag
+	})
+	return record
+}
+
+// ToBook maps a MARC 21 bibliographic record to a book.
+// The publisher and authors are only named; linking them to catalog records is left to the caller.
+func ToBook(record Record) *book.Book {
+	b := &book.Book{}
+	var main, added []author.Author
+	var publisher260 DataField
+
+	for _, field := range record.DataFields {
+		switch field.Tag {
+		case "020":
+			isbn := strings.ToUpper(strings.ReplaceAll(isbnPattern.FindString(field.Subfield("a")), "-", ""))
+			switch {
+			case len(isbn) == 13 && b.ISBN13 == "":
+				b.ISBN13 = isbn
+			case len(isbn) == 10 && b.ISBN10 == "":
+				b.ISBN10 = isbn
+			}
+		case "100", "700":
+			if name := trimPunctuation(field.Subfield("a")); name != "" {
+				if field.Tag == "100" {
+					main = append(main, author.Author{Name: name})
+				} else {
+					added = append(added, author.Author{Name: name})
+				}
+			}
+		case "245":
+			b.Title = trimPunctuation(field.Subfield("a"))
+			if remainder := trimPunctuation(field.Subfield("b")); remainder != "" {
+				b.Title += ": " + remainder
+			}
+		case "260":
+			publisher260 = field
+		case "264":
+			if field.Ind2 == "1" && b.Publisher.Name == "" {
+				b.Publisher.Name = trimPunctuation(field.Subfield("b"))
+				b.Year = firstNumber(field.Subfield("c"))
+			}
+		case "300":
+			b.Pages = firstNumber(field.Subfield("a"))
+		case "520":
+			if b.Description == "" {
+				b.Description = strings.TrimSpace(field.Subfield("a"))
+			}
+		}
+	}
+
+	if b.Publisher.Name == "" && publisher260.Tag != "" {
+		b.Publisher.Name = trimPunctuation(publisher260.Subfield("b"))
+		b.Year = firstNumber(publisher260.Subfield("c"))
+	}
+	b.Authors = append(main, added...)
+	return b
+}
+
+// trimPunctuation strips the ISBD punctuation that ends a subfield
+func trimPunctuation(value string) string {
+	return strings.TrimSpace(strings.TrimRight(strings.TrimSpace(value), " ,:;/="))
+}
+
+// firstNumber returns the first number in value, or 0 when it has none
+func firstNumber(value string) uint {
+	n, err := strconv.ParseUint(numberPattern.FindString(value), 10, 32)
+	if err != nil {
+		return 0
+	}
+	return uint(n)
+}
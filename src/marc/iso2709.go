@@ -0,0 +1,192 @@
+package marc
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+const (
+	leaderLength     = 24
+	entryLength      = 12
+	fieldTerminator  = 0x1E
+	recordTerminator = 0x1D
+	subfieldMark     = 0x1F
+	// DefaultLeader is the leader of a new record: a new, single item language material in UTF-8
+	DefaultLeader = "00000nam a2200000 i 4500"
+)
+
+// Encode writes records in the ISO 2709 exchange format of MARC 21
+func Encode(w io.Writer, records []Record) error {
+	for i := range records {
+		data, err := records[i].MarshalBinary()
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MarshalBinary encodes the record in ISO 2709, computing the lengths of its leader and directory
+func (r *Record) MarshalBinary() ([]byte, error) {
+	var directory, data bytes.Buffer
+	add := func(tag string, field []byte) error {
+		if len(tag) != 3 {
+			return fmt.Errorf("%w: tag %q is not three characters", ErrInvalidRecord, tag)
+		}
+		field = append(field, fieldTerminator)
+		if len(field) > 9999 {
+			return fmt.Errorf("%w: field %s is longer than 9999 bytes", ErrInvalidRecord, tag)
+		}
+		fmt.Fprintf(&directory, "%s%04d%05d", tag, len(field), data.Len())
+		data.Write(field)
+		return nil
+	}
+
+	for _, field := range r.ControlFields {
+		if err := add(field.Tag, []byte(field.Value)); err != nil {
+			return nil, err
+		}
+	}
+	for _, field := range r.DataFields {
+		value := []byte(indicator(field.Ind1) + indicator(field.Ind2))
+		for _, subfield := range field.Subfields {
+			value = append(value, subfieldMark)
+			value = append(value, subfield.Code...)
+			value = append(value, subfield.Value...)
+		}
+		if err := add(field.Tag, value); err != nil {
+			return nil, err
+		}
+	}
+	directory.WriteByte(fieldTerminator)
+
+	base := leaderLength + directory.Len()
+	length := base + data.Len() + 1
+	if length > 99999 {
+		return nil, ErrRecordTooLong
+	}
+
+	out := make([]byte, 0, length)
+	out = append(out, leader(r.Leader, length, base)...)
+	out = append(out, directory.Bytes()...)
+	out = append(out, data.Bytes()...)
+	return append(out, recordTerminator), nil
+}
+
+// Decode reads the records of an ISO 2709 file
+func Decode(r io.Reader) ([]Record, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	records := []Record{}
+	for n := 1; ; n++ {
+		data = bytes.TrimLeft(data, " \t\r\n")
+		if len(data) == 0 {
+			return records, nil
+		}
+		if len(data) < leaderLength {
+			return nil, fmt.Errorf("%w: record %d: truncated leader", ErrInvalidRecord, n)
+		}
+		length, ok := number(data[:5])
+		if !ok || length <= leaderLength || length > len(data) {
+			return nil, fmt.Errorf("%w: record %d: invalid record length", ErrInvalidRecord, n)
+		}
+
+		var record Record
+		if err := record.UnmarshalBinary(data[:length]); err != nil {
+			return nil, fmt.Errorf("record %d: %w", n, err)
+		}
+		records = append(records, record)
+		data = data[length:]
+	}
+}
+
+// UnmarshalBinary decodes a record in ISO 2709
+func (r *Record) UnmarshalBinary(data []byte) error {
+	if len(data) <= leaderLength {
+		return fmt.Errorf("%w: truncated leader", ErrInvalidRecord)
+	}
+	base, ok := number(data[12:17])
+	if !ok || base <= leaderLength || base > len(data) {
+		return fmt.Errorf("%w: invalid base address of data", ErrInvalidRecord)
+	}
+	directory := data[leaderLength : base-1]
+	if len(directory)%entryLength != 0 {
+		return fmt.Errorf("%w: invalid directory length", ErrInvalidRecord)
+	}
+
+	r.Leader = string(data[:leaderLength])
+	r.ControlFields = nil
+	r.DataFields = nil
+	for i := 0; i < len(directory); i += entryLength {
+		entry := directory[i : i+entryLength]
+		tag := string(entry[:3])
+		length, lengthOK := number(entry[3:7])
+		start, startOK := number(entry[7:12])
+		if !lengthOK || !startOK || length < 1 || base+start+length > len(data) {
+			return fmt.Errorf("%w: invalid directory entry for field %s", ErrInvalidRecord, tag)
+		}
+		field := bytes.TrimSuffix(data[base+start:base+start+length], []byte{fieldTerminator})
+
+		if isControlTag(tag) {
+			r.ControlFields = append(r.ControlFields, ControlField{Tag: tag, Value: string(field)})
+			continue
+		}
+		if len(field) < 2 {
+			return fmt.Errorf("%w: field %s has no indicators", ErrInvalidRecord, tag)
+		}
+		dataField := DataField{Tag: tag, Ind1: string(field[0]), Ind2: string(field[1])}
+		for _, subfield := range bytes.Split(field[2:], []byte{subfieldMark}) {
+			if len(subfield) == 0 {
+				continue
+			}
+			dataField.Subfields = append(dataField.Subfields, Subfield{Code: string(subfield[:1]), Value: string(subfield[1:])})
+		}
+		r.DataFields = append(r.DataFields, dataField)
+	}
+	return nil
+}
+
+// number reads the unsigned decimal number of a leader or directory position. Unlike strconv.Atoi
+// it refuses signs, so a malformed record cannot point before the data it describes.
+func number(digits []byte) (int, bool) {
+	if len(digits) == 0 {
+		return 0, false
+	}
+	n := 0
+	for _, d := range digits {
+		if d < '0' || d > '9' {
+			return 0, false
+		}
+		n = n*10 + int(d-'0')
+	}
+	return n, true
+}
+
+// leader returns base with its record length, base address and the fixed UTF-8 and entry map positions set
+func leader(base string, length, address int) []byte {
+	if len(base) != leaderLength {
+		base = DefaultLeader
+	}
+	out := []byte(base)
+	copy(out[0:5], fmt.Sprintf("%05d", length))
+	out[9] = 'a'
+	copy(out[10:12], "22")
+	copy(out[12:17], fmt.Sprintf("%05d", address))
+	copy(out[20:24], "4500")
+	return out
+}
+
+// indicator returns the indicator value, a blank when it is unset
+func indicator(value string) string {
+	if len(value) != 1 {
+		return " "
+	}
+	return value
+}
@@ -0,0 +1,109 @@
+package marc
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+const (
+	// ContentType is the media type of ISO 2709 MARC 21 files
+	ContentType = "application/marc"
+	// XMLContentType is the media type of MARCXML files
+	XMLContentType = "application/marcxml+xml"
+	// Namespace is the XML namespace of MARCXML
+	Namespace = "http://www.loc.gov/MARC21/slim"
+)
+
+// Format is a serialization of MARC 21 records
+type Format string
+
+const (
+	// FormatMARC21 is the binary ISO 2709 exchange format
+	FormatMARC21 Format = "marc21"
+	// FormatMARCXML is the MARCXML slim schema
+	FormatMARCXML Format = "marcxml"
+)
+
+// Formats lists the supported formats
+var Formats = []Format{FormatMARC21, FormatMARCXML}
+
+// ParseFormat returns the Format named raw, FormatMARC21 when raw is empty
+func ParseFormat(raw string) (Format, error) {
+	if raw == "" {
+		return FormatMARC21, nil
+	}
+	for _, format := range Formats {
+		if string(format) == strings.ToLower(raw) {
+			return format, nil
+		}
+	}
+	return "", &FormatError{Format: raw}
+}
+
+// ContentType returns the media type of the format
+func (f Format) ContentType() string {
+	if f == FormatMARCXML {
+		return XMLContentType
+	}
+	return ContentType
+}
+
+// Extension returns the file extension of the format
+func (f Format) Extension() string {
+	if f == FormatMARCXML {
+		return "xml"
+	}
+	return "mrc"
+}
+
+// Encode writes records to w in the format
+func (f Format) Encode(w io.Writer, records []Record) error {
+	if f == FormatMARCXML {
+		return EncodeXML(w, records)
+	}
+	return Encode(w, records)
+}
+
+// Record is a MARC 21 bibliographic record
+type Record struct {
+	XMLName       xml.Name       `xml:"record"`
+	Leader        string         `xml:"leader"`
+	ControlFields []ControlField `xml:"controlfield"`
+	DataFields    []DataField    `xml:"datafield"`
+}
+
+// ControlField is a variable control field, tags 001 to 009
+type ControlField struct {
+	Tag   string `xml:"tag,attr"`
+	Value string `xml:",chardata"`
+}
+
+// DataField is a variable data field with two indicators and subfields
+type DataField struct {
+	Tag       string     `xml:"tag,attr"`
+	Ind1      string     `xml:"ind1,attr"`
+	Ind2      string     `xml:"ind2,attr"`
+	Subfields []Subfield `xml:"subfield"`
+}
+
+// Subfield is a coded element of a DataField
+type Subfield struct {
+	Code  string `xml:"code,attr"`
+	Value string `xml:",chardata"`
+}
+
+// Subfield returns the value of the first subfield with the given code
+func (f DataField) Subfield(code string) string {
+	for _, subfield := range f.Subfields {
+		if subfield.Code == code {
+			return subfield.Value
+		}
+	}
+	return ""
+}
+
+// isControlTag reports whether tag names a control field
+func isControlTag(tag string) bool {
+	return strings.HasPrefix(tag, "00")
+}
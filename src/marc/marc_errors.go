@@ -0,0 +1,32 @@
+package marc
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidRecord is returned when a MARC file cannot be decoded
+	ErrInvalidRecord = errors.New("invalid MARC record")
+	// ErrRecordTooLong is returned when a record does not fit the ISO 2709 length fields
+	ErrRecordTooLong = errors.New("the MARC record is longer than 99999 bytes")
+)
+
+// FormatError describes a format name that is not supported
+type FormatError struct {
+	Format string
+}
+
+// Error implements the error interface
+func (e *FormatError) Error() string {
+	return fmt.Sprintf("unknown MARC format: %q", e.Format)
+}
+
+// AllowedValues returns the supported format names
+func (e *FormatError) AllowedValues() []string {
+	names := make([]string, len(Formats))
+	for i, format := range Formats {
+		names[i] = string(format)
+	}
+	return names
+}
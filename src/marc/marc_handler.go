@@ -0,0 +1,78 @@
+package marc
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+)
+
+// MARCHandler handles HTTP requests for MARC exports
+type MARCHandler struct {
+	service MARCService
+}
+
+// NewMARCHandler creates a new instance of MARCHandler
+func NewMARCHandler(service MARCService) *MARCHandler {
+	return &MARCHandler{service: service}
+}
+
+// GetBook handles GET /books/:id/marc request
+func (h *MARCHandler) GetBook(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid book ID")
+	}
+	format, err := ParseFormat(c.Query("format"))
+	if err != nil {
+		return apperror.BadRequest("format", err)
+	}
+
+	record, err := h.service.ExportBook(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return send(c, format, fmt.Sprintf("book-%d", id), []Record{*record})
+}
+
+// GetBooks handles GET /books/marc request, exporting every book matching the list filters
+func (h *MARCHandler) GetBooks(c *fiber.Ctx) error {
+	format, err := ParseFormat(c.Query("format"))
+	if err != nil {
+		return apperror.BadRequest("format", err)
+	}
+	filter, err := book.FilterFromQuery(c)
+	if err != nil {
+		return err
+	}
+
+	records, err := h.service.ExportBooks(c.UserContext(), filter)
+	if err != nil {
+		return err
+	}
+
+	return send(c, format, "books", records)
+}
+
+// send writes records in format as an attachment named after name
+func send(c *fiber.Ctx, format Format, name string, records []Record) error {
+	var body bytes.Buffer
+	if err := format.Encode(&body, records); err != nil {
+		return err
+	}
+
+	c.Attachment(name + "." + format.Extension())
+	c.Set(fiber.HeaderContentType, format.ContentType())
+	return c.Send(body.Bytes())
+}
+
+// RegisterRoutes registers the MARC routes.
+// They must be registered before the book routes so that /books/marc is not taken for a book ID.
+func (h *MARCHandler) RegisterRoutes(app *fiber.App) {
+	books := app.Group("/api/v1/books")
+	books.Get("/marc", h.GetBooks)
+	books.Get("/:id/marc", h.GetBook)
+}
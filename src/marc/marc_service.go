@@ -0,0 +1,47 @@
+package marc
+
+import (
+	"context"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+)
+
+// MARCService defines the interface for exporting books as MARC records
+type MARCService interface {
+	ExportBook(ctx context.Context, id uint) (*Record, error)
+	ExportBooks(ctx context.Context, filter book.BookFilter) ([]Record, error)
+}
+
+type marcServiceImpl struct {
+	books book.BookRepository
+}
+
+// NewMARCService creates a new instance of MARCService
+func NewMARCService(books book.BookRepository) MARCService {
+	return &marcServiceImpl{books: books}
+}
+
+// ExportBook maps the book with the given ID to a MARC record
+func (s *marcServiceImpl) ExportBook(ctx context.Context, id uint) (*Record, error) {
+	b, err := s.books.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	record := FromBook(b)
+	return &record, nil
+}
+
+// ExportBooks maps every book matching filter to a MARC record
+func (s *marcServiceImpl) ExportBooks(ctx context.Context, filter book.BookFilter) ([]Record, error) {
+	books, err := book.FindAllMatching(ctx, s.books, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]Record, len(books))
+	for i := range books {
+		records[i] = FromBook(&books[i])
+	}
+	return records, nil
+}
@@ -0,0 +1,69 @@
+package marc
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// collection is the root element of a MARCXML file
+type collection struct {
+	XMLName xml.Name `xml:"http://www.loc.gov/MARC21/slim collection"`
+	Records []Record `xml:"record"`
+}
+
+// EncodeXML writes records as a MARCXML collection
+func EncodeXML(w io.Writer, records []Record) error {
+	out := collection{Records: make([]Record, len(records))}
+	for i, record := range records {
+		out.Records[i] = ForXML(record)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(out); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// ForXML returns a copy of record as written in MARCXML, with the fixed positions of its leader
+// and its unset indicators filled in
+func ForXML(record Record) Record {
+	record.Leader = string(leader(record.Leader, 0, 0))
+	record.DataFields = append([]DataField(nil), record.DataFields...)
+	for i := range record.DataFields {
+		record.DataFields[i].Ind1 = indicator(record.DataFields[i].Ind1)
+		record.DataFields[i].Ind2 = indicator(record.DataFields[i].Ind2)
+	}
+	return record
+}
+
+// DecodeXML reads the records of a MARCXML file, either a collection or a single record
+func DecodeXML(r io.Reader) ([]Record, error) {
+	decoder := xml.NewDecoder(r)
+	records := []Record{}
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRecord, err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "record" {
+			continue
+		}
+		var record Record
+		if err := decoder.DecodeElement(&record, &start); err != nil {
+			return nil, fmt.Errorf("%w: record %d: %v", ErrInvalidRecord, len(records)+1, err)
+		}
+		records = append(records, record)
+	}
+}
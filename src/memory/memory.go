@@ -0,0 +1,128 @@
+package memory
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+)
+
+// Sort orders items by descending score when score is not nil, then by spec.
+// value returns the sort key of item for a public field name of the spec.
+func Sort[T any](items []T, spec sorting.Spec, value func(item T, field string) interface{}, score func(item T) float64) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if score != nil {
+			if si, sj := score(items[i]), score(items[j]); si != sj {
+				return si > sj
+			}
+		}
+		for _, f := range spec {
+			c := Compare(value(items[i], f.Name), value(items[j], f.Name))
+			if c == 0 {
+				continue
+			}
+			if f.Desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+}
+
+// Paginate returns the items of page out of items sorted by spec.
+// For a cursor page it returns the items after the cursor, or before it in reverse order,
+// plus one more item when the list continues, like pagination.Request.Apply.
+func Paginate[T any](items []T, page pagination.Request, spec sorting.Spec, value func(item T, field string) interface{}) []T {
+	if cursor := page.Cursor; cursor != nil {
+		if cursor.Backward {
+			items = slices.Clone(items)
+			slices.Reverse(items)
+			spec = spec.Reverse()
+		}
+		var selected []T
+		for _, item := range items {
+			if len(cursor.Key) == 0 || after(item, cursor.Key, spec, value) {
+				selected = append(selected, item)
+			}
+		}
+		return selected[:min(len(selected), int(page.PageSize)+1)]
+	}
+
+	start := page.Offset()
+	if start >= len(items) {
+		return []T{}
+	}
+	end := start + int(page.PageSize)
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
+
+// Compare compares two sort keys of the same type and returns -1, 0 or 1
+func Compare(a interface{}, b interface{}) int {
+	switch av := a.(type) {
+	case string:
+		return cmp.Compare(av, b.(string))
+	case uint:
+		return cmp.Compare(av, b.(uint))
+	case int:
+		return cmp.Compare(av, b.(int))
+	case float64:
+		return cmp.Compare(av, b.(float64))
+	case time.Time:
+		return av.Compare(b.(time.Time))
+	default:
+		panic(fmt.Sprintf("memory: unsupported sort key type %T", a))
+	}
+}
+
+// after reports whether item comes after key in the order of spec
+func after[T any](item T, key []interface{}, spec sorting.Spec, value func(item T, field string) interface{}) bool {
+	for i, f := range spec {
+		c := CompareKey(value(item, f.Name), key[i])
+		if c == 0 {
+			continue
+		}
+		return (c > 0) != f.Desc
+	}
+	return false
+}
+
+// CompareKey compares a sort key with the matching value of a decoded cursor and returns -1, 0 or 1
+func CompareKey(value interface{}, key interface{}) int {
+	switch v := value.(type) {
+	case time.Time:
+		s, _ := key.(string)
+		t, _ := time.Parse(time.RFC3339Nano, s)
+		return v.Compare(t)
+	case uint:
+		n, _ := key.(int64)
+		return cmp.Compare(int64(v), n)
+	case int:
+		n, _ := key.(int64)
+		return cmp.Compare(int64(v), n)
+	case float64:
+		switch k := key.(type) {
+		case int64:
+			return cmp.Compare(v, float64(k))
+		case float64:
+			return cmp.Compare(v, k)
+		}
+		return 1
+	default:
+		s, _ := key.(string)
+		return cmp.Compare(fmt.Sprint(v), s)
+	}
+}
+
+// ContainsFold reports whether substr is within s, ignoring case
+func ContainsFold(s string, substr string) bool {
+	return strings.Contains(strings.ToUpper(s), strings.ToUpper(substr))
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/history"
+)
+
+// ActorHeader names the actor of the changes a request makes
+const ActorHeader = "X-Actor"
+
+// Actor stores the actor named by the X-Actor header in the request context,
+// so the changes the request makes are recorded under that name.
+// Names the history cannot store are refused with 400 Bad Request.
+func Actor() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if actor := strings.TrimSpace(c.Get(ActorHeader)); actor != "" {
+			if err := history.CheckActor(actor); err != nil {
+				return apperror.BadRequest(ActorHeader, err)
+			}
+			c.SetUserContext(history.WithActor(c.UserContext(), actor))
+		}
+		return c.Next()
+	}
+}
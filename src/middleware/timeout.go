@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TimeoutConfig defines the deadline of each request
+type TimeoutConfig struct {
+	// Default applies to paths without a route timeout, zero means no deadline
+	Default time.Duration
+	// Routes maps path prefixes to their own timeout, the longest matching prefix wins
+	Routes map[string]time.Duration
+}
+
+// ParseRouteTimeouts parses comma-separated prefix=duration pairs,
+// e.g. "/api/v1/search=10s,/api/v1/books=3s"
+func ParseRouteTimeouts(raw string) (map[string]time.Duration, error) {
+	routes := make(map[string]time.Duration)
+	for _, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		prefix, value, ok := strings.Cut(pair, "=")
+		if !ok || !strings.HasPrefix(prefix, "/") {
+			return nil, fmt.Errorf("invalid route timeout %q, expected /prefix=duration", pair)
+		}
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid route timeout %q: %w", pair, err)
+		}
+		routes[strings.TrimSuffix(prefix, "/")] = timeout
+	}
+	return routes, nil
+}
+
+// For returns the timeout of path
+func (c TimeoutConfig) For(path string) time.Duration {
+	timeout, longest := c.Default, -1
+	for prefix, t := range c.Routes {
+		matches := path == prefix || strings.HasPrefix(path, prefix+"/")
+		if matches && len(prefix) > longest {
+			timeout, longest = t, len(prefix)
+		}
+	}
+	return timeout
+}
+
+// Timeout gives the user context of each request the deadline of its route
+// and turns handler failures caused by the deadline into a 504 error
+func Timeout(config TimeoutConfig) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		timeout := config.For(c.Path())
+		if timeout <= 0 {
+			return c.Next()
+		}
+
+		ctx, cancel := context.WithTimeout(c.UserContext(), timeout)
+		defer cancel()
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+
+		// Keep successful responses of handlers that finished just after the deadline
+		failed := err != nil || c.Response().StatusCode() >= fiber.StatusInternalServerError
+		if failed && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fiber.NewError(fiber.StatusGatewayTimeout, fmt.Sprintf("request timed out after %s", timeout))
+		}
+		return err
+	}
+}
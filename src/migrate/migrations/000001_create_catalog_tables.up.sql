@@ -0,0 +1,70 @@
+-- Catalog tables. IF NOT EXISTS lets databases created by the former AutoMigrate adopt this migration.
+
+CREATE TABLE IF NOT EXISTS publishers (
+	id bigserial NOT NULL,
+	name varchar(100) NOT NULL,
+	description text NULL,
+	created_at timestamptz NULL,
+	updated_at timestamptz NULL,
+	deleted_at timestamptz NULL,
+	CONSTRAINT publishers_pkey PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_publishers_deleted_at ON publishers USING btree (deleted_at);
+
+CREATE TABLE IF NOT EXISTS authors (
+	id bigserial NOT NULL,
+	name varchar(100) NOT NULL,
+	description varchar(500) NULL,
+	created_at timestamptz NULL,
+	updated_at timestamptz NULL,
+	deleted_at timestamptz NULL,
+	CONSTRAINT authors_pkey PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_authors_deleted_at ON authors USING btree (deleted_at);
+
+CREATE TABLE IF NOT EXISTS categories (
+	id bigserial NOT NULL,
+	code varchar(50) NOT NULL,
+	name varchar(100) NOT NULL,
+	description varchar(500) NULL,
+	created_at timestamptz NULL,
+	updated_at timestamptz NULL,
+	deleted_at timestamptz NULL,
+	CONSTRAINT categories_pkey PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_code ON categories USING btree (code);
+CREATE INDEX IF NOT EXISTS idx_categories_deleted_at ON categories USING btree (deleted_at);
+
+CREATE TABLE IF NOT EXISTS books (
+	id bigserial NOT NULL,
+	title varchar(200) NOT NULL,
+	description varchar(1000) NULL,
+	isbn10 varchar(10) NULL,
+	isbn13 varchar(13) NULL,
+	pages bigint NOT NULL,
+	year bigint NOT NULL,
+	publisher_id bigint NOT NULL,
+	created_at timestamptz NULL,
+	updated_at timestamptz NULL,
+	deleted_at timestamptz NULL,
+	CONSTRAINT books_pkey PRIMARY KEY (id),
+	CONSTRAINT fk_books_publisher FOREIGN KEY (publisher_id) REFERENCES publishers (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_books_isbn13 ON books USING btree (isbn13) WHERE isbn13 <> '' AND deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_books_deleted_at ON books USING btree (deleted_at);
+
+CREATE TABLE IF NOT EXISTS book_authors (
+	book_id bigint NOT NULL,
+	author_id bigint NOT NULL,
+	CONSTRAINT book_authors_pkey PRIMARY KEY (book_id, author_id),
+	CONSTRAINT fk_book_authors_book FOREIGN KEY (book_id) REFERENCES books (id),
+	CONSTRAINT fk_book_authors_author FOREIGN KEY (author_id) REFERENCES authors (id)
+);
+
+CREATE TABLE IF NOT EXISTS book_categories (
+	book_id bigint NOT NULL,
+	category_id bigint NOT NULL,
+	CONSTRAINT book_categories_pkey PRIMARY KEY (book_id, category_id),
+	CONSTRAINT fk_book_categories_book FOREIGN KEY (book_id) REFERENCES books (id),
+	CONSTRAINT fk_book_categories_category FOREIGN KEY (category_id) REFERENCES categories (id)
+);
@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS publishers_search_vector_refresh ON publishers;
+DROP FUNCTION IF EXISTS publishers_search_vector_refresh();
+DROP TRIGGER IF EXISTS authors_search_vector_refresh ON authors;
+DROP FUNCTION IF EXISTS authors_search_vector_refresh();
+DROP TRIGGER IF EXISTS book_authors_search_vector_refresh ON book_authors;
+DROP FUNCTION IF EXISTS book_authors_search_vector_refresh();
+DROP TRIGGER IF EXISTS books_search_vector_refresh ON books;
+DROP FUNCTION IF EXISTS books_search_vector_refresh();
+DROP INDEX IF EXISTS idx_books_search_vector;
+ALTER TABLE books DROP COLUMN IF EXISTS search_vector;
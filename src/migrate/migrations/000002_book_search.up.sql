@@ -0,0 +1,78 @@
+-- Weighted full-text search over books.
+-- Title (A) ranks above author names (B), then publisher name (C) and description (D).
+
+ALTER TABLE books ADD COLUMN IF NOT EXISTS search_vector tsvector;
+CREATE INDEX IF NOT EXISTS idx_books_search_vector ON books USING gin (search_vector);
+
+-- Recompute the search vector of a book row before it is written
+CREATE OR REPLACE FUNCTION books_search_vector_refresh() RETURNS trigger AS $$
+BEGIN
+	NEW.search_vector :=
+		setweight(to_tsvector('simple', coalesce(NEW.title, '')), 'A') ||
+		setweight(to_tsvector('simple', coalesce((
+			SELECT string_agg(a.name, ' ')
+			FROM book_authors ba
+			JOIN authors a ON a.id = ba.author_id AND a.deleted_at IS NULL
+			WHERE ba.book_id = NEW.id
+		), '')), 'B') ||
+		setweight(to_tsvector('simple', coalesce((
+			SELECT p.name
+			FROM publishers p
+			WHERE p.id = NEW.publisher_id AND p.deleted_at IS NULL
+		), '')), 'C') ||
+		setweight(to_tsvector('simple', coalesce(NEW.description, '')), 'D');
+	RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS books_search_vector_refresh ON books;
+CREATE TRIGGER books_search_vector_refresh
+	BEFORE INSERT OR UPDATE ON books
+	FOR EACH ROW EXECUTE FUNCTION books_search_vector_refresh();
+
+-- Touch the book when an author link is added or removed
+CREATE OR REPLACE FUNCTION book_authors_search_vector_refresh() RETURNS trigger AS $$
+BEGIN
+	IF TG_OP = 'DELETE' THEN
+		UPDATE books SET search_vector = NULL WHERE id = OLD.book_id;
+	ELSE
+		UPDATE books SET search_vector = NULL WHERE id = NEW.book_id;
+	END IF;
+	RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS book_authors_search_vector_refresh ON book_authors;
+CREATE TRIGGER book_authors_search_vector_refresh
+	AFTER INSERT OR DELETE ON book_authors
+	FOR EACH ROW EXECUTE FUNCTION book_authors_search_vector_refresh();
+
+-- Touch the books of an author whose name changes or who is deleted
+CREATE OR REPLACE FUNCTION authors_search_vector_refresh() RETURNS trigger AS $$
+BEGIN
+	UPDATE books SET search_vector = NULL
+	WHERE id IN (SELECT book_id FROM book_authors WHERE author_id = NEW.id);
+	RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS authors_search_vector_refresh ON authors;
+CREATE TRIGGER authors_search_vector_refresh
+	AFTER UPDATE OF name, deleted_at ON authors
+	FOR EACH ROW EXECUTE FUNCTION authors_search_vector_refresh();
+
+-- Touch the books of a publisher whose name changes or which is deleted
+CREATE OR REPLACE FUNCTION publishers_search_vector_refresh() RETURNS trigger AS $$
+BEGIN
+	UPDATE books SET search_vector = NULL WHERE publisher_id = NEW.id;
+	RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS publishers_search_vector_refresh ON publishers;
+CREATE TRIGGER publishers_search_vector_refresh
+	AFTER UPDATE OF name, deleted_at ON publishers
+	FOR EACH ROW EXECUTE FUNCTION publishers_search_vector_refresh();
+
+-- Backfill rows written before the triggers existed
+UPDATE books SET search_vector = NULL WHERE search_vector IS NULL;
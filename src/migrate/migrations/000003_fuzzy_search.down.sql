@@ -0,0 +1,6 @@
+-- The pg_trgm and unaccent extensions are left installed, other schemas may rely on them
+DROP INDEX IF EXISTS idx_books_title_trgm;
+DROP INDEX IF EXISTS idx_categories_name_trgm;
+DROP INDEX IF EXISTS idx_publishers_name_trgm;
+DROP INDEX IF EXISTS idx_authors_name_trgm;
+DROP FUNCTION IF EXISTS f_unaccent(text);
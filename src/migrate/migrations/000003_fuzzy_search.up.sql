@@ -0,0 +1,14 @@
+-- Accent-insensitive trigram matching for names and titles.
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- unaccent() is only STABLE, an IMMUTABLE wrapper is required to use it in an index
+CREATE OR REPLACE FUNCTION f_unaccent(text) RETURNS text AS $$
+	SELECT public.unaccent('public.unaccent', $1)
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT;
+
+CREATE INDEX IF NOT EXISTS idx_authors_name_trgm ON authors USING gin (f_unaccent(lower(name)) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_publishers_name_trgm ON publishers USING gin (f_unaccent(lower(name)) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_categories_name_trgm ON categories USING gin (f_unaccent(lower(name)) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_books_title_trgm ON books USING gin (f_unaccent(lower(title)) gin_trgm_ops);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_categories_code;
+CREATE UNIQUE INDEX idx_categories_code ON categories USING btree (code);
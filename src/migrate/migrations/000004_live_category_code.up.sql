@@ -0,0 +1,5 @@
+-- Category codes only have to be unique among live categories, like book ISBNs, so a deleted
+-- category no longer blocks its code. Restoring it fails while another category uses the code.
+
+DROP INDEX IF EXISTS idx_categories_code;
+CREATE UNIQUE INDEX idx_categories_code ON categories USING btree (code) WHERE deleted_at IS NULL;
@@ -0,0 +1,7 @@
+-- Every update increments the version of a record. It is exposed as the ETag of the record
+-- so updates and deletes can require the version they were based on with If-Match.
+
+ALTER TABLE authors ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
+ALTER TABLE publishers ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
+ALTER TABLE books ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
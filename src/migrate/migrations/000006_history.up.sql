@@ -0,0 +1,16 @@
+-- Every change of a catalog record is recorded with the state of the record before and after it,
+-- so its history can be listed, read as of a past time and reverted to.
+
+CREATE TABLE IF NOT EXISTS history (
+	id bigserial NOT NULL,
+	entity_type varchar(20) NOT NULL,
+	entity_id bigint NOT NULL,
+	version bigint NOT NULL,
+	action varchar(10) NOT NULL,
+	actor varchar(100) NOT NULL,
+	changed_at timestamptz NOT NULL,
+	before_state jsonb NULL,
+	after_state jsonb NULL,
+	CONSTRAINT history_pkey PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_history_entity ON history USING btree (entity_type, entity_id, changed_at);
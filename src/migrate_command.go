@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/migrate"
+)
+
+const migrateUsage = `usage: book-catalog migrate <command>
+
+commands:
+  up             apply all pending migrations
+  down [steps]   roll back the latest migrations (default: 1)
+  status         list migrations and whether they are applied
+  create <name>  create an empty up/down migration pair in MIGRATIONS_DIR`
+
+// runMigrate executes the migrate subcommand
+func runMigrate(args []string) {
+	if len(args) == 0 {
+		log.Fatal(migrateUsage)
+	}
+
+	// create only writes files and does not need a database
+	if args[0] == "create" {
+		if len(args) != 2 {
+			log.Fatal(migrateUsage)
+		}
+		paths, err := migrate.Create(getEnvOrDefault("MIGRATIONS_DIR", "src/migrate/migrations"), args[1])
+		if err != nil {
+			log.Fatal("Failed to create migration:", err)
+		}
+		for _, path := range paths {
+			fmt.Println("created", path)
+		}
+		return
+	}
+
+	migrator, err := NewMigrator(OpenDB())
+	if err != nil {
+		log.Fatal("Failed to load migrations:", err)
+	}
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, version := range applied {
+			fmt.Printf("applied %06d\n", version)
+		}
+		if err != nil {
+			log.Fatal("Failed to migrate up:", err)
+		}
+		if len(applied) == 0 {
+			fmt.Println("no pending migrations")
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				log.Fatal("steps must be a positive number")
+			}
+		}
+		rolledBack, err := migrator.Down(ctx, steps)
+		for _, version := range rolledBack {
+			fmt.Printf("rolled back %06d\n", version)
+		}
+		if err != nil {
+			log.Fatal("Failed to migrate down:", err)
+		}
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatal("Failed to read migration status:", err)
+		}
+		for _, status := range statuses {
+			state := "pending"
+			if status.AppliedAt != nil {
+				state = "applied " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%06d_%s\t%s\n", status.Version, status.Name, state)
+		}
+	default:
+		log.Fatal(migrateUsage)
+	}
+}
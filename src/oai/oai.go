@@ -0,0 +1,302 @@
+package oai
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/linkeddata"
+	"github.com/tedysaputro/book-catalog-with-go/src/marc"
+)
+
+const (
+	// ContentType is the media type of OAI-PMH responses
+	ContentType = "text/xml; charset=utf-8"
+	// Namespace is the XML namespace of OAI-PMH responses
+	Namespace = "http://www.openarchives.org/OAI/2.0/"
+	// SchemaLocation locates the schema of OAI-PMH responses
+	SchemaLocation = Namespace + " http://www.openarchives.org/OAI/2.0/OAI-PMH.xsd"
+	// XSINamespace is the XML namespace of XML Schema instances
+	XSINamespace = "http://www.w3.org/2001/XMLSchema-instance"
+	// ProtocolVersion is the version of OAI-PMH implemented
+	ProtocolVersion = "2.0"
+	// Granularity is the finest granularity of the datestamps supported
+	Granularity = "YYYY-MM-DDThh:mm:ssZ"
+	// DeletedRecord tells harvesters that deleted records are reported until they are purged from the trash
+	DeletedRecord = "transient"
+	// PageSize is the number of records or headers returned before a resumption token
+	PageSize = 100
+
+	dayLayout    = "2006-01-02"
+	secondLayout = "2006-01-02T15:04:05Z"
+)
+
+// Verbs of OAI-PMH requests
+const (
+	VerbIdentify            = "Identify"
+	VerbListMetadataFormats = "ListMetadataFormats"
+	VerbListSets            = "ListSets"
+	VerbListIdentifiers     = "ListIdentifiers"
+	VerbListRecords         = "ListRecords"
+	VerbGetRecord           = "GetRecord"
+)
+
+// Prefixes of the metadata formats served
+const (
+	PrefixDublinCore = "oai_dc"
+	PrefixMARC21     = "marc21"
+)
+
+// Specs of the sets the books are grouped in. A category or publisher set is a subset of its parent
+// set, with the category code or publisher ID after a colon.
+const (
+	SetCategory  = "category"
+	SetPublisher = "publisher"
+)
+
+// Repository describes the repository in Identify responses
+type Repository struct {
+	Name       string
+	AdminEmail string
+	// Identifier is the domain name that prefixes the identifiers of records
+	Identifier string
+}
+
+// DefaultRepository is the repository served by the OAI-PMH endpoint
+var DefaultRepository = Repository{
+	Name:       "Book Catalog",
+	AdminEmail: "admin@book-catalog.local",
+	Identifier: "book-catalog.local",
+}
+
+// MetadataFormats lists the metadata formats every record is disseminated in
+var MetadataFormats = []MetadataFormat{
+	{
+		Prefix:    PrefixDublinCore,
+		Schema:    "http://www.openarchives.org/OAI/2.0/oai_dc.xsd",
+		Namespace: "http://www.openarchives.org/OAI/2.0/oai_dc/",
+	},
+	{
+		Prefix:    PrefixMARC21,
+		Schema:    "http://www.loc.gov/standards/marcxml/schema/MARC21slim.xsd",
+		Namespace: marc.Namespace,
+	},
+}
+
+// Response is an OAI-PMH response, holding either errors or the element of its verb
+type Response struct {
+	XMLName             xml.Name             `xml:"http://www.openarchives.org/OAI/2.0/ OAI-PMH"`
+	XSI                 string               `xml:"xmlns:xsi,attr"`
+	SchemaLocation      string               `xml:"xsi:schemaLocation,attr"`
+	ResponseDate        string               `xml:"responseDate"`
+	Request             Request              `xml:"request"`
+	Errors              []*Error             `xml:"error"`
+	Identify            *Identify            `xml:"Identify"`
+	ListMetadataFormats *ListMetadataFormats `xml:"ListMetadataFormats"`
+	ListSets            *ListSets            `xml:"ListSets"`
+	GetRecord           *GetRecord           `xml:"GetRecord"`
+	ListIdentifiers     *ListIdentifiers     `xml:"ListIdentifiers"`
+	ListRecords         *ListRecords         `xml:"ListRecords"`
+}
+
+// Request echoes the base URL and the arguments of the request answered
+type Request struct {
+	URL             string `xml:",chardata"`
+	Verb            string `xml:"verb,attr,omitempty"`
+	Identifier      string `xml:"identifier,attr,omitempty"`
+	MetadataPrefix  string `xml:"metadataPrefix,attr,omitempty"`
+	From            string `xml:"from,attr,omitempty"`
+	Until           string `xml:"until,attr,omitempty"`
+	Set             string `xml:"set,attr,omitempty"`
+	ResumptionToken string `xml:"resumptionToken,attr,omitempty"`
+}
+
+// Identify describes the repository
+type Identify struct {
+	RepositoryName    string `xml:"repositoryName"`
+	BaseURL           string `xml:"baseURL"`
+	ProtocolVersion   string `xml:"protocolVersion"`
+	AdminEmail        string `xml:"adminEmail"`
+	EarliestDatestamp string `xml:"earliestDatestamp"`
+	DeletedRecord     string `xml:"deletedRecord"`
+	Granularity       string `xml:"granularity"`
+}
+
+// ListMetadataFormats lists the metadata formats of the repository or of a record
+type ListMetadataFormats struct {
+	MetadataFormats []MetadataFormat `xml:"metadataFormat"`
+}
+
+// MetadataFormat is a metadata format records are disseminated in
+type MetadataFormat struct {
+	Prefix    string `xml:"metadataPrefix"`
+	Schema    string `xml:"schema"`
+	Namespace string `xml:"metadataNamespace"`
+}
+
+// ListSets lists the sets of the repository
+type ListSets struct {
+	Sets []Set `xml:"set"`
+}
+
+// Set is a group of records harvested together
+type Set struct {
+	Spec string `xml:"setSpec"`
+	Name string `xml:"setName"`
+}
+
+// GetRecord holds a single record
+type GetRecord struct {
+	Record Record `xml:"record"`
+}
+
+// ListIdentifiers holds a page of record headers
+type ListIdentifiers struct {
+	Headers         []Header         `xml:"header"`
+	ResumptionToken *ResumptionToken `xml:"resumptionToken"`
+}
+
+// ListRecords holds a page of records
+type ListRecords struct {
+	Records         []Record         `xml:"record"`
+	ResumptionToken *ResumptionToken `xml:"resumptionToken"`
+}
+
+// Record is a book in a metadata format, or only its header when the book is deleted
+type Record struct {
+	Header   Header    `xml:"header"`
+	Metadata *Metadata `xml:"metadata"`
+}
+
+// Header identifies a record and tells when it last changed
+type Header struct {
+	Status     string   `xml:"status,attr,omitempty"`
+	Identifier string   `xml:"identifier"`
+	Datestamp  string   `xml:"datestamp"`
+	SetSpecs   []string `xml:"setSpec"`
+}
+
+// Metadata holds a record in one of the MetadataFormats
+type Metadata struct {
+	DublinCore *DublinCore `xml:"oai_dc:dc"`
+	MARC       *MARCRecord `xml:"record"`
+}
+
+// MARCRecord is a record in the marc21 format
+type MARCRecord struct {
+	marc.Record
+}
+
+// MarshalXML writes the record in the MARCXML namespace
+func (r MARCRecord) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name = xml.Name{Space: marc.Namespace, Local: "record"}
+	return e.EncodeElement(r.Record, start)
+}
+
+// DublinCore is a record in the oai_dc format
+type DublinCore struct {
+	OAIDC          string `xml:"xmlns:oai_dc,attr"`
+	DC             string `xml:"xmlns:dc,attr"`
+	SchemaLocation string `xml:"xsi:schemaLocation,attr"`
+	linkeddata.DublinCore
+}
+
+// ResumptionToken resumes an incomplete list; it is empty in the last page of the list
+type ResumptionToken struct {
+	Value            string `xml:",chardata"`
+	CompleteListSize uint64 `xml:"completeListSize,attr"`
+	Cursor           uint64 `xml:"cursor,attr"`
+}
+
+// Identifier returns the OAI identifier of a book
+func Identifier(id uint) string {
+	return fmt.Sprintf("oai:%s:book:%d", DefaultRepository.Identifier, id)
+}
+
+// ParseIdentifier returns the book ID of an OAI identifier
+func ParseIdentifier(identifier string) (uint, bool) {
+	raw, ok := strings.CutPrefix(identifier, fmt.Sprintf("oai:%s:book:", DefaultRepository.Identifier))
+	if !ok {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(raw, 10, 32)
+	if err != nil || id == 0 {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// Datestamp formats t with the granularity of the repository
+func Datestamp(t time.Time) string {
+	return t.UTC().Format(secondLayout)
+}
+
+// parseDatestamp parses a from or until argument, a day or a second in UTC.
+// It returns the start of the day or second and its length.
+func parseDatestamp(value string) (time.Time, time.Duration, bool) {
+	if t, err := time.Parse(dayLayout, value); err == nil {
+		return t, 24 * time.Hour, true
+	}
+	if t, err := time.Parse(secondLayout, value); err == nil {
+		return t, time.Second, true
+	}
+	return time.Time{}, 0, false
+}
+
+// CategorySet returns the spec of the set of a category
+func CategorySet(code string) string {
+	return SetCategory + ":" + code
+}
+
+// PublisherSet returns the spec of the set of a publisher
+func PublisherSet(id uint) string {
+	return fmt.Sprintf("%s:%d", SetPublisher, id)
+}
+
+// HeaderOf returns the header of a book, marked deleted when the book is soft deleted
+func HeaderOf(b *book.Book) Header {
+	header := Header{Identifier: Identifier(b.ID), Datestamp: Datestamp(b.ChangedAt())}
+	if b.DeletedAt.Valid {
+		header.Status = "deleted"
+	}
+	for _, c := range b.Categories {
+		header.SetSpecs = append(header.SetSpecs, CategorySet(c.Code))
+	}
+	header.SetSpecs = append(header.SetSpecs, PublisherSet(b.PublisherID))
+	return header
+}
+
+// RecordOf returns the record of a book in the metadata format of prefix, without metadata when
+// the book is soft deleted. base is the URL of the API the book is identified under.
+func RecordOf(b *book.Book, prefix, base string) Record {
+	record := Record{Header: HeaderOf(b)}
+	if b.DeletedAt.Valid {
+		return record
+	}
+
+	switch prefix {
+	case PrefixMARC21:
+		record.Metadata = &Metadata{MARC: &MARCRecord{Record: marc.ForXML(marc.FromBook(b))}}
+	default:
+		record.Metadata = &Metadata{DublinCore: &DublinCore{
+			OAIDC:          MetadataFormats[0].Namespace,
+			DC:             linkeddata.DCNamespace,
+			SchemaLocation: MetadataFormats[0].Namespace + " " + MetadataFormats[0].Schema,
+			DublinCore:     linkeddata.DublinCoreOf(b, base),
+		}}
+	}
+	return record
+}
+
+// metadataFormat returns the metadata format with the prefix
+func metadataFormat(prefix string) (MetadataFormat, bool) {
+	for _, format := range MetadataFormats {
+		if format.Prefix == prefix {
+			return format, true
+		}
+	}
+	return MetadataFormat{}, false
+}
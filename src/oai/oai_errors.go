@@ -0,0 +1,27 @@
+package oai
+
+// Codes of the errors and exceptions of OAI-PMH
+const (
+	CodeBadArgument             = "badArgument"
+	CodeBadResumptionToken      = "badResumptionToken"
+	CodeBadVerb                 = "badVerb"
+	CodeCannotDisseminateFormat = "cannotDisseminateFormat"
+	CodeIDDoesNotExist          = "idDoesNotExist"
+	CodeNoRecordsMatch          = "noRecordsMatch"
+)
+
+// Error is an OAI-PMH error, reported in a successful response instead of its verb element
+type Error struct {
+	Code    string `xml:"code,attr"`
+	Message string `xml:",chardata"`
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+// newError creates an Error with the code and message
+func newError(code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
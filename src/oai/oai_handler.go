@@ -0,0 +1,168 @@
+package oai
+
+import (
+	"encoding/xml"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// verbArguments lists the arguments of a verb. An exclusive argument cannot be combined with others.
+type verbArguments struct {
+	required  []string
+	optional  []string
+	exclusive string
+}
+
+// verbs lists the arguments of each verb
+var verbs = map[string]verbArguments{
+	VerbIdentify:            {},
+	VerbListMetadataFormats: {optional: []string{"identifier"}},
+	VerbListSets:            {exclusive: "resumptionToken"},
+	VerbListIdentifiers:     {required: []string{"metadataPrefix"}, optional: []string{"from", "until", "set"}, exclusive: "resumptionToken"},
+	VerbListRecords:         {required: []string{"metadataPrefix"}, optional: []string{"from", "until", "set"}, exclusive: "resumptionToken"},
+	VerbGetRecord:           {required: []string{"identifier", "metadataPrefix"}},
+}
+
+// OAIHandler handles the OAI-PMH endpoint
+type OAIHandler struct {
+	service OAIService
+}
+
+// NewOAIHandler creates a new instance of OAIHandler
+func NewOAIHandler(service OAIService) *OAIHandler {
+	return &OAIHandler{service: service}
+}
+
+// Handle handles GET and POST /oai requests, answering protocol errors in a successful response
+func (h *OAIHandler) Handle(c *fiber.Ctx) error {
+	response := &Response{
+		XSI:            XSINamespace,
+		SchemaLocation: SchemaLocation,
+		ResponseDate:   Datestamp(time.Now()),
+		Request:        Request{URL: c.BaseURL() + "/oai"},
+	}
+
+	args, err := arguments(c)
+	if err == nil {
+		response.Request = Request{
+			URL:             response.Request.URL,
+			Verb:            args["verb"],
+			Identifier:      args["identifier"],
+			MetadataPrefix:  args["metadataPrefix"],
+			From:            args["from"],
+			Until:           args["until"],
+			Set:             args["set"],
+			ResumptionToken: args["resumptionToken"],
+		}
+		err = h.answer(c, args, response)
+	}
+
+	var protocolErr *Error
+	if errors.As(err, &protocolErr) {
+		// Requests with a bad verb or arguments are only echoed with their base URL
+		if protocolErr.Code == CodeBadVerb || protocolErr.Code == CodeBadArgument {
+			response.Request = Request{URL: response.Request.URL}
+		}
+		response.Errors = []*Error{protocolErr}
+	} else if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, ContentType)
+	if _, err := c.WriteString(xml.Header); err != nil {
+		return err
+	}
+	return xml.NewEncoder(c).Encode(response)
+}
+
+// answer fills response with the element of the verb of the request
+func (h *OAIHandler) answer(c *fiber.Ctx, args map[string]string, response *Response) error {
+	ctx := c.UserContext()
+	base := c.BaseURL() + "/api/v1"
+	var err error
+
+	switch args["verb"] {
+	case VerbIdentify:
+		response.Identify, err = h.service.Identify(ctx, response.Request.URL)
+	case VerbListMetadataFormats:
+		response.ListMetadataFormats, err = h.service.ListMetadataFormats(ctx, args["identifier"])
+	case VerbListSets:
+		if token := args["resumptionToken"]; token != "" {
+			return newError(CodeBadResumptionToken, "the list of sets is never split")
+		}
+		response.ListSets, err = h.service.ListSets(ctx)
+	case VerbListIdentifiers, VerbListRecords:
+		query, queryErr := listQuery(args)
+		if queryErr != nil {
+			return queryErr
+		}
+		if args["verb"] == VerbListIdentifiers {
+			response.ListIdentifiers, err = h.service.ListIdentifiers(ctx, query)
+		} else {
+			response.ListRecords, err = h.service.ListRecords(ctx, query, base)
+		}
+	case VerbGetRecord:
+		response.GetRecord, err = h.service.GetRecord(ctx, args["identifier"], args["metadataPrefix"], base)
+	}
+	return err
+}
+
+// RegisterRoutes registers all routes for the OAI-PMH endpoint
+func (h *OAIHandler) RegisterRoutes(app *fiber.App) {
+	app.Get("/oai", h.Handle)
+	app.Post("/oai", h.Handle)
+}
+
+// arguments reads the arguments of a request, from its query string or its form encoded body,
+// checking they are valid for its verb
+func arguments(c *fiber.Ctx) (map[string]string, error) {
+	source := c.Context().QueryArgs()
+	if c.Method() == fiber.MethodPost {
+		source = c.Context().PostArgs()
+	}
+
+	args := map[string]string{}
+	var repeated string
+	source.VisitAll(func(key, value []byte) {
+		if _, ok := args[string(key)]; ok {
+			repeated = string(key)
+		}
+		args[string(key)] = string(value)
+	})
+
+	verb, ok := verbs[args["verb"]]
+	if !ok || repeated == "verb" {
+		return nil, newError(CodeBadVerb, "the verb is missing or not an OAI-PMH verb")
+	}
+	if repeated != "" {
+		return nil, newError(CodeBadArgument, "the argument "+repeated+" is repeated")
+	}
+	if verb.exclusive != "" && args[verb.exclusive] != "" {
+		if len(args) > 2 {
+			return nil, newError(CodeBadArgument, "the argument "+verb.exclusive+" is exclusive")
+		}
+		return args, nil
+	}
+	for name := range args {
+		if name != "verb" && name != verb.exclusive && !slices.Contains(verb.required, name) && !slices.Contains(verb.optional, name) {
+			return nil, newError(CodeBadArgument, "the argument "+name+" is not allowed with the verb "+args["verb"])
+		}
+	}
+	for _, name := range verb.required {
+		if args[name] == "" {
+			return nil, newError(CodeBadArgument, "the argument "+name+" is missing")
+		}
+	}
+	return args, nil
+}
+
+// listQuery returns the Query of a ListIdentifiers or ListRecords request
+func listQuery(args map[string]string) (Query, error) {
+	if token := args["resumptionToken"]; token != "" {
+		return ParseResumptionToken(token)
+	}
+	return NewQuery(args["metadataPrefix"], args["from"], args["until"], args["set"])
+}
@@ -0,0 +1,259 @@
+package oai
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/category"
+	"github.com/tedysaputro/book-catalog-with-go/src/fuzzy"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/publisher"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+)
+
+// OAIService defines the interface for answering the verbs of OAI-PMH.
+// Protocol errors are returned as *Error; base is the URL of the API records are identified under.
+type OAIService interface {
+	Identify(ctx context.Context, baseURL string) (*Identify, error)
+	ListMetadataFormats(ctx context.Context, identifier string) (*ListMetadataFormats, error)
+	ListSets(ctx context.Context) (*ListSets, error)
+	ListIdentifiers(ctx context.Context, query Query) (*ListIdentifiers, error)
+	ListRecords(ctx context.Context, query Query, base string) (*ListRecords, error)
+	GetRecord(ctx context.Context, identifier, prefix, base string) (*GetRecord, error)
+}
+
+type oaiServiceImpl struct {
+	books      book.BookRepository
+	categories category.CategoryRepository
+	publishers publisher.PublisherRepository
+}
+
+// NewOAIService creates a new instance of OAIService
+func NewOAIService(books book.BookRepository, categories category.CategoryRepository, publishers publisher.PublisherRepository) OAIService {
+	return &oaiServiceImpl{books: books, categories: categories, publishers: publishers}
+}
+
+// Identify describes the repository, dating it from the oldest change of a book
+func (s *oaiServiceImpl) Identify(ctx context.Context, baseURL string) (*Identify, error) {
+	sort, err := sorting.Parse("updated_at", book.SortFields)
+	if err != nil {
+		return nil, err
+	}
+	oldest, _, err := s.books.FindChanged(ctx, pagination.Request{Page: 1, PageSize: 1}, sort, book.ChangeFilter{})
+	if err != nil {
+		return nil, err
+	}
+	earliest := time.Unix(0, 0)
+	if len(oldest) > 0 {
+		earliest = oldest[0].UpdatedAt
+	}
+
+	return &Identify{
+		RepositoryName:    DefaultRepository.Name,
+		BaseURL:           baseURL,
+		ProtocolVersion:   ProtocolVersion,
+		AdminEmail:        DefaultRepository.AdminEmail,
+		EarliestDatestamp: Datestamp(earliest),
+		DeletedRecord:     DeletedRecord,
+		Granularity:       Granularity,
+	}, nil
+}
+
+// ListMetadataFormats lists the metadata formats, checking the record exists when identifier is set
+func (s *oaiServiceImpl) ListMetadataFormats(ctx context.Context, identifier string) (*ListMetadataFormats, error) {
+	if identifier != "" {
+		if _, err := s.findBook(ctx, identifier); err != nil {
+			return nil, err
+		}
+	}
+	return &ListMetadataFormats{MetadataFormats: MetadataFormats}, nil
+}
+
+// ListSets lists the category and publisher sets, each after its parent set
+func (s *oaiServiceImpl) ListSets(ctx context.Context) (*ListSets, error) {
+	categories, err := findAll(func(page pagination.Request, sort sorting.Spec) ([]category.Category, uint64, error) {
+		return s.categories.FindAll(ctx, page, sort, "", fuzzy.Options{})
+	}, category.SortFields)
+	if err != nil {
+		return nil, err
+	}
+	publishers, err := findAll(func(page pagination.Request, sort sorting.Spec) ([]publisher.Publisher, uint64, error) {
+		return s.publishers.FindAll(ctx, page, sort, "", fuzzy.Options{})
+	}, publisher.SortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	sets := []Set{{Spec: SetCategory, Name: "Books by category"}}
+	for _, c := range categories {
+		sets = append(sets, Set{Spec: CategorySet(c.Code), Name: c.Name})
+	}
+	sets = append(sets, Set{Spec: SetPublisher, Name: "Books by publisher"})
+	for _, p := range publishers {
+		sets = append(sets, Set{Spec: PublisherSet(p.ID), Name: p.Name})
+	}
+	return &ListSets{Sets: sets}, nil
+}
+
+// ListIdentifiers lists a page of the headers of the records matching query
+func (s *oaiServiceImpl) ListIdentifiers(ctx context.Context, query Query) (*ListIdentifiers, error) {
+	books, token, err := s.list(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &ListIdentifiers{Headers: make([]Header, len(books)), ResumptionToken: token}
+	for i := range books {
+		list.Headers[i] = HeaderOf(&books[i])
+	}
+	return list, nil
+}
+
+// ListRecords lists a page of the records matching query
+func (s *oaiServiceImpl) ListRecords(ctx context.Context, query Query, base string) (*ListRecords, error) {
+	books, token, err := s.list(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &ListRecords{Records: make([]Record, len(books)), ResumptionToken: token}
+	for i := range books {
+		list.Records[i] = RecordOf(&books[i], query.MetadataPrefix, base)
+	}
+	return list, nil
+}
+
+// GetRecord retrieves the record of a book, live or deleted
+func (s *oaiServiceImpl) GetRecord(ctx context.Context, identifier, prefix, base string) (*GetRecord, error) {
+	if _, ok := metadataFormat(prefix); !ok {
+		return nil, newError(CodeCannotDisseminateFormat, "the metadata format "+prefix+" is not supported")
+	}
+	b, err := s.findBook(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+	return &GetRecord{Record: RecordOf(b, prefix, base)}, nil
+}
+
+// list retrieves a page of the live and deleted books matching query after query.After, ordered
+// by ID, with the resumption token of the list when it takes more than one page
+func (s *oaiServiceImpl) list(ctx context.Context, query Query) ([]book.Book, *ResumptionToken, error) {
+	if _, ok := metadataFormat(query.MetadataPrefix); !ok {
+		return nil, nil, newError(CodeCannotDisseminateFormat, "the metadata format "+query.MetadataPrefix+" is not supported")
+	}
+	filter, ok, err := s.filter(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	sort, err := sorting.Parse("id", book.SortFields)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var books []book.Book
+	var total uint64
+	if ok {
+		// The cursor page holds one more book when the list continues
+		page := pagination.Request{PageSize: PageSize, Cursor: &pagination.Cursor{Sort: sort.String()}}
+		if query.After > 0 {
+			page.Cursor.Key = []interface{}{int64(query.After)}
+		}
+		if books, total, err = s.books.FindChanged(ctx, page, sort, filter); err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(books) == 0 {
+		if query.After > 0 {
+			return nil, nil, newError(CodeBadResumptionToken, "the resumption token is past the end of the list")
+		}
+		return nil, nil, newError(CodeNoRecordsMatch, "no records match the request")
+	}
+
+	more := len(books) > PageSize
+	books = books[:min(len(books), PageSize)]
+	if query.After == 0 && !more {
+		return books, nil, nil
+	}
+	// The list may have grown or shrunk since the harvest started, so the size is only an estimate
+	token := &ResumptionToken{CompleteListSize: max(total, query.Listed+uint64(len(books))), Cursor: query.Listed}
+	if more {
+		token.Value = query.ResumptionToken(books[len(books)-1].ID, len(books))
+	}
+	return books, token, nil
+}
+
+// filter maps the period and set of query to a book.ChangeFilter. It reports false when the set
+// names no category or publisher, so no book can match.
+func (s *oaiServiceImpl) filter(ctx context.Context, query Query) (book.ChangeFilter, bool, error) {
+	filter := book.ChangeFilter{From: query.From, Until: query.Until}
+	parent, child, hasChild := strings.Cut(query.Set, ":")
+	switch {
+	case query.Set == "":
+	case parent == SetCategory && hasChild:
+		filter.CategoryCodes = []string{child}
+	case parent == SetCategory:
+		categories, err := findAll(func(page pagination.Request, sort sorting.Spec) ([]category.Category, uint64, error) {
+			return s.categories.FindAll(ctx, page, sort, "", fuzzy.Options{})
+		}, category.SortFields)
+		if err != nil || len(categories) == 0 {
+			return filter, false, err
+		}
+		for _, c := range categories {
+			filter.CategoryCodes = append(filter.CategoryCodes, c.Code)
+		}
+	case parent == SetPublisher && hasChild:
+		id, err := strconv.ParseUint(child, 10, 32)
+		if err != nil || id == 0 {
+			return filter, false, nil
+		}
+		filter.PublisherID = uint(id)
+	case parent == SetPublisher:
+	default:
+		return filter, false, nil
+	}
+	return filter, true, nil
+}
+
+// findBook retrieves the live or soft deleted book of an OAI identifier
+func (s *oaiServiceImpl) findBook(ctx context.Context, identifier string) (*book.Book, error) {
+	notFound := newError(CodeIDDoesNotExist, "no record has the identifier "+identifier)
+	id, ok := ParseIdentifier(identifier)
+	if !ok {
+		return nil, notFound
+	}
+
+	b, err := s.books.FindByID(ctx, id)
+	if errors.Is(err, book.ErrBookNotFound) {
+		b, err = s.books.FindDeletedByID(ctx, id)
+	}
+	if errors.Is(err, book.ErrBookNotInTrash) {
+		return nil, notFound
+	}
+	return b, err
+}
+
+// findAll reads every record of a listing sorted by ID, a page of pagination.MaxPageSize at a time
+func findAll[T any](find func(page pagination.Request, sort sorting.Spec) ([]T, uint64, error), fields sorting.Fields) ([]T, error) {
+	sort, err := sorting.Parse("id", fields)
+	if err != nil {
+		return nil, err
+	}
+
+	all := []T{}
+	page := pagination.Request{Page: 1, PageSize: pagination.MaxPageSize}
+	for {
+		found, total, err := find(page, sort)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, found...)
+		if len(found) == 0 || uint64(page.Page*page.PageSize) >= total {
+			return all, nil
+		}
+		page.Page++
+	}
+}
@@ -0,0 +1,108 @@
+package oai
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Query selects the records of a ListIdentifiers or ListRecords request
+type Query struct {
+	MetadataPrefix string
+	// From matches records changed at or after it when set
+	From time.Time
+	// Until matches records changed before it when set
+	Until time.Time
+	Set   string
+	// After is the ID of the last record of the previous page, 0 on the first page. Resuming
+	// after an ID rather than at an offset keeps records from being skipped or repeated when the
+	// list changes during a harvest.
+	After uint
+	// Listed is the number of records listed on the previous pages
+	Listed uint64
+}
+
+// NewQuery creates the Query of the first page of a list from the from, until and set arguments
+func NewQuery(prefix, from, until, set string) (Query, error) {
+	query := Query{MetadataPrefix: prefix, Set: set}
+	var fromLength, untilLength time.Duration
+	var ok bool
+	if from != "" {
+		if query.From, fromLength, ok = parseDatestamp(from); !ok {
+			return Query{}, newError(CodeBadArgument, "from is not a datestamp of the form "+Granularity)
+		}
+	}
+	if until != "" {
+		if query.Until, untilLength, ok = parseDatestamp(until); !ok {
+			return Query{}, newError(CodeBadArgument, "until is not a datestamp of the form "+Granularity)
+		}
+	}
+	if from != "" && until != "" {
+		if fromLength != untilLength {
+			return Query{}, newError(CodeBadArgument, "from and until have different granularities")
+		}
+		if query.From.After(query.Until) {
+			return Query{}, newError(CodeBadArgument, "from is later than until")
+		}
+	}
+	// until includes the whole day or second it names
+	if until != "" {
+		query.Until = query.Until.Add(untilLength)
+	}
+	return query, nil
+}
+
+// ParseResumptionToken returns the Query a resumption token resumes
+func ParseResumptionToken(token string) (Query, error) {
+	invalid := newError(CodeBadResumptionToken, "the resumption token is invalid")
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Query{}, invalid
+	}
+	values, err := url.ParseQuery(string(raw))
+	if err != nil {
+		return Query{}, invalid
+	}
+
+	query := Query{MetadataPrefix: values.Get("metadataPrefix"), Set: values.Get("set")}
+	after, err := strconv.ParseUint(values.Get("after"), 10, 32)
+	if err != nil || after == 0 {
+		return Query{}, invalid
+	}
+	query.After = uint(after)
+	if query.Listed, err = strconv.ParseUint(values.Get("listed"), 10, 64); err != nil || query.Listed == 0 {
+		return Query{}, invalid
+	}
+	for name, t := range map[string]*time.Time{"from": &query.From, "until": &query.Until} {
+		if value := values.Get(name); value != "" {
+			if *t, err = time.Parse(time.RFC3339, value); err != nil {
+				return Query{}, invalid
+			}
+		}
+	}
+	if _, ok := metadataFormat(query.MetadataPrefix); !ok {
+		return Query{}, invalid
+	}
+	return query, nil
+}
+
+// ResumptionToken returns the token resuming the list of the query after the record lastID,
+// once the current page of listed records has been listed
+func (q Query) ResumptionToken(lastID uint, listed int) string {
+	values := url.Values{
+		"metadataPrefix": {q.MetadataPrefix},
+		"after":          {strconv.FormatUint(uint64(lastID), 10)},
+		"listed":         {strconv.FormatUint(q.Listed+uint64(listed), 10)},
+	}
+	if q.Set != "" {
+		values.Set("set", q.Set)
+	}
+	if !q.From.IsZero() {
+		values.Set("from", q.From.UTC().Format(time.RFC3339))
+	}
+	if !q.Until.IsZero() {
+		values.Set("until", q.Until.UTC().Format(time.RFC3339))
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(values.Encode()))
+}
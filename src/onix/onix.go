@@ -0,0 +1,261 @@
+package onix
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+)
+
+const (
+	// Namespace is the XML namespace of ONIX 3.0 messages with reference tags
+	Namespace = "http://ns.editeur.org/onix/3.0/reference"
+	// Release is the ONIX release the messages follow
+	Release = "3.0"
+	// SenderName names the catalog in the header of every message
+	SenderName = "Book Catalog"
+	// ContentType is the media type of ONIX messages
+	ContentType = "application/xml; charset=utf-8"
+)
+
+// Codes of the ONIX code lists used by the messages
+const (
+	notificationConfirmed = "03"  // list 1: notification confirmed on publication
+	notificationDelete    = "05"  // list 1: delete
+	idProprietary         = "01"  // list 5: proprietary identifier
+	idISBN10              = "02"  // list 5: ISBN-10
+	idISBN13              = "15"  // list 5: ISBN-13
+	singleItem            = "00"  // list 2: single-component retail product
+	formBook              = "BA"  // list 150: book
+	distinctiveTitle      = "01"  // list 15: distinctive title
+	productLevel          = "01"  // list 149: product level
+	roleAuthor            = "A01" // list 17: by (author)
+	mainContentPages      = "00"  // list 23: main content page count
+	unitPages             = "03"  // list 24: pages
+	textDescription       = "03"  // list 153: description
+	audienceUnrestricted  = "00"  // list 154: unrestricted
+	rolePublisher         = "01"  // list 45: publisher
+	datePublication       = "01"  // list 163: publication date
+	formatYear            = "05"  // list 55: YYYY
+)
+
+// Message is an ONIX 3.0 message of products
+type Message struct {
+	Header   Header
+	Products []Product
+}
+
+// Header identifies the sender of a Message
+type Header struct {
+	XMLName      xml.Name `xml:"Header"`
+	SenderName   string   `xml:"Sender>SenderName"`
+	SentDateTime string   `xml:"SentDateTime"`
+}
+
+// Product is the record of a book, or the notification that it was deleted
+type Product struct {
+	XMLName            xml.Name            `xml:"Product"`
+	RecordReference    string              `xml:"RecordReference"`
+	NotificationType   string              `xml:"NotificationType"`
+	ProductIdentifiers []ProductIdentifier `xml:"ProductIdentifier"`
+	DescriptiveDetail  *DescriptiveDetail  `xml:"DescriptiveDetail,omitempty"`
+	CollateralDetail   *CollateralDetail   `xml:"CollateralDetail,omitempty"`
+	PublishingDetail   *PublishingDetail   `xml:"PublishingDetail,omitempty"`
+}
+
+// ProductIdentifier is an identifier of a Product
+type ProductIdentifier struct {
+	ProductIDType string `xml:"ProductIDType"`
+	IDTypeName    string `xml:"IDTypeName,omitempty"`
+	IDValue       string `xml:"IDValue"`
+}
+
+// DescriptiveDetail is block 1 of a Product: its form, title, contributors and extent
+type DescriptiveDetail struct {
+	ProductComposition string        `xml:"ProductComposition"`
+	ProductForm        string        `xml:"ProductForm"`
+	TitleDetail        TitleDetail   `xml:"TitleDetail"`
+	Contributors       []Contributor `xml:"Contributor"`
+	NoContributor      *struct{}     `xml:"NoContributor,omitempty"`
+	Extents            []Extent      `xml:"Extent"`
+}
+
+// TitleDetail is the title of a Product
+type TitleDetail struct {
+	TitleType         string `xml:"TitleType"`
+	TitleElementLevel string `xml:"TitleElement>TitleElementLevel"`
+	TitleText         string `xml:"TitleElement>TitleText"`
+}
+
+// Contributor is a person who contributed to a Product
+type Contributor struct {
+	SequenceNumber  int    `xml:"SequenceNumber"`
+	ContributorRole string `xml:"ContributorRole"`
+	PersonName      string `xml:"PersonName"`
+}
+
+// Extent is a measure of the content of a Product
+type Extent struct {
+	ExtentType  string `xml:"ExtentType"`
+	ExtentValue uint   `xml:"ExtentValue"`
+	ExtentUnit  string `xml:"ExtentUnit"`
+}
+
+// CollateralDetail is block 2 of a Product: its descriptive texts
+type CollateralDetail struct {
+	TextContents []TextContent `xml:"TextContent"`
+}
+
+// TextContent is a text describing a Product
+type TextContent struct {
+	TextType        string `xml:"TextType"`
+	ContentAudience string `xml:"ContentAudience"`
+	Text            string `xml:"Text"`
+}
+
+// PublishingDetail is block 4 of a Product: its publisher and publication date
+type PublishingDetail struct {
+	Publisher       Publisher        `xml:"Publisher"`
+	PublishingDates []PublishingDate `xml:"PublishingDate"`
+}
+
+// Publisher is the publisher of a Product
+type Publisher struct {
+	PublishingRole string `xml:"PublishingRole"`
+	PublisherName  string `xml:"PublisherName"`
+}
+
+// PublishingDate is a date in the publishing history of a Product
+type PublishingDate struct {
+	PublishingDateRole string `xml:"PublishingDateRole"`
+	Date               Date   `xml:"Date"`
+}
+
+// Date is a date in the format named by its dateformat attribute
+type Date struct {
+	Format string `xml:"dateformat,attr"`
+	Value  string `xml:",chardata"`
+}
+
+// NewMessage builds a message sent at sentAt with a product for each book,
+// a delete notification for each soft deleted one and for each of removed
+func NewMessage(sentAt time.Time, books []book.Book, removed []book.Book) *Message {
+	message := &Message{
+		Header:   Header{SenderName: SenderName, SentDateTime: sentAt.UTC().Format("20060102T1504Z")},
+		Products: make([]Product, 0, len(books)+len(removed)),
+	}
+	for i := range books {
+		message.Products = append(message.Products, FromBook(&books[i]))
+	}
+	for i := range removed {
+		message.Products = append(message.Products, DeleteNotification(&removed[i]))
+	}
+	return message
+}
+
+// DeleteNotification maps a book to the notification that its product is withdrawn from the feed
+func DeleteNotification(b *book.Book) Product {
+	return Product{
+		RecordReference:    RecordReference(b.ID),
+		NotificationType:   notificationDelete,
+		ProductIdentifiers: identifiers(b),
+	}
+}
+
+// FromBook maps a book to an ONIX product, or to a delete notification when it is soft deleted
+func FromBook(b *book.Book) Product {
+	if b.DeletedAt.Valid {
+		return DeleteNotification(b)
+	}
+	product := Product{
+		RecordReference:    RecordReference(b.ID),
+		NotificationType:   notificationConfirmed,
+		ProductIdentifiers: identifiers(b),
+	}
+
+	detail := &DescriptiveDetail{
+		ProductComposition: singleItem,
+		ProductForm:        formBook,
+		TitleDetail:        TitleDetail{TitleType: distinctiveTitle, TitleElementLevel: productLevel, TitleText: b.Title},
+	}
+	for i, a := range b.Authors {
+		detail.Contributors = append(detail.Contributors, Contributor{SequenceNumber: i + 1, ContributorRole: roleAuthor, PersonName: a.Name})
+	}
+	if len(detail.Contributors) == 0 {
+		detail.NoContributor = &struct{}{}
+	}
+	if b.Pages > 0 {
+		detail.Extents = []Extent{{ExtentType: mainContentPages, ExtentValue: b.Pages, ExtentUnit: unitPages}}
+	}
+	product.DescriptiveDetail = detail
+
+	if b.Description != "" {
+		product.CollateralDetail = &CollateralDetail{TextContents: []TextContent{
+			{TextType: textDescription, ContentAudience: audienceUnrestricted, Text: b.Description},
+		}}
+	}
+
+	product.PublishingDetail = &PublishingDetail{Publisher: Publisher{PublishingRole: rolePublisher, PublisherName: b.Publisher.Name}}
+	if b.Year > 0 {
+		product.PublishingDetail.PublishingDates = []PublishingDate{
+			{PublishingDateRole: datePublication, Date: Date{Format: formatYear, Value: strconv.FormatUint(uint64(b.Year), 10)}},
+		}
+	}
+	return product
+}
+
+// RecordReference returns the persistent reference of the product of a book
+func RecordReference(bookID uint) string {
+	return fmt.Sprintf("book-catalog:book:%d", bookID)
+}
+
+// identifiers returns the ISBNs of a book, or its ID as a proprietary identifier when it has none
+func identifiers(b *book.Book) []ProductIdentifier {
+	var ids []ProductIdentifier
+	if b.ISBN13 != "" {
+		ids = append(ids, ProductIdentifier{ProductIDType: idISBN13, IDValue: b.ISBN13})
+	}
+	if b.ISBN10 != "" {
+		ids = append(ids, ProductIdentifier{ProductIDType: idISBN10, IDValue: b.ISBN10})
+	}
+	if len(ids) == 0 {
+		ids = append(ids, ProductIdentifier{ProductIDType: idProprietary, IDTypeName: "Book ID", IDValue: strconv.FormatUint(uint64(b.ID), 10)})
+	}
+	return ids
+}
+
+// Encode writes the message to w one product at a time
+func (m *Message) Encode(w io.Writer) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	root := xml.StartElement{
+		Name: xml.Name{Local: "ONIXMessage"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "release"}, Value: Release}, {Name: xml.Name{Local: "xmlns"}, Value: Namespace}},
+	}
+	if err := encoder.EncodeToken(root); err != nil {
+		return err
+	}
+	if err := encoder.Encode(m.Header); err != nil {
+		return err
+	}
+	for i := range m.Products {
+		if err := encoder.Encode(m.Products[i]); err != nil {
+			return err
+		}
+	}
+	if err := encoder.EncodeToken(root.End()); err != nil {
+		return err
+	}
+	if err := encoder.Flush(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
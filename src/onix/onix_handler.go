@@ -0,0 +1,61 @@
+package onix
+
+import (
+	"bufio"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+)
+
+// ONIXHandler handles HTTP requests for ONIX feeds
+type ONIXHandler struct {
+	service ONIXService
+}
+
+// NewONIXHandler creates a new instance of ONIXHandler
+func NewONIXHandler(service ONIXService) *ONIXHandler {
+	return &ONIXHandler{service: service}
+}
+
+// GetPublisherFeed handles GET /publishers/:id/onix request, streaming the ONIX message of the publisher
+func (h *ONIXHandler) GetPublisherFeed(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid publisher ID")
+	}
+	since, err := Since(c)
+	if err != nil {
+		return err
+	}
+
+	message, err := h.service.GetPublisherFeed(c.UserContext(), uint(id), since)
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, ContentType)
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		_ = message.Encode(w)
+		_ = w.Flush()
+	})
+	return nil
+}
+
+// Since parses the since query parameter, an RFC 3339 time, returning the zero time when it is not given
+func Since(c *fiber.Ctx) (time.Time, error) {
+	raw := c.Query("since")
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	since, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, apperror.BadRequest("since", err)
+	}
+	return since, nil
+}
+
+// RegisterRoutes registers the ONIX routes
+func (h *ONIXHandler) RegisterRoutes(app *fiber.App) {
+	app.Get("/api/v1/publishers/:id/onix", h.GetPublisherFeed)
+}
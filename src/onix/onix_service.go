@@ -0,0 +1,54 @@
+package onix
+
+import (
+	"context"
+	"time"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/history"
+	"github.com/tedysaputro/book-catalog-with-go/src/publisher"
+)
+
+// ONIXService defines the interface for building ONIX feeds
+type ONIXService interface {
+	GetPublisherFeed(ctx context.Context, publisherID uint, since time.Time) (*Message, error)
+}
+
+type onixServiceImpl struct {
+	publishers publisher.PublisherRepository
+	books      book.BookRepository
+	history    history.HistoryRepository
+}
+
+// NewONIXService creates a new instance of ONIXService. history tells the books that moved
+// to another publisher.
+func NewONIXService(publishers publisher.PublisherRepository, books book.BookRepository, history history.HistoryRepository) ONIXService {
+	return &onixServiceImpl{publishers: publishers, books: books, history: history}
+}
+
+// GetPublisherFeed builds the ONIX message of the live books of a publisher. When since is set,
+// the message is a delta of the books changed since then, with delete notifications for the
+// books deleted or moved to another publisher since then.
+func (s *onixServiceImpl) GetPublisherFeed(ctx context.Context, publisherID uint, since time.Time) (*Message, error) {
+	if _, err := s.publishers.FindByID(ctx, publisherID); err != nil {
+		return nil, err
+	}
+
+	if since.IsZero() {
+		books, err := s.books.FindAllByPublisher(ctx, publisherID)
+		if err != nil {
+			return nil, err
+		}
+		return NewMessage(time.Now(), books, nil), nil
+	}
+
+	books, err := s.books.FindChangedByPublisher(ctx, publisherID, since)
+	if err != nil {
+		return nil, err
+	}
+	moved, err := book.FindMovedFromPublisher(ctx, s.books, s.history, publisherID, since)
+	if err != nil {
+		return nil, err
+	}
+	return NewMessage(time.Now(), books, moved), nil
+}
@@ -0,0 +1,192 @@
+package opds
+
+import (
+	"encoding/xml"
+	"strconv"
+	"time"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+)
+
+const (
+	atomNamespace       = "http://www.w3.org/2005/Atom"
+	dcTermsNamespace    = "http://purl.org/dc/terms/"
+	openSearchNamespace = "http://a9.com/-/spec/opensearch/1.1/"
+)
+
+// URLs are the URLs feeds link to: the root of the OPDS version served and the API
+type URLs struct {
+	Root string
+	API  string
+}
+
+type atomFeed struct {
+	XMLName      xml.Name    `xml:"feed"`
+	Xmlns        string      `xml:"xmlns,attr"`
+	DC           string      `xml:"xmlns:dc,attr"`
+	OpenSearch   string      `xml:"xmlns:opensearch,attr"`
+	ID           string      `xml:"id"`
+	Title        string      `xml:"title"`
+	Updated      string      `xml:"updated"`
+	Author       atomPerson  `xml:"author"`
+	Links        []atomLink  `xml:"link"`
+	TotalResults *uint64     `xml:"opensearch:totalResults,omitempty"`
+	ItemsPerPage uint        `xml:"opensearch:itemsPerPage,omitempty"`
+	Entries      []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	Title       string         `xml:"title"`
+	ID          string         `xml:"id"`
+	Updated     string         `xml:"updated"`
+	Authors     []atomPerson   `xml:"author"`
+	Publisher   string         `xml:"dc:publisher,omitempty"`
+	Issued      string         `xml:"dc:issued,omitempty"`
+	Identifiers []string       `xml:"dc:identifier"`
+	Categories  []atomCategory `xml:"category"`
+	Summary     *atomText      `xml:"summary,omitempty"`
+	Content     *atomText      `xml:"content,omitempty"`
+	Links       []atomLink     `xml:"link"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+type atomLink struct {
+	Rel   string `xml:"rel,attr,omitempty"`
+	Href  string `xml:"href,attr"`
+	Type  string `xml:"type,attr,omitempty"`
+	Title string `xml:"title,attr,omitempty"`
+}
+
+type atomCategory struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr,omitempty"`
+}
+
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// Atom renders the feed as an OPDS 1.2 Atom document
+func Atom(feed *Feed, urls URLs) ([]byte, error) {
+	self := feed.PageHref(urls.Root, max(feed.Page.Page, 1))
+	doc := atomFeed{
+		Xmlns:      atomNamespace,
+		DC:         dcTermsNamespace,
+		OpenSearch: openSearchNamespace,
+		ID:         urls.Root + feed.Path,
+		Title:      feed.Title,
+		Updated:    atomTime(feed.Updated),
+		Author:     atomPerson{Name: CatalogTitle, URI: urls.Root},
+		Links: []atomLink{
+			{Rel: "self", Href: self, Type: atomType(feed.Kind)},
+			{Rel: "start", Href: urls.Root, Type: NavigationType, Title: CatalogTitle},
+			{Rel: "search", Href: urls.Root + "/opensearch.xml", Type: OpenSearchType},
+		},
+	}
+	if feed.Path != "" {
+		doc.Links = append(doc.Links, atomLink{Rel: "up", Href: urls.Root, Type: NavigationType})
+	}
+	for _, link := range feed.pageLinks() {
+		doc.Links = append(doc.Links, atomLink{Rel: link.Rel, Href: feed.PageHref(urls.Root, link.Page), Type: atomType(feed.Kind)})
+	}
+
+	for _, entry := range feed.Entries {
+		doc.Entries = append(doc.Entries, atomEntry{
+			Title:   entry.Title,
+			ID:      urls.Root + entry.Path,
+			Updated: atomTime(feed.Updated),
+			Content: &atomText{Type: "text", Value: entry.Summary},
+			Links:   []atomLink{{Rel: entry.Rel, Href: urls.Root + entry.Path, Type: atomType(entry.Kind)}},
+		})
+	}
+	if feed.paged() {
+		total := feed.Total
+		doc.TotalResults = &total
+		doc.ItemsPerPage = feed.Page.PageSize
+	}
+	for i := range feed.Books {
+		doc.Entries = append(doc.Entries, atomBook(&feed.Books[i], urls))
+	}
+
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// atomBook maps a book to the entry of an acquisition feed
+func atomBook(b *book.Book, urls URLs) atomEntry {
+	href := bookHref(b, urls)
+	entry := atomEntry{
+		Title:       b.Title,
+		ID:          href,
+		Updated:     atomTime(b.UpdatedAt),
+		Publisher:   b.Publisher.Name,
+		Identifiers: identifiers(b),
+		Links:       []atomLink{{Rel: RelAcquisition, Href: href, Type: "application/json"}},
+	}
+	if b.Year > 0 {
+		entry.Issued = strconv.FormatUint(uint64(b.Year), 10)
+	}
+	for _, a := range b.Authors {
+		entry.Authors = append(entry.Authors, atomPerson{Name: a.Name, URI: urls.Root + authorPath(a.ID)})
+	}
+	for _, c := range b.Categories {
+		entry.Categories = append(entry.Categories, atomCategory{Term: c.Code, Label: c.Name})
+	}
+	if b.Description != "" {
+		entry.Summary = &atomText{Type: "text", Value: b.Description}
+	}
+	return entry
+}
+
+// atomType returns the media type of an Atom feed of kind
+func atomType(kind Kind) string {
+	if kind == KindAcquisition {
+		return AcquisitionType
+	}
+	return NavigationType
+}
+
+// atomTime formats t as an Atom date
+func atomTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// openSearchDescription describes the title search of the OPDS 1.2 catalog
+type openSearchDescription struct {
+	XMLName        xml.Name      `xml:"OpenSearchDescription"`
+	Xmlns          string        `xml:"xmlns,attr"`
+	ShortName      string        `xml:"ShortName"`
+	Description    string        `xml:"Description"`
+	InputEncoding  string        `xml:"InputEncoding"`
+	OutputEncoding string        `xml:"OutputEncoding"`
+	URL            openSearchURL `xml:"Url"`
+}
+
+type openSearchURL struct {
+	Type     string `xml:"type,attr"`
+	Template string `xml:"template,attr"`
+}
+
+// OpenSearch renders the OpenSearch description of the title search under root
+func OpenSearch(root string) ([]byte, error) {
+	body, err := xml.MarshalIndent(openSearchDescription{
+		Xmlns:          openSearchNamespace,
+		ShortName:      CatalogTitle,
+		Description:    "Search the books of the catalog by title",
+		InputEncoding:  "UTF-8",
+		OutputEncoding: "UTF-8",
+		URL:            openSearchURL{Type: AcquisitionType, Template: root + "/search?q={searchTerms}&page={startPage?}"},
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
@@ -0,0 +1,162 @@
+package opds
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+)
+
+const (
+	// NavigationType is the media type of OPDS 1.2 navigation feeds
+	NavigationType = "application/atom+xml;profile=opds-catalog;kind=navigation"
+	// AcquisitionType is the media type of OPDS 1.2 acquisition feeds
+	AcquisitionType = "application/atom+xml;profile=opds-catalog;kind=acquisition"
+	// OPDS2Type is the media type of OPDS 2.0 feeds
+	OPDS2Type = "application/opds+json"
+	// OpenSearchType is the media type of OpenSearch description documents
+	OpenSearchType = "application/opensearchdescription+xml"
+	// CatalogTitle is the title of the root feed
+	CatalogTitle = "Book Catalog"
+
+	// RelAcquisition links a publication to the resource it is acquired from
+	RelAcquisition = "http://opds-spec.org/acquisition"
+	// RelNew links to the feed of the newest publications
+	RelNew = "http://opds-spec.org/sort/new"
+	// RelSubsection links to a feed one level down the catalog
+	RelSubsection = "subsection"
+)
+
+// Kind tells navigation feeds, which link to other feeds, from acquisition feeds, which list books
+type Kind string
+
+const (
+	KindNavigation  Kind = "navigation"
+	KindAcquisition Kind = "acquisition"
+)
+
+// Feed is a catalog feed, rendered as OPDS 1.2 Atom or OPDS 2.0 JSON
+type Feed struct {
+	// Path is the path of the feed under the OPDS root, empty for the root itself
+	Path    string
+	Title   string
+	Kind    Kind
+	Updated time.Time
+	// Entries are the links of a navigation feed
+	Entries []Entry
+	// Books are the publications of an acquisition feed
+	Books []book.Book
+	// Page and Total page the entries or books, the root feed has a zero Page
+	Page  pagination.Request
+	Total uint64
+	// Query are the search terms of a search feed
+	Query string
+}
+
+// Entry is a link of a navigation feed to another feed
+type Entry struct {
+	Path    string
+	Title   string
+	Summary string
+	Kind    Kind
+	Rel     string
+}
+
+// newAcquisitionFeed builds an acquisition feed of a page of books, updated when its newest book was
+func newAcquisitionFeed(path, title string, books []book.Book, page pagination.Request, total uint64) *Feed {
+	feed := &Feed{Path: path, Title: title, Kind: KindAcquisition, Books: books, Page: page, Total: total, Updated: time.Now()}
+	if len(books) > 0 {
+		feed.Updated = books[0].UpdatedAt
+		for _, b := range books[1:] {
+			if b.UpdatedAt.After(feed.Updated) {
+				feed.Updated = b.UpdatedAt
+			}
+		}
+	}
+	return feed
+}
+
+// LastPage returns the number of the last page of the feed
+func (f *Feed) LastPage() uint {
+	if f.Total == 0 || f.Page.PageSize == 0 {
+		return 1
+	}
+	return uint((f.Total + uint64(f.Page.PageSize) - 1) / uint64(f.Page.PageSize))
+}
+
+// PageHref returns the URL of a page of the feed under root, keeping its page size and search terms
+func (f *Feed) PageHref(root string, page uint) string {
+	query := url.Values{}
+	if f.Query != "" {
+		query.Set("q", f.Query)
+	}
+	if page > 1 {
+		query.Set("page", strconv.FormatUint(uint64(page), 10))
+	}
+	if f.Page.PageSize != 0 && f.Page.PageSize != pagination.DefaultPageSize {
+		query.Set("page_size", strconv.FormatUint(uint64(f.Page.PageSize), 10))
+	}
+	if len(query) == 0 {
+		return root + f.Path
+	}
+	return root + f.Path + "?" + query.Encode()
+}
+
+// pageLink is a link to another page of a feed
+type pageLink struct {
+	Rel  string
+	Page uint
+}
+
+// paged reports whether the feed is a page of a longer list
+func (f *Feed) paged() bool {
+	return f.Page.PageSize != 0
+}
+
+// pageLinks returns the links to the first, previous, next and last pages of the feed
+func (f *Feed) pageLinks() []pageLink {
+	if !f.paged() {
+		return nil
+	}
+	links := []pageLink{{Rel: "first", Page: 1}}
+	if f.Page.Page > 1 {
+		links = append(links, pageLink{Rel: "previous", Page: f.Page.Page - 1})
+	}
+	if f.Page.Page < f.LastPage() {
+		links = append(links, pageLink{Rel: "next", Page: f.Page.Page + 1})
+	}
+	return append(links, pageLink{Rel: "last", Page: f.LastPage()})
+}
+
+// authorPath returns the path of the acquisition feed of an author
+func authorPath(id uint) string {
+	return "/authors/" + strconv.FormatUint(uint64(id), 10)
+}
+
+// publisherPath returns the path of the acquisition feed of a publisher
+func publisherPath(id uint) string {
+	return "/publishers/" + strconv.FormatUint(uint64(id), 10)
+}
+
+// categoryPath returns the path of the acquisition feed of a category
+func categoryPath(code string) string {
+	return "/categories/" + url.PathEscape(code)
+}
+
+// bookHref returns the URL of a book in the API
+func bookHref(b *book.Book, urls URLs) string {
+	return urls.API + "/books/" + strconv.FormatUint(uint64(b.ID), 10)
+}
+
+// identifiers returns the ISBNs of a book as URNs
+func identifiers(b *book.Book) []string {
+	var ids []string
+	for _, isbn := range []string{b.ISBN13, b.ISBN10} {
+		if isbn != "" {
+			ids = append(ids, "urn:isbn:"+isbn)
+		}
+	}
+	return ids
+}
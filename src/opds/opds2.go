@@ -0,0 +1,138 @@
+package opds
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+)
+
+type opds2Feed struct {
+	Metadata     opds2Metadata       `json:"metadata"`
+	Links        []opds2Link         `json:"links"`
+	Navigation   []opds2Link         `json:"navigation,omitempty"`
+	Publications *[]opds2Publication `json:"publications,omitempty"`
+}
+
+type opds2Metadata struct {
+	Title         string  `json:"title"`
+	Modified      string  `json:"modified,omitempty"`
+	NumberOfItems *uint64 `json:"numberOfItems,omitempty"`
+	ItemsPerPage  uint    `json:"itemsPerPage,omitempty"`
+	CurrentPage   uint    `json:"currentPage,omitempty"`
+}
+
+type opds2Link struct {
+	Rel       string `json:"rel,omitempty"`
+	Href      string `json:"href"`
+	Type      string `json:"type,omitempty"`
+	Title     string `json:"title,omitempty"`
+	Templated bool   `json:"templated,omitempty"`
+}
+
+type opds2Publication struct {
+	Metadata opds2PublicationMetadata `json:"metadata"`
+	Links    []opds2Link              `json:"links"`
+}
+
+type opds2PublicationMetadata struct {
+	Type          string         `json:"@type"`
+	Title         string         `json:"title"`
+	Identifier    string         `json:"identifier,omitempty"`
+	Author        []opds2Contrib `json:"author,omitempty"`
+	Publisher     []opds2Contrib `json:"publisher,omitempty"`
+	Published     string         `json:"published,omitempty"`
+	Modified      string         `json:"modified"`
+	Description   string         `json:"description,omitempty"`
+	NumberOfPages uint           `json:"numberOfPages,omitempty"`
+	Subject       []opds2Subject `json:"subject,omitempty"`
+}
+
+type opds2Contrib struct {
+	Name  string      `json:"name"`
+	Links []opds2Link `json:"links,omitempty"`
+}
+
+type opds2Subject struct {
+	Name  string      `json:"name"`
+	Code  string      `json:"code"`
+	Links []opds2Link `json:"links,omitempty"`
+}
+
+// OPDS2 renders the feed as an OPDS 2.0 JSON document
+func OPDS2(feed *Feed, urls URLs) ([]byte, error) {
+	doc := opds2Feed{
+		Metadata: opds2Metadata{Title: feed.Title, Modified: atomTime(feed.Updated)},
+		Links: []opds2Link{
+			{Rel: "self", Href: feed.PageHref(urls.Root, max(feed.Page.Page, 1)), Type: OPDS2Type},
+			{Rel: "start", Href: urls.Root, Type: OPDS2Type, Title: CatalogTitle},
+			{Rel: "search", Href: urls.Root + "/search{?q}", Type: OPDS2Type, Templated: true},
+		},
+	}
+	if feed.Path != "" {
+		doc.Links = append(doc.Links, opds2Link{Rel: "up", Href: urls.Root, Type: OPDS2Type})
+	}
+	for _, link := range feed.pageLinks() {
+		doc.Links = append(doc.Links, opds2Link{Rel: link.Rel, Href: feed.PageHref(urls.Root, link.Page), Type: OPDS2Type})
+	}
+
+	for _, entry := range feed.Entries {
+		doc.Navigation = append(doc.Navigation, opds2Link{Rel: entry.Rel, Href: urls.Root + entry.Path, Type: OPDS2Type, Title: entry.Title})
+	}
+	if feed.paged() {
+		total := feed.Total
+		doc.Metadata.NumberOfItems = &total
+		doc.Metadata.ItemsPerPage = feed.Page.PageSize
+		doc.Metadata.CurrentPage = feed.Page.Page
+	}
+	if feed.Kind == KindAcquisition {
+		publications := make([]opds2Publication, len(feed.Books))
+		for i := range feed.Books {
+			publications[i] = opds2Book(&feed.Books[i], urls)
+		}
+		doc.Publications = &publications
+	}
+
+	return json.Marshal(doc)
+}
+
+// opds2Book maps a book to an OPDS 2.0 publication
+func opds2Book(b *book.Book, urls URLs) opds2Publication {
+	metadata := opds2PublicationMetadata{
+		Type:          "http://schema.org/Book",
+		Title:         b.Title,
+		Modified:      atomTime(b.UpdatedAt),
+		Description:   b.Description,
+		NumberOfPages: b.Pages,
+	}
+	if ids := identifiers(b); len(ids) > 0 {
+		metadata.Identifier = ids[0]
+	}
+	if b.Year > 0 {
+		metadata.Published = strconv.FormatUint(uint64(b.Year), 10)
+	}
+	for _, a := range b.Authors {
+		metadata.Author = append(metadata.Author, opds2Contrib{
+			Name:  a.Name,
+			Links: []opds2Link{{Href: urls.Root + authorPath(a.ID), Type: OPDS2Type}},
+		})
+	}
+	if b.Publisher.ID != 0 {
+		metadata.Publisher = []opds2Contrib{{
+			Name:  b.Publisher.Name,
+			Links: []opds2Link{{Href: urls.Root + publisherPath(b.Publisher.ID), Type: OPDS2Type}},
+		}}
+	}
+	for _, c := range b.Categories {
+		metadata.Subject = append(metadata.Subject, opds2Subject{
+			Name:  c.Name,
+			Code:  c.Code,
+			Links: []opds2Link{{Href: urls.Root + categoryPath(c.Code), Type: OPDS2Type}},
+		})
+	}
+
+	return opds2Publication{
+		Metadata: metadata,
+		Links:    []opds2Link{{Rel: RelAcquisition, Href: bookHref(b, urls), Type: "application/json"}},
+	}
+}
@@ -0,0 +1,146 @@
+package opds
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+)
+
+const (
+	// Root is the path of the OPDS 1.2 catalog
+	Root = "/opds"
+	// RootV2 is the path of the OPDS 2.0 catalog
+	RootV2 = "/opds/v2"
+)
+
+// OPDSHandler handles HTTP requests for the OPDS catalog
+type OPDSHandler struct {
+	service OPDSService
+}
+
+// NewOPDSHandler creates a new instance of OPDSHandler
+func NewOPDSHandler(service OPDSService) *OPDSHandler {
+	return &OPDSHandler{service: service}
+}
+
+// feedFunc builds the feed a request asks for
+type feedFunc func(c *fiber.Ctx, page pagination.Request) (*Feed, error)
+
+// renderer renders a feed with the links of one OPDS version
+type renderer struct {
+	root        string
+	render      func(feed *Feed, urls URLs) ([]byte, error)
+	contentType func(feed *Feed) string
+}
+
+var (
+	atomRenderer  = renderer{root: Root, render: Atom, contentType: func(feed *Feed) string { return atomType(feed.Kind) }}
+	opds2Renderer = renderer{root: RootV2, render: OPDS2, contentType: func(*Feed) string { return OPDS2Type }}
+)
+
+// serve returns the handler answering with the feed built by build, rendered by r
+func (h *OPDSHandler) serve(r renderer, build feedFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		page, err := pagination.FromQuery(c, nil)
+		if err != nil {
+			return err
+		}
+
+		feed, err := build(c, page)
+		if err != nil {
+			return err
+		}
+
+		body, err := r.render(feed, URLs{Root: c.BaseURL() + r.root, API: c.BaseURL() + "/api/v1"})
+		if err != nil {
+			return err
+		}
+		c.Set(fiber.HeaderContentType, r.contentType(feed))
+		return c.Send(body)
+	}
+}
+
+// GetRoot handles GET /opds request
+func (h *OPDSHandler) GetRoot(c *fiber.Ctx, _ pagination.Request) (*Feed, error) {
+	return h.service.GetRoot(c.UserContext())
+}
+
+// GetNewest handles GET /opds/new request
+func (h *OPDSHandler) GetNewest(c *fiber.Ctx, page pagination.Request) (*Feed, error) {
+	return h.service.GetNewest(c.UserContext(), page)
+}
+
+// GetCategories handles GET /opds/categories request
+func (h *OPDSHandler) GetCategories(c *fiber.Ctx, page pagination.Request) (*Feed, error) {
+	return h.service.GetCategories(c.UserContext(), page)
+}
+
+// GetCategoryBooks handles GET /opds/categories/:code request
+func (h *OPDSHandler) GetCategoryBooks(c *fiber.Ctx, page pagination.Request) (*Feed, error) {
+	return h.service.GetCategoryBooks(c.UserContext(), c.Params("code"), page)
+}
+
+// GetAuthors handles GET /opds/authors request
+func (h *OPDSHandler) GetAuthors(c *fiber.Ctx, page pagination.Request) (*Feed, error) {
+	return h.service.GetAuthors(c.UserContext(), page)
+}
+
+// GetAuthorBooks handles GET /opds/authors/:id request
+func (h *OPDSHandler) GetAuthorBooks(c *fiber.Ctx, page pagination.Request) (*Feed, error) {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid author ID")
+	}
+	return h.service.GetAuthorBooks(c.UserContext(), uint(id), page)
+}
+
+// GetPublishers handles GET /opds/publishers request
+func (h *OPDSHandler) GetPublishers(c *fiber.Ctx, page pagination.Request) (*Feed, error) {
+	return h.service.GetPublishers(c.UserContext(), page)
+}
+
+// GetPublisherBooks handles GET /opds/publishers/:id request
+func (h *OPDSHandler) GetPublisherBooks(c *fiber.Ctx, page pagination.Request) (*Feed, error) {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid publisher ID")
+	}
+	return h.service.GetPublisherBooks(c.UserContext(), uint(id), page)
+}
+
+// Search handles GET /opds/search request
+func (h *OPDSHandler) Search(c *fiber.Ctx, page pagination.Request) (*Feed, error) {
+	q := strings.TrimSpace(c.Query("q"))
+	if q == "" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Query parameter q is required")
+	}
+	return h.service.Search(c.UserContext(), q, page)
+}
+
+// GetOpenSearch handles GET /opds/opensearch.xml request
+func (h *OPDSHandler) GetOpenSearch(c *fiber.Ctx) error {
+	body, err := OpenSearch(c.BaseURL() + Root)
+	if err != nil {
+		return err
+	}
+	c.Set(fiber.HeaderContentType, OpenSearchType)
+	return c.Send(body)
+}
+
+// RegisterRoutes registers the OPDS 1.2 routes under /opds and the OPDS 2.0 routes under /opds/v2
+func (h *OPDSHandler) RegisterRoutes(app *fiber.App) {
+	app.Get(Root+"/opensearch.xml", h.GetOpenSearch)
+	for _, r := range []renderer{opds2Renderer, atomRenderer} {
+		catalog := app.Group(r.root)
+		catalog.Get("/", h.serve(r, h.GetRoot))
+		catalog.Get("/new", h.serve(r, h.GetNewest))
+		catalog.Get("/categories", h.serve(r, h.GetCategories))
+		catalog.Get("/categories/:code", h.serve(r, h.GetCategoryBooks))
+		catalog.Get("/authors", h.serve(r, h.GetAuthors))
+		catalog.Get("/authors/:id", h.serve(r, h.GetAuthorBooks))
+		catalog.Get("/publishers", h.serve(r, h.GetPublishers))
+		catalog.Get("/publishers/:id", h.serve(r, h.GetPublisherBooks))
+		catalog.Get("/search", h.serve(r, h.Search))
+	}
+}
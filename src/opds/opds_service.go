@@ -0,0 +1,163 @@
+package opds
+
+import (
+	"context"
+	"time"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/author"
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/category"
+	"github.com/tedysaputro/book-catalog-with-go/src/fuzzy"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/publisher"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+)
+
+// OPDSService defines the interface for building the feeds of the OPDS catalog
+type OPDSService interface {
+	GetRoot(ctx context.Context) (*Feed, error)
+	GetNewest(ctx context.Context, page pagination.Request) (*Feed, error)
+	GetCategories(ctx context.Context, page pagination.Request) (*Feed, error)
+	GetCategoryBooks(ctx context.Context, code string, page pagination.Request) (*Feed, error)
+	GetAuthors(ctx context.Context, page pagination.Request) (*Feed, error)
+	GetAuthorBooks(ctx context.Context, id uint, page pagination.Request) (*Feed, error)
+	GetPublishers(ctx context.Context, page pagination.Request) (*Feed, error)
+	GetPublisherBooks(ctx context.Context, id uint, page pagination.Request) (*Feed, error)
+	Search(ctx context.Context, q string, page pagination.Request) (*Feed, error)
+}
+
+type opdsServiceImpl struct {
+	books      book.BookRepository
+	authors    author.AuthorRepository
+	publishers publisher.PublisherRepository
+	categories category.CategoryRepository
+}
+
+// NewOPDSService creates a new instance of OPDSService
+func NewOPDSService(books book.BookRepository, authors author.AuthorRepository, publishers publisher.PublisherRepository, categories category.CategoryRepository) OPDSService {
+	return &opdsServiceImpl{books: books, authors: authors, publishers: publishers, categories: categories}
+}
+
+// GetRoot builds the root navigation feed
+func (s *opdsServiceImpl) GetRoot(ctx context.Context) (*Feed, error) {
+	return &Feed{
+		Title:   CatalogTitle,
+		Kind:    KindNavigation,
+		Updated: time.Now(),
+		Entries: []Entry{
+			{Path: "/new", Title: "New books", Summary: "The books most recently added to the catalog", Kind: KindAcquisition, Rel: RelNew},
+			{Path: "/categories", Title: "By category", Summary: "Browse the books by category", Kind: KindNavigation, Rel: RelSubsection},
+			{Path: "/authors", Title: "By author", Summary: "Browse the books by author", Kind: KindNavigation, Rel: RelSubsection},
+			{Path: "/publishers", Title: "By publisher", Summary: "Browse the books by publisher", Kind: KindNavigation, Rel: RelSubsection},
+		},
+	}, nil
+}
+
+// GetNewest builds the acquisition feed of the books, newest first
+func (s *opdsServiceImpl) GetNewest(ctx context.Context, page pagination.Request) (*Feed, error) {
+	sort, err := sorting.Parse("-created_at", book.SortFields)
+	if err != nil {
+		return nil, err
+	}
+	return s.bookFeed(ctx, "/new", "New books", page, sort.Stable(book.SortFields), book.BookFilter{})
+}
+
+// GetCategories builds the navigation feed of the categories
+func (s *opdsServiceImpl) GetCategories(ctx context.Context, page pagination.Request) (*Feed, error) {
+	categories, total, err := s.categories.FindAll(ctx, page, byName(category.SortFields), "", fuzzy.Options{})
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &Feed{Path: "/categories", Title: "By category", Kind: KindNavigation, Updated: time.Now(), Page: page, Total: total}
+	for _, c := range categories {
+		feed.Entries = append(feed.Entries, Entry{Path: categoryPath(c.Code), Title: c.Name, Summary: c.Description, Kind: KindAcquisition, Rel: RelSubsection})
+	}
+	return feed, nil
+}
+
+// GetCategoryBooks builds the acquisition feed of the books of a category
+func (s *opdsServiceImpl) GetCategoryBooks(ctx context.Context, code string, page pagination.Request) (*Feed, error) {
+	c, err := s.categories.FindByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	return s.bookFeed(ctx, categoryPath(c.Code), c.Name, page, byTitle(), book.BookFilter{CategoryCodes: []string{c.Code}})
+}
+
+// GetAuthors builds the navigation feed of the authors
+func (s *opdsServiceImpl) GetAuthors(ctx context.Context, page pagination.Request) (*Feed, error) {
+	authors, total, err := s.authors.FindAll(ctx, page, byName(author.SortFields), "", fuzzy.Options{})
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &Feed{Path: "/authors", Title: "By author", Kind: KindNavigation, Updated: time.Now(), Page: page, Total: total}
+	for _, a := range authors {
+		feed.Entries = append(feed.Entries, Entry{Path: authorPath(a.ID), Title: a.Name, Summary: a.Description, Kind: KindAcquisition, Rel: RelSubsection})
+	}
+	return feed, nil
+}
+
+// GetAuthorBooks builds the acquisition feed of the books of an author
+func (s *opdsServiceImpl) GetAuthorBooks(ctx context.Context, id uint, page pagination.Request) (*Feed, error) {
+	a, err := s.authors.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.bookFeed(ctx, authorPath(a.ID), a.Name, page, byTitle(), book.BookFilter{AuthorIDs: []uint{a.ID}})
+}
+
+// GetPublishers builds the navigation feed of the publishers
+func (s *opdsServiceImpl) GetPublishers(ctx context.Context, page pagination.Request) (*Feed, error) {
+	publishers, total, err := s.publishers.FindAll(ctx, page, byName(publisher.SortFields), "", fuzzy.Options{})
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &Feed{Path: "/publishers", Title: "By publisher", Kind: KindNavigation, Updated: time.Now(), Page: page, Total: total}
+	for _, p := range publishers {
+		feed.Entries = append(feed.Entries, Entry{Path: publisherPath(p.ID), Title: p.Name, Summary: p.Description, Kind: KindAcquisition, Rel: RelSubsection})
+	}
+	return feed, nil
+}
+
+// GetPublisherBooks builds the acquisition feed of the books of a publisher
+func (s *opdsServiceImpl) GetPublisherBooks(ctx context.Context, id uint, page pagination.Request) (*Feed, error) {
+	p, err := s.publishers.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.bookFeed(ctx, publisherPath(p.ID), p.Name, page, byTitle(), book.BookFilter{PublisherIDs: []uint{p.ID}})
+}
+
+// Search builds the acquisition feed of the books whose title contains q
+func (s *opdsServiceImpl) Search(ctx context.Context, q string, page pagination.Request) (*Feed, error) {
+	feed, err := s.bookFeed(ctx, "/search", "Search results for "+q, page, byTitle(), book.BookFilter{Title: q})
+	if err != nil {
+		return nil, err
+	}
+	feed.Query = q
+	return feed, nil
+}
+
+// bookFeed builds an acquisition feed of a page of the books matching filter
+func (s *opdsServiceImpl) bookFeed(ctx context.Context, path, title string, page pagination.Request, sort sorting.Spec, filter book.BookFilter) (*Feed, error) {
+	books, total, err := s.books.FindAll(ctx, page, sort, filter)
+	if err != nil {
+		return nil, err
+	}
+	return newAcquisitionFeed(path, title, books, page, total), nil
+}
+
+// byTitle orders books by title
+func byTitle() sorting.Spec {
+	sort, _ := sorting.Parse("title", book.SortFields)
+	return sort.Stable(book.SortFields)
+}
+
+// byName orders records with a name field by it
+func byName(fields sorting.Fields) sorting.Spec {
+	sort, _ := sorting.Parse("name", fields)
+	return sort.Stable(fields)
+}
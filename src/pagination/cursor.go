@@ -0,0 +1,89 @@
+package pagination
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+)
+
+var (
+	// ErrCursorUnsupported is reported for a cursor on a list that is ordered by relevance
+	ErrCursorUnsupported = errors.New("cursor paging is not supported for results ordered by relevance, use page")
+	// ErrCursorMalformed is reported for a cursor that was not issued by this API
+	ErrCursorMalformed = errors.New("malformed cursor")
+	// ErrCursorSortMismatch is reported for a cursor issued for another sort order
+	ErrCursorSortMismatch = errors.New("cursor does not match the sort order")
+)
+
+// Cursor marks a position in a sorted list. A page read with a cursor holds the items after
+// Key, or before it when Backward is set. An empty Key starts at the first, or last, item.
+type Cursor struct {
+	Sort     string        `json:"s"`
+	Key      []interface{} `json:"k,omitempty"`
+	Backward bool          `json:"b,omitempty"`
+}
+
+// Encode returns the opaque form of the cursor used in query parameters and responses
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses an opaque cursor and checks that it was issued for sort
+func DecodeCursor(raw string, sort sorting.Spec) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, ErrCursorMalformed
+	}
+
+	var cursor Cursor
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&cursor); err != nil {
+		return nil, ErrCursorMalformed
+	}
+	if cursor.Sort != sort.String() {
+		return nil, ErrCursorSortMismatch
+	}
+	if len(cursor.Key) > 0 && len(cursor.Key) != len(sort) {
+		return nil, ErrCursorMalformed
+	}
+
+	// Each value must fit the column it is compared with; numbers are kept as int64
+	for i, value := range cursor.Key {
+		key, ok := keyValue(value, sort[i].Column.Type)
+		if !ok {
+			return nil, ErrCursorMalformed
+		}
+		cursor.Key[i] = key
+	}
+	return &cursor, nil
+}
+
+// keyValue returns the decoded value of a key of a cursor for a column of type t, reporting
+// false when the value does not fit the column
+func keyValue(value interface{}, t sorting.Type) (interface{}, bool) {
+	switch t {
+	case sorting.Integer:
+		number, ok := value.(json.Number)
+		if !ok {
+			return nil, false
+		}
+		n, err := number.Int64()
+		return n, err == nil
+	case sorting.Time:
+		s, ok := value.(string)
+		if !ok {
+			return nil, false
+		}
+		_, err := time.Parse(time.RFC3339Nano, s)
+		return s, err == nil
+	default:
+		s, ok := value.(string)
+		return s, ok
+	}
+}
@@ -0,0 +1,278 @@
+package pagination
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// DefaultPageSize is the page size used when a request does not set one
+	DefaultPageSize uint = 10
+	// MaxPageSize is the largest page size a request may ask for
+	MaxPageSize uint = 100
+)
+
+// deprecatedParams lists the old page parameters with their replacement, by precedence
+var deprecatedParams = []struct {
+	Old         string
+	Replacement string
+}{
+	{Old: "p", Replacement: "page"},
+	{Old: "pages", Replacement: "page"},
+	{Old: "limit", Replacement: "page_size"},
+}
+
+// Request is the page a list request asks for, by page number or, when Cursor is set, by cursor
+type Request struct {
+	Page     uint
+	PageSize uint
+	Cursor   *Cursor
+}
+
+// Offset returns the number of items before the page
+func (r Request) Offset() int {
+	return int((r.Page - 1) * r.PageSize)
+}
+
+// Links are the URLs of the pages around a Page
+type Links struct {
+	Self  string `json:"self"`
+	First string `json:"first"`
+	Last  string `json:"last"`
+	Prev  string `json:"prev,omitempty"`
+	Next  string `json:"next,omitempty"`
+}
+
+// Page is the response envelope of every list endpoint
+type Page[T any] struct {
+	Data       []T    `json:"data"`
+	Page       uint   `json:"page,omitempty"`
+	PageSize   uint   `json:"page_size"`
+	Total      uint64 `json:"total"`
+	TotalPages uint64 `json:"total_pages"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+	Links      Links  `json:"links"`
+
+	request Request
+	more    bool
+}
+
+// FromQuery reads the page, page_size and cursor query parameters of c.
+// The deprecated p, pages and limit parameters are still accepted and flagged
+// with a Deprecation header. Page sizes above MaxPageSize are capped.
+// A cursor must have been issued for sort; lists ordered by relevance pass a nil sort and take no cursor.
+func FromQuery(c *fiber.Ctx, sort sorting.Spec) (Request, error) {
+	page, err := queryUint(c, "page", 1)
+	if err != nil {
+		return Request{}, err
+	}
+	pageSize, err := queryUint(c, "page_size", DefaultPageSize)
+	if err != nil {
+		return Request{}, err
+	}
+	request := Request{Page: page, PageSize: min(pageSize, MaxPageSize)}
+
+	raw := c.Query("cursor")
+	if raw == "" {
+		return request, nil
+	}
+	if sort == nil {
+		return Request{}, apperror.BadRequest("cursor", ErrCursorUnsupported)
+	}
+	for _, name := range []string{"page", "p", "pages"} {
+		if c.Query(name) != "" {
+			return Request{}, apperror.BadRequest("cursor", fmt.Errorf("cursor cannot be combined with %s", name))
+		}
+	}
+	if request.Cursor, err = DecodeCursor(raw, sort); err != nil {
+		return Request{}, apperror.BadRequest("cursor", err)
+	}
+	return request, nil
+}
+
+// Apply orders query by the rank expressions and sort and restricts it to the page.
+// A cursor page selects the rows after the cursor, or before it in reverse order, plus one
+// more row that tells New whether the list continues.
+func (r Request) Apply(query *gorm.DB, sort sorting.Spec, rank ...clause.Expr) *gorm.DB {
+	if r.Cursor == nil {
+		return sort.Apply(query, rank...).Offset(r.Offset()).Limit(int(r.PageSize))
+	}
+	if r.Cursor.Backward {
+		sort = sort.Reverse()
+	}
+	if len(r.Cursor.Key) > 0 {
+		query = query.Where(sort.After(r.Cursor.Key))
+	}
+	return sort.Apply(query, rank...).Limit(int(r.PageSize) + 1)
+}
+
+// queryUint reads the positive integer query parameter name, or its deprecated alias
+func queryUint(c *fiber.Ctx, name string, defaultValue uint) (uint, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		for _, param := range deprecatedParams {
+			if param.Replacement == name && c.Query(param.Old) != "" {
+				raw = c.Query(param.Old)
+				c.Set("Deprecation", "true")
+				c.Append(fiber.HeaderWarning, fmt.Sprintf(`299 - "%s is deprecated, use %s"`, param.Old, name))
+				name = param.Old
+				break
+			}
+		}
+	}
+	if raw == "" {
+		return defaultValue, nil
+	}
+
+	value, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || value < 1 {
+		return 0, apperror.BadRequest(name, errors.New("must be a positive integer"))
+	}
+	return uint(value), nil
+}
+
+// New builds the Page of data for request out of total items.
+// For a cursor request data holds the rows loaded by Apply, in the order they were loaded.
+func New[T any](data []T, request Request, total uint64) *Page[T] {
+	if data == nil {
+		data = []T{}
+	}
+	page, more := request.Page, false
+	if request.Cursor != nil {
+		page = 0
+		if more = len(data) > int(request.PageSize); more {
+			data = data[:request.PageSize]
+		}
+		if request.Cursor.Backward {
+			data = slices.Clone(data)
+			slices.Reverse(data)
+		}
+	}
+	totalPages := total / uint64(request.PageSize)
+	if total%uint64(request.PageSize) > 0 {
+		totalPages++
+	}
+	return &Page[T]{
+		Data:       data,
+		Page:       page,
+		PageSize:   request.PageSize,
+		Total:      total,
+		TotalPages: totalPages,
+		request:    request,
+		more:       more,
+	}
+}
+
+// WithCursors sets the cursors of the pages around p from the sort key of its first and last item,
+// as returned by value for each field of sort
+func (p *Page[T]) WithCursors(sort sorting.Spec, value func(item T, field string) interface{}) *Page[T] {
+	if len(p.Data) == 0 {
+		return p
+	}
+
+	hasPrev, hasNext := p.Page > 1, uint64(p.Page) < p.TotalPages
+	if cursor := p.request.Cursor; cursor != nil {
+		started := len(cursor.Key) > 0
+		hasPrev, hasNext = started, p.more
+		if cursor.Backward {
+			hasPrev, hasNext = p.more, started
+		}
+	}
+
+	encode := func(item T, backward bool) string {
+		key := make([]interface{}, len(sort))
+		for i, f := range sort {
+			key[i] = value(item, f.Name)
+		}
+		return Cursor{Sort: sort.String(), Key: key, Backward: backward}.Encode()
+	}
+	if hasPrev {
+		p.PrevCursor = encode(p.Data[0], true)
+	}
+	if hasNext {
+		p.NextCursor = encode(p.Data[len(p.Data)-1], false)
+	}
+	return p
+}
+
+// Map converts the items of page with convert, keeping its position
+func Map[T any, R any](page *Page[T], convert func(T) R) *Page[R] {
+	data := make([]R, len(page.Data))
+	for i, item := range page.Data {
+		data[i] = convert(item)
+	}
+	return &Page[R]{
+		Data:       data,
+		Page:       page.Page,
+		PageSize:   page.PageSize,
+		Total:      page.Total,
+		TotalPages: page.TotalPages,
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+		Links:      page.Links,
+		request:    page.request,
+		more:       page.more,
+	}
+}
+
+// WithLinks fills the links of page from the URL of the request it answers,
+// keeping every query parameter except the page ones.
+// The links of a cursor page use cursors as well.
+func (p *Page[T]) WithLinks(c *fiber.Ctx) *Page[T] {
+	query := url.Values{}
+	c.Context().QueryArgs().VisitAll(func(key []byte, value []byte) {
+		query.Add(string(key), string(value))
+	})
+	for _, param := range deprecatedParams {
+		query.Del(param.Old)
+	}
+	query.Set("page_size", strconv.FormatUint(uint64(p.PageSize), 10))
+
+	if cursor := p.request.Cursor; cursor != nil {
+		link := func(raw string) string {
+			query.Set("cursor", raw)
+			return c.Path() + "?" + query.Encode()
+		}
+		p.Links = Links{
+			Self:  link(c.Query("cursor")),
+			First: link(Cursor{Sort: cursor.Sort}.Encode()),
+			Last:  link(Cursor{Sort: cursor.Sort, Backward: true}.Encode()),
+		}
+		if p.PrevCursor != "" {
+			p.Links.Prev = link(p.PrevCursor)
+		}
+		if p.NextCursor != "" {
+			p.Links.Next = link(p.NextCursor)
+		}
+		return p
+	}
+
+	link := func(page uint64) string {
+		query.Set("page", strconv.FormatUint(page, 10))
+		return c.Path() + "?" + query.Encode()
+	}
+
+	lastPage := max(p.TotalPages, 1)
+	p.Links = Links{
+		Self:  link(uint64(p.Page)),
+		First: link(1),
+		Last:  link(lastPage),
+	}
+	if p.Page > 1 {
+		p.Links.Prev = link(min(uint64(p.Page)-1, lastPage))
+	}
+	if uint64(p.Page) < p.TotalPages {
+		p.Links.Next = link(uint64(p.Page) + 1)
+	}
+	return p
+}
@@ -0,0 +1,310 @@
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+)
+
+// operation is a single JSON Patch operation
+type operation struct {
+	Op    string
+	Path  string
+	From  string
+	Value interface{}
+}
+
+// operations is a JSON Patch, applied in order as a whole or not at all
+type operations []operation
+
+// parseOperations reads a JSON Patch document
+func parseOperations(raw []byte) (operations, error) {
+	var members []map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &members); err != nil {
+		return nil, err
+	}
+
+	ops := make(operations, len(members))
+	for i, m := range members {
+		op := &ops[i]
+		for name, target := range map[string]*string{"op": &op.Op, "path": &op.Path, "from": &op.From} {
+			if value, ok := m[name]; ok {
+				if err := json.Unmarshal(value, target); err != nil {
+					return nil, fmt.Errorf("operation %d: %s must be a string", i, name)
+				}
+			}
+		}
+		if _, ok := m["path"]; !ok {
+			return nil, fmt.Errorf("operation %d: path is required", i)
+		}
+
+		switch op.Op {
+		case "add", "replace", "test":
+			value, ok := m["value"]
+			if !ok {
+				return nil, fmt.Errorf("operation %d: %s requires a value", i, op.Op)
+			}
+			if err := decode(value, &op.Value); err != nil {
+				return nil, fmt.Errorf("operation %d: %w", i, err)
+			}
+		case "move", "copy":
+			if _, ok := m["from"]; !ok {
+				return nil, fmt.Errorf("operation %d: %s requires from", i, op.Op)
+			}
+		case "remove":
+		default:
+			return nil, fmt.Errorf("operation %d: unknown op %q", i, op.Op)
+		}
+	}
+	return ops, nil
+}
+
+// Apply implements Patch
+func (ops operations) Apply(target interface{}) error {
+	return apply(target, func(doc interface{}) (interface{}, error) {
+		for i, op := range ops {
+			var err error
+			if doc, err = op.apply(doc); err != nil {
+				var appErr *apperror.Error
+				if errors.As(err, &appErr) {
+					return nil, err
+				}
+				return nil, apperror.InvalidField("patch", fmt.Errorf("operation %d (%s %s): %w", i, op.Op, op.Path, err))
+			}
+		}
+		return doc, nil
+	})
+}
+
+// apply performs op on doc and returns the changed document
+func (op operation) apply(doc interface{}) (interface{}, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add":
+		return add(doc, path, op.Value)
+	case "remove":
+		doc, _, err := remove(doc, path)
+		return doc, err
+	case "replace":
+		doc, _, err := remove(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, path, op.Value)
+	case "test":
+		value, err := get(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !equal(value, op.Value) {
+			return nil, apperror.Conflict(fmt.Sprintf("patch test failed: %s does not have the expected value", op.Path))
+		}
+		return doc, nil
+	default:
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		var value interface{}
+		if op.Op == "move" {
+			if len(from) < len(path) && slices.Equal(from, path[:len(from)]) {
+				return nil, errors.New("a value cannot be moved into one of its children")
+			}
+			doc, value, err = remove(doc, from)
+		} else {
+			value, err = get(doc, from)
+			value = clone(value)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	}
+}
+
+// parsePointer splits a JSON Pointer (RFC 6901) into its unescaped reference tokens
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("path %q must start with /", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// get returns the value at path in doc
+func get(doc interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		switch node := doc.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("member %q does not exist", token)
+			}
+			doc = value
+		case []interface{}:
+			i, err := index(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			doc = node[i]
+		default:
+			return nil, fmt.Errorf("%q cannot be resolved in a scalar", token)
+		}
+	}
+	return doc, nil
+}
+
+// add sets the member or inserts the array element at path in doc
+func add(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	token, last := path[0], len(path) == 1
+	switch node := doc.(type) {
+	case map[string]interface{}:
+		if last {
+			node[token] = value
+			return node, nil
+		}
+		child, ok := node[token]
+		if !ok {
+			return nil, fmt.Errorf("member %q does not exist", token)
+		}
+		child, err := add(child, path[1:], value)
+		node[token] = child
+		return node, err
+	case []interface{}:
+		if last {
+			i := len(node)
+			if token != "-" {
+				var err error
+				if i, err = index(token, len(node)); err != nil {
+					return nil, err
+				}
+			}
+			return slices.Insert(node, i, value), nil
+		}
+		i, err := index(token, len(node)-1)
+		if err != nil {
+			return nil, err
+		}
+		node[i], err = add(node[i], path[1:], value)
+		return node, err
+	default:
+		return nil, fmt.Errorf("%q cannot be resolved in a scalar", token)
+	}
+}
+
+// remove deletes the member or array element at path in doc and returns it
+func remove(doc interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, doc, nil
+	}
+	token, last := path[0], len(path) == 1
+	switch node := doc.(type) {
+	case map[string]interface{}:
+		child, ok := node[token]
+		if !ok {
+			return nil, nil, fmt.Errorf("member %q does not exist", token)
+		}
+		if last {
+			delete(node, token)
+			return node, child, nil
+		}
+		child, removed, err := remove(child, path[1:])
+		node[token] = child
+		return node, removed, err
+	case []interface{}:
+		i, err := index(token, len(node)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		if last {
+			removed := node[i]
+			return slices.Delete(node, i, i+1), removed, nil
+		}
+		child, removed, err := remove(node[i], path[1:])
+		node[i] = child
+		return node, removed, err
+	default:
+		return nil, nil, fmt.Errorf("%q cannot be resolved in a scalar", token)
+	}
+}
+
+// index parses an array index token that may not exceed max
+func index(token string, max int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%q is not an array index", token)
+	}
+	if i > max {
+		return 0, fmt.Errorf("index %d is out of bounds", i)
+	}
+	return i, nil
+}
+
+// equal compares two JSON values, numbers by their value
+func equal(a interface{}, b interface{}) bool {
+	switch a := a.(type) {
+	case json.Number:
+		b, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		x, errX := a.Float64()
+		y, errY := b.Float64()
+		return errX == nil && errY == nil && x == y
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for name, value := range a {
+			other, ok := b[name]
+			if !ok || !equal(value, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		b, ok := b.([]interface{})
+		return ok && slices.EqualFunc(a, b, equal)
+	default:
+		return a == b
+	}
+}
+
+// clone deep copies a JSON value so a copied value can be changed on its own
+func clone(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(value))
+		for name, member := range value {
+			copied[name] = clone(member)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(value))
+		for i, element := range value {
+			copied[i] = clone(element)
+		}
+		return copied
+	default:
+		return value
+	}
+}
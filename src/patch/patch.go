@@ -0,0 +1,120 @@
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"reflect"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+)
+
+const (
+	// MergePatchContentType is the media type of JSON Merge Patch documents (RFC 7396)
+	MergePatchContentType = "application/merge-patch+json"
+	// JSONPatchContentType is the media type of JSON Patch documents (RFC 6902)
+	JSONPatchContentType = "application/json-patch+json"
+)
+
+// AcceptPatch lists the supported media types for the Accept-Patch header
+const AcceptPatch = MergePatchContentType + ", " + JSONPatchContentType
+
+// Patch is a patch document
+type Patch interface {
+	// Apply patches the JSON representation of target, a pointer to a request struct
+	Apply(target interface{}) error
+}
+
+// FromRequest reads the patch in the body of c according to its content type
+func FromRequest(c *fiber.Ctx) (Patch, error) {
+	mediaType, _, _ := mime.ParseMediaType(c.Get(fiber.HeaderContentType))
+	switch mediaType {
+	case MergePatchContentType:
+		var doc interface{}
+		if err := decode(c.Body(), &doc); err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid merge patch: "+err.Error())
+		}
+		return mergePatch{doc: doc}, nil
+	case JSONPatchContentType:
+		operations, err := parseOperations(c.Body())
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid JSON patch: "+err.Error())
+		}
+		return operations, nil
+	default:
+		c.Set("Accept-Patch", AcceptPatch)
+		return nil, fiber.NewError(fiber.StatusUnsupportedMediaType, "PATCH requires a body of type "+AcceptPatch)
+	}
+}
+
+// mergePatch is a JSON Merge Patch: objects are merged recursively, null removes a member
+// and any other value replaces the target, arrays included
+type mergePatch struct {
+	doc interface{}
+}
+
+// Apply implements Patch
+func (p mergePatch) Apply(target interface{}) error {
+	return apply(target, func(doc interface{}) (interface{}, error) {
+		return merge(doc, p.doc), nil
+	})
+}
+
+// merge applies the merge patch patch to target as described in RFC 7396
+func merge(target interface{}, patch interface{}) interface{} {
+	members, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	result, ok := target.(map[string]interface{})
+	if !ok {
+		result = map[string]interface{}{}
+	}
+	for name, value := range members {
+		if value == nil {
+			delete(result, name)
+		} else {
+			result[name] = merge(result[name], value)
+		}
+	}
+	return result
+}
+
+// apply round-trips target through JSON, changing its document with fn in between.
+// Members the patch removes are left at their zero value.
+func apply(target interface{}, fn func(doc interface{}) (interface{}, error)) error {
+	raw, err := json.Marshal(target)
+	if err != nil {
+		return err
+	}
+	var doc interface{}
+	if err := decode(raw, &doc); err != nil {
+		return err
+	}
+
+	doc, err = fn(doc)
+	if err != nil {
+		return err
+	}
+
+	raw, err = json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	value := reflect.ValueOf(target).Elem()
+	value.Set(reflect.Zero(value.Type()))
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil {
+		return apperror.InvalidField("patch", err)
+	}
+	return nil
+}
+
+// decode unmarshals a JSON document keeping numbers exact
+func decode(raw []byte, doc interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	return decoder.Decode(doc)
+}
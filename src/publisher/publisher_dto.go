@@ -1,5 +1,11 @@
 package publisher
 
+import (
+	"time"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+)
+
 type PublisherRequest struct {
 	Name        string `json:"name" validate:"required"`
 	Description string `json:"description"`
@@ -11,17 +17,24 @@ type PublisherCreateResponse struct {
 
 type PublisherDetailResponse struct {
 	ID          uint   `json:"id"`
+	Version     uint   `json:"version"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
 }
 
-type PublisherListResponse struct {
-	Result   []PublisherDTO `json:"result"`
-	Pages    uint           `json:"pages"`
-	Elements uint64         `json:"elements"`
-}
+// PublisherListResponse represents a page of publishers
+type PublisherListResponse = pagination.Page[PublisherDTO]
 
 type PublisherDTO struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
 }
+
+// PublisherTrashResponse represents a deleted publisher in the trash
+type PublisherTrashResponse struct {
+	PublisherDTO
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// PublisherTrashListResponse represents a page of deleted publishers
+type PublisherTrashListResponse = pagination.Page[PublisherTrashResponse]
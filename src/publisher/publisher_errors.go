@@ -0,0 +1,17 @@
+package publisher
+
+import "github.com/tedysaputro/book-catalog-with-go/src/apperror"
+
+var (
+	// ErrPublisherNotFound is returned when no live publisher has the requested ID
+	ErrPublisherNotFound = apperror.NotFound("publisher not found")
+	// ErrPublisherNotInTrash is returned when no deleted publisher has the requested ID
+	ErrPublisherNotInTrash = apperror.NotFound("publisher not found in trash")
+	// ErrPublisherInUse is returned when a publisher that books refer to is purged
+	ErrPublisherInUse = apperror.Conflict("publisher is still referenced by books")
+	// ErrReassignTargetInvalid is returned when the books of a deleted publisher are reassigned
+	// to the same publisher or to one that does not exist
+	ErrReassignTargetInvalid = apperror.Validation("reassign_to", "reassign_to must be the ID of another live publisher")
+	// ErrNameRequired is returned when a publisher has no name
+	ErrNameRequired = apperror.Validation("name", "name is required")
+)
@@ -2,6 +2,14 @@ package publisher
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/deletion"
+	"github.com/tedysaputro/book-catalog-with-go/src/fuzzy"
+	"github.com/tedysaputro/book-catalog-with-go/src/history"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/patch"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+	"github.com/tedysaputro/book-catalog-with-go/src/version"
 )
 
 // PublisherHandler handles HTTP requests for publisher operations
@@ -18,22 +26,12 @@ func NewPublisherHandler(service PublisherService) *PublisherHandler {
 func (h *PublisherHandler) CreatePublisher(c *fiber.Ctx) error {
 	var request PublisherRequest
 	if err := c.BodyParser(&request); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
 	}
 
-	if request.Name == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Name is required",
-		})
-	}
-
-	dto, err := h.service.createPublisher(request)
+	dto, err := h.service.CreatePublisher(c.UserContext(), request)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(dto)
@@ -43,104 +41,209 @@ func (h *PublisherHandler) CreatePublisher(c *fiber.Ctx) error {
 func (h *PublisherHandler) UpdatePublisher(c *fiber.Ctx) error {
 	id, err := c.ParamsInt("id")
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid publisher ID",
-		})
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid publisher ID")
 	}
 
 	var request PublisherRequest
 	if err := c.BodyParser(&request); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
 	}
 
-	if request.Name == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Name is required",
-		})
+	match, err := version.IfMatch(c)
+	if err != nil {
+		return err
 	}
 
-	dto, err := h.service.UpdatePublisher(uint(id), request)
+	dto, err := h.service.UpdatePublisher(c.UserContext(), uint(id), request, match)
 	if err != nil {
-		if err.Error() == "publisher not found" {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": err.Error(),
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
+	}
+
+	c.Set(fiber.HeaderETag, version.ETag(dto.Version))
+	return c.JSON(dto)
+}
+
+// PatchPublisher handles PATCH /publishers/:id request
+func (h *PublisherHandler) PatchPublisher(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid publisher ID")
+	}
+
+	p, err := patch.FromRequest(c)
+	if err != nil {
+		return err
+	}
+
+	match, err := version.IfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	dto, err := h.service.PatchPublisher(c.UserContext(), uint(id), p, match)
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderETag, version.ETag(dto.Version))
+	return c.JSON(dto)
+}
+
+// RevertPublisher handles POST /publishers/:id/revert request
+func (h *PublisherHandler) RevertPublisher(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid publisher ID")
 	}
 
+	var request history.RevertRequest
+	if err := c.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	match, err := version.IfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	dto, err := h.service.RevertPublisher(c.UserContext(), uint(id), request.Version, match)
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderETag, version.ETag(dto.Version))
 	return c.JSON(dto)
 }
 
-// GetPublisher handles GET /publishers/:id request
+// GetPublisher handles GET /publishers/:id request, returning the state at as_of when given
 func (h *PublisherHandler) GetPublisher(c *fiber.Ctx) error {
 	id, err := c.ParamsInt("id")
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid publisher ID",
-		})
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid publisher ID")
 	}
 
-	dto, err := h.service.GetPublisher(uint(id))
+	at, ok, err := history.AsOf(c)
 	if err != nil {
-		if err.Error() == "publisher not found" {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": err.Error(),
-			})
+		return err
+	}
+	if ok {
+		dto, err := h.service.GetPublisherAsOf(c.UserContext(), uint(id), at)
+		if err != nil {
+			return err
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.JSON(dto)
 	}
 
-	return c.JSON(dto)
+	dto, err := h.service.GetPublisher(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return version.Send(c, dto.Version, dto)
 }
 
 // GetPublishers handles GET /publishers request
 func (h *PublisherHandler) GetPublishers(c *fiber.Ctx) error {
-	page := uint(c.QueryInt("pages", 1))
-	limit := uint(c.QueryInt("limit", 10))
-	sortBy := c.Query("sortBy", "id")
-	direction := c.Query("direction", "asc")
+	sort, err := sorting.FromQuery(c.Query("sort"), c.Query("sortBy"), c.Query("direction"), SortFields)
+	if err != nil {
+		return apperror.BadRequest("sort", err)
+	}
+	sort = sort.Stable(SortFields)
 	publisherName := c.Query("publisherName", "")
+	match := fuzzy.Options{
+		Enabled:   c.QueryBool("fuzzy"),
+		Threshold: c.QueryFloat("threshold", fuzzy.DefaultThreshold),
+	}
+	if err := match.Validate(); err != nil {
+		return apperror.BadRequest("threshold", err)
+	}
+	page, err := pagination.FromQuery(c, sort)
+	if err != nil {
+		return err
+	}
+	if match.Enabled && page.Cursor != nil {
+		return apperror.BadRequest("cursor", pagination.ErrCursorUnsupported)
+	}
 
-	publishers, err := h.service.GetPublishers(page, limit, sortBy, direction, publisherName)
+	publishers, err := h.service.GetPublishers(c.UserContext(), page, sort, publisherName, match)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
 	}
 
-	return c.JSON(publishers)
+	return c.JSON(publishers.WithLinks(c))
 }
 
+// DeletePublisher handles DELETE /publishers/:id request
 func (h *PublisherHandler) DeletePublisher(c *fiber.Ctx) error {
 	id, err := c.ParamsInt("id")
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid publisher ID",
-		})
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid publisher ID")
+	}
+	options, err := deletion.FromQuery(c)
+	if err != nil {
+		return err
 	}
 
-	err = h.service.DeletePublisher(uint(id))
+	match, err := version.IfMatch(c)
 	if err != nil {
-		if err.Error() == "publisher not found" {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": err.Error(),
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
 	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message": "Publisher deleted successfully",
-	})
+	err = h.service.DeletePublisher(c.UserContext(), uint(id), match, options)
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetDeletedPublishers handles GET /publishers/trash request
+func (h *PublisherHandler) GetDeletedPublishers(c *fiber.Ctx) error {
+	sort, err := sorting.FromQuery(c.Query("sort"), c.Query("sortBy"), c.Query("direction"), SortFields)
+	if err != nil {
+		return apperror.BadRequest("sort", err)
+	}
+	sort = sort.Stable(SortFields)
+	page, err := pagination.FromQuery(c, sort)
+	if err != nil {
+		return err
+	}
+
+	publishers, err := h.service.GetDeletedPublishers(c.UserContext(), page, sort)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(publishers.WithLinks(c))
+}
+
+// RestorePublisher handles POST /publishers/:id/restore request
+func (h *PublisherHandler) RestorePublisher(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid publisher ID")
+	}
+
+	publisher, err := h.service.RestorePublisher(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(publisher)
+}
+
+// PurgePublisher handles DELETE /publishers/:id/purge request
+func (h *PublisherHandler) PurgePublisher(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid publisher ID")
+	}
+
+	if err := h.service.PurgePublisher(c.UserContext(), uint(id)); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
 }
 
 // RegisterRoutes registers all routes for publisher module
@@ -152,7 +255,16 @@ func (h *PublisherHandler) RegisterRoutes(app *fiber.App) {
 	publishers := v1.Group("/publishers")
 	publishers.Post("/", h.CreatePublisher)
 	publishers.Get("/", h.GetPublishers)
+	publishers.Get("/trash", h.GetDeletedPublishers)
 	publishers.Get("/:id", h.GetPublisher)
 	publishers.Put("/:id", h.UpdatePublisher)
+	publishers.Patch("/:id", h.PatchPublisher)
 	publishers.Delete("/:id", h.DeletePublisher)
+	publishers.Post("/:id/restore", h.RestorePublisher)
+	publishers.Post("/:id/revert", h.RevertPublisher)
+}
+
+// RegisterAdminRoutes registers the publisher routes that are only open to admins, guarded by admin
+func (h *PublisherHandler) RegisterAdminRoutes(app *fiber.App, admin fiber.Handler) {
+	app.Delete("/api/v1/publishers/:id/purge", admin, h.PurgePublisher)
 }
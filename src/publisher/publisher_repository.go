@@ -0,0 +1,199 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/fuzzy"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+	"github.com/tedysaputro/book-catalog-with-go/src/transaction"
+	"github.com/tedysaputro/book-catalog-with-go/src/version"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PublisherRepository defines the persistence operations for publishers
+type PublisherRepository interface {
+	Create(ctx context.Context, publisher *Publisher) error
+	Update(ctx context.Context, publisher *Publisher) error
+	FindByID(ctx context.Context, id uint) (*Publisher, error)
+	FindByIDs(ctx context.Context, ids []uint) ([]Publisher, error)
+	FindByName(ctx context.Context, name string) (*Publisher, error)
+	FindAll(ctx context.Context, page pagination.Request, sort sorting.Spec, publisherName string, match fuzzy.Options) ([]Publisher, uint64, error)
+	SoftDelete(ctx context.Context, publisher *Publisher) error
+	FindAllDeleted(ctx context.Context, page pagination.Request, sort sorting.Spec) ([]Publisher, uint64, error)
+	FindDeletedByID(ctx context.Context, id uint) (*Publisher, error)
+	Restore(ctx context.Context, publisher *Publisher) error
+	Purge(ctx context.Context, publisher *Publisher) error
+	FindAllDeletedBefore(ctx context.Context, before time.Time) ([]Publisher, error)
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type gormPublisherRepository struct {
+	db *gorm.DB
+}
+
+// NewGormPublisherRepository creates a PublisherRepository backed by GORM
+func NewGormPublisherRepository(db *gorm.DB) PublisherRepository {
+	return &gormPublisherRepository{db: db}
+}
+
+// Transaction runs fn in a database transaction that the writes of every repository made with
+// the context passed to fn join, keeping them only if fn returns nil
+func (r *gormPublisherRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction.Run(ctx, r.db, fn)
+}
+
+// Create saves a new Publisher record to the database
+func (r *gormPublisherRepository) Create(ctx context.Context, publisher *Publisher) error {
+	return transaction.DB(ctx, r.db).Create(publisher).Error
+}
+
+// Update updates a Publisher record in the database
+func (r *gormPublisherRepository) Update(ctx context.Context, publisher *Publisher) error {
+	if err := publisher.Validate(); err != nil {
+		return err
+	}
+	return version.Save(transaction.DB(ctx, r.db), publisher, &publisher.Version)
+}
+
+// FindByID retrieves a Publisher by ID while deleted_at is null
+func (r *gormPublisherRepository) FindByID(ctx context.Context, id uint) (*Publisher, error) {
+	var publisher Publisher
+	err := transaction.DB(ctx, r.db).First(&publisher, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPublisherNotFound
+		}
+		return nil, err
+	}
+	return &publisher, nil
+}
+
+// FindByIDs retrieves the Publishers with the given IDs, skipping IDs that do not exist
+func (r *gormPublisherRepository) FindByIDs(ctx context.Context, ids []uint) ([]Publisher, error) {
+	var publishers []Publisher
+	if len(ids) == 0 {
+		return publishers, nil
+	}
+	if err := transaction.DB(ctx, r.db).Find(&publishers, ids).Error; err != nil {
+		return nil, err
+	}
+	return publishers, nil
+}
+
+// FindByName retrieves the oldest live Publisher named name, ignoring case
+func (r *gormPublisherRepository) FindByName(ctx context.Context, name string) (*Publisher, error) {
+	var publisher Publisher
+	err := transaction.DB(ctx, r.db).Where("UPPER(publishers.name) = ?", strings.ToUpper(name)).Order("publishers.id").First(&publisher).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPublisherNotFound
+		}
+		return nil, err
+	}
+	return &publisher, nil
+}
+
+// FindAll retrieves all Publishers while deleted_at is null
+func (r *gormPublisherRepository) FindAll(ctx context.Context, page pagination.Request, sort sorting.Spec, publisherName string, match fuzzy.Options) ([]Publisher, uint64, error) {
+	var publishers []Publisher
+	var count int64
+
+	err := fuzzy.Run(ctx, r.db, match, func(ctx context.Context) error {
+		// Count total records
+		query, rank := filterByName(transaction.DB(ctx, r.db).Model(&Publisher{}), publisherName, match)
+		if err := query.Session(&gorm.Session{}).Count(&count).Error; err != nil {
+			return err
+		}
+
+		// Get records with pagination
+		return page.Apply(query, sort, rank...).Find(&publishers).Error
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return publishers, uint64(count), nil
+}
+
+// SoftDelete soft deletes a Publisher record
+func (r *gormPublisherRepository) SoftDelete(ctx context.Context, publisher *Publisher) error {
+	return version.SoftDelete(transaction.DB(ctx, r.db), publisher, publisher.Version)
+}
+
+// FindAllDeleted retrieves the soft deleted Publisher records
+func (r *gormPublisherRepository) FindAllDeleted(ctx context.Context, page pagination.Request, sort sorting.Spec) ([]Publisher, uint64, error) {
+	var publishers []Publisher
+	var total int64
+
+	query := transaction.DB(ctx, r.db).Unscoped().Model(&Publisher{}).Where("publishers.deleted_at IS NOT NULL")
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := page.Apply(query, sort).Find(&publishers).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return publishers, uint64(total), nil
+}
+
+// FindDeletedByID retrieves a soft deleted Publisher by ID
+func (r *gormPublisherRepository) FindDeletedByID(ctx context.Context, id uint) (*Publisher, error) {
+	var publisher Publisher
+	err := transaction.DB(ctx, r.db).Unscoped().Where("deleted_at IS NOT NULL").First(&publisher, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPublisherNotInTrash
+		}
+		return nil, err
+	}
+	return &publisher, nil
+}
+
+// Restore clears the deletion time of a soft deleted Publisher record and increments its version
+func (r *gormPublisherRepository) Restore(ctx context.Context, publisher *Publisher) error {
+	if err := version.Restore(transaction.DB(ctx, r.db), publisher, &publisher.Version); err != nil {
+		return err
+	}
+	publisher.DeletedAt = gorm.DeletedAt{}
+	return nil
+}
+
+// Purge permanently deletes a Publisher record.
+// A publisher that books still refer to, even deleted ones, is reported as ErrPublisherInUse.
+func (r *gormPublisherRepository) Purge(ctx context.Context, publisher *Publisher) error {
+	err := transaction.DB(ctx, r.db).Unscoped().Delete(publisher).Error
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		return ErrPublisherInUse
+	}
+	return err
+}
+
+// FindAllDeletedBefore retrieves the Publisher records soft deleted before the given time, ordered by ID,
+// skipping the ones that books still refer to
+func (r *gormPublisherRepository) FindAllDeletedBefore(ctx context.Context, before time.Time) ([]Publisher, error) {
+	var publishers []Publisher
+	err := transaction.DB(ctx, r.db).Unscoped().
+		Where("deleted_at < ?", before).
+		Where("NOT EXISTS (SELECT 1 FROM books WHERE books.publisher_id = publishers.id)").
+		Order("id").
+		Find(&publishers).Error
+	return publishers, err
+}
+
+// filterByName keeps the publishers whose name contains name, or resembles it when match is enabled,
+// and returns the similarity ranking of fuzzy matches
+func filterByName(query *gorm.DB, name string, match fuzzy.Options) (*gorm.DB, []clause.Expr) {
+	if name == "" {
+		return query, nil
+	}
+	if match.Enabled {
+		return fuzzy.Where(query, "publishers.name", name), []clause.Expr{fuzzy.Rank("publishers.name", name)}
+	}
+	return query.Where("UPPER(publishers.name) LIKE ?", "%"+strings.ToUpper(name)+"%"), nil
+}
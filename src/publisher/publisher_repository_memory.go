@@ -0,0 +1,231 @@
+package publisher
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/fuzzy"
+	"github.com/tedysaputro/book-catalog-with-go/src/memory"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+	"github.com/tedysaputro/book-catalog-with-go/src/version"
+	"gorm.io/gorm"
+)
+
+type memoryPublisherRepository struct {
+	mu         sync.RWMutex
+	publishers map[uint]Publisher
+	nextID     uint
+}
+
+// NewMemoryPublisherRepository creates a PublisherRepository that keeps publishers in memory
+func NewMemoryPublisherRepository() PublisherRepository {
+	return &memoryPublisherRepository{publishers: make(map[uint]Publisher)}
+}
+
+// Transaction runs fn; the writes it made before a failure are not rolled back
+func (r *memoryPublisherRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// Create stores a new Publisher
+func (r *memoryPublisherRepository) Create(ctx context.Context, publisher *Publisher) error {
+	if err := publisher.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	now := time.Now()
+	publisher.ID = r.nextID
+	publisher.Version = 1
+	publisher.CreatedAt = now
+	publisher.UpdatedAt = now
+	r.publishers[publisher.ID] = *publisher
+	return nil
+}
+
+// Update replaces a stored Publisher
+func (r *memoryPublisherRepository) Update(ctx context.Context, publisher *Publisher) error {
+	if err := publisher.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if stored, ok := r.publishers[publisher.ID]; !ok || stored.Version != publisher.Version {
+		return version.ErrStale
+	}
+	publisher.Version++
+	publisher.UpdatedAt = time.Now()
+	r.publishers[publisher.ID] = *publisher
+	return nil
+}
+
+// FindByID retrieves a live Publisher by ID
+func (r *memoryPublisherRepository) FindByID(ctx context.Context, id uint) (*Publisher, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	publisher, ok := r.publishers[id]
+	if !ok || publisher.DeletedAt.Valid {
+		return nil, ErrPublisherNotFound
+	}
+	return &publisher, nil
+}
+
+// FindByIDs retrieves the live Publishers with the given IDs, skipping IDs that do not exist
+func (r *memoryPublisherRepository) FindByIDs(ctx context.Context, ids []uint) ([]Publisher, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	publishers := []Publisher{}
+	for _, id := range ids {
+		if publisher, ok := r.publishers[id]; ok && !publisher.DeletedAt.Valid {
+			publishers = append(publishers, publisher)
+		}
+	}
+	return publishers, nil
+}
+
+// FindByName retrieves the oldest live Publisher named name, ignoring case
+func (r *memoryPublisherRepository) FindByName(ctx context.Context, name string) (*Publisher, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var found *Publisher
+	for _, publisher := range r.publishers {
+		if !publisher.DeletedAt.Valid && strings.EqualFold(publisher.Name, name) && (found == nil || publisher.ID < found.ID) {
+			found = &publisher
+		}
+	}
+	if found == nil {
+		return nil, ErrPublisherNotFound
+	}
+	return found, nil
+}
+
+// FindAll retrieves all live Publishers matching publisherName
+func (r *memoryPublisherRepository) FindAll(ctx context.Context, page pagination.Request, sort sorting.Spec, publisherName string, match fuzzy.Options) ([]Publisher, uint64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	publishers := []Publisher{}
+	for _, publisher := range r.publishers {
+		if publisher.DeletedAt.Valid {
+			continue
+		}
+		switch {
+		case publisherName == "":
+		case match.Enabled && fuzzy.Similarity(publisherName, publisher.Name) < match.Threshold:
+			continue
+		case !match.Enabled && !memory.ContainsFold(publisher.Name, publisherName):
+			continue
+		}
+		publishers = append(publishers, publisher)
+	}
+
+	var score func(Publisher) float64
+	if publisherName != "" && match.Enabled {
+		score = func(pub Publisher) float64 { return fuzzy.Similarity(publisherName, pub.Name) }
+	}
+	value := func(pub Publisher, field string) interface{} { return pub.SortValue(field) }
+	memory.Sort(publishers, sort, value, score)
+
+	return memory.Paginate(publishers, page, sort, value), uint64(len(publishers)), nil
+}
+
+// SoftDelete soft deletes a stored Publisher
+func (r *memoryPublisherRepository) SoftDelete(ctx context.Context, publisher *Publisher) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.publishers[publisher.ID]
+	if !ok {
+		return nil
+	}
+	if stored.Version != publisher.Version {
+		return version.ErrStale
+	}
+	stored.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	r.publishers[publisher.ID] = stored
+	return nil
+}
+
+// FindAllDeleted retrieves the soft deleted Publisher records
+func (r *memoryPublisherRepository) FindAllDeleted(ctx context.Context, page pagination.Request, sort sorting.Spec) ([]Publisher, uint64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	publishers := []Publisher{}
+	for _, publisher := range r.publishers {
+		if publisher.DeletedAt.Valid {
+			publishers = append(publishers, publisher)
+		}
+	}
+
+	value := func(pub Publisher, field string) interface{} { return pub.SortValue(field) }
+	memory.Sort(publishers, sort, value, nil)
+
+	return memory.Paginate(publishers, page, sort, value), uint64(len(publishers)), nil
+}
+
+// FindDeletedByID retrieves a soft deleted Publisher by ID
+func (r *memoryPublisherRepository) FindDeletedByID(ctx context.Context, id uint) (*Publisher, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	publisher, ok := r.publishers[id]
+	if !ok || !publisher.DeletedAt.Valid {
+		return nil, ErrPublisherNotInTrash
+	}
+	return &publisher, nil
+}
+
+// Restore clears the deletion time of a stored Publisher
+func (r *memoryPublisherRepository) Restore(ctx context.Context, publisher *Publisher) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.publishers[publisher.ID]
+	if !ok {
+		return nil
+	}
+	stored.DeletedAt = gorm.DeletedAt{}
+	stored.Version++
+	stored.UpdatedAt = time.Now()
+	r.publishers[publisher.ID] = stored
+	*publisher = stored
+	return nil
+}
+
+// Purge permanently removes a stored Publisher
+func (r *memoryPublisherRepository) Purge(ctx context.Context, publisher *Publisher) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.publishers, publisher.ID)
+	return nil
+}
+
+// FindAllDeletedBefore retrieves the stored Publishers soft deleted before the given time, ordered by ID
+func (r *memoryPublisherRepository) FindAllDeletedBefore(ctx context.Context, before time.Time) ([]Publisher, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	publishers := []Publisher{}
+	for _, publisher := range r.publishers {
+		if publisher.DeletedAt.Valid && publisher.DeletedAt.Time.Before(before) {
+			publishers = append(publishers, publisher)
+		}
+	}
+	slices.SortFunc(publishers, func(a, b Publisher) int { return cmp.Compare(a.ID, b.ID) })
+	return publishers, nil
+}
@@ -1,26 +1,55 @@
 package publisher
 
-import "strconv"
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/deletion"
+	"github.com/tedysaputro/book-catalog-with-go/src/fuzzy"
+	"github.com/tedysaputro/book-catalog-with-go/src/history"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/patch"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+	"github.com/tedysaputro/book-catalog-with-go/src/version"
+)
 
 // PublisherService defines the interface for publisher operations
 
 type PublisherService interface {
-	createPublisher(request PublisherRequest) (*PublisherCreateResponse, error)
-	GetPublisher(id uint) (*PublisherDetailResponse, error)
-	GetPublishers(p uint, limit uint, sortBy string, direction string, publisherName string) (*PublisherListResponse, error)
-	UpdatePublisher(id uint, request PublisherRequest) (*PublisherDetailResponse, error)
-	DeletePublisher(id uint) error
+	CreatePublisher(ctx context.Context, request PublisherRequest) (*PublisherCreateResponse, error)
+	GetPublisher(ctx context.Context, id uint) (*PublisherDetailResponse, error)
+	GetPublishersByIDs(ctx context.Context, ids []uint) ([]PublisherDetailResponse, error)
+	GetPublisherAsOf(ctx context.Context, id uint, at time.Time) (*PublisherDetailResponse, error)
+	GetPublishers(ctx context.Context, page pagination.Request, sort sorting.Spec, publisherName string, match fuzzy.Options) (*PublisherListResponse, error)
+	UpdatePublisher(ctx context.Context, id uint, request PublisherRequest, match version.Precondition) (*PublisherDetailResponse, error)
+	PatchPublisher(ctx context.Context, id uint, p patch.Patch, match version.Precondition) (*PublisherDetailResponse, error)
+	RevertPublisher(ctx context.Context, id uint, to uint, match version.Precondition) (*PublisherDetailResponse, error)
+	DeletePublisher(ctx context.Context, id uint, match version.Precondition, options deletion.Options) error
+	GetDeletedPublishers(ctx context.Context, page pagination.Request, sort sorting.Spec) (*PublisherTrashListResponse, error)
+	RestorePublisher(ctx context.Context, id uint) (*PublisherDetailResponse, error)
+	PurgePublisher(ctx context.Context, id uint) error
+	PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error)
 }
 
-type publisherServiceImpl struct{}
+// entityType names publishers in their change history
+const entityType = "publisher"
+
+type publisherServiceImpl struct {
+	repo    PublisherRepository
+	books   deletion.Dependents
+	history history.HistoryService
+}
 
-// NewPublisherService creates a new instance of PublisherService
-func NewPublisherService() PublisherService {
-	return &publisherServiceImpl{}
+// NewPublisherService creates a new instance of PublisherService.
+// books handles the books of deleted publishers and history records every change of a publisher.
+func NewPublisherService(repo PublisherRepository, books deletion.Dependents, history history.HistoryService) PublisherService {
+	return &publisherServiceImpl{repo: repo, books: books, history: history}
 }
 
-// createPublisher creates a new publisher
-func (s *publisherServiceImpl) createPublisher(request PublisherRequest) (*PublisherCreateResponse, error) {
+// CreatePublisher creates a new publisher
+func (s *publisherServiceImpl) CreatePublisher(ctx context.Context, request PublisherRequest) (*PublisherCreateResponse, error) {
 	publisher := &Publisher{
 		Name:        request.Name,
 		Description: request.Description,
@@ -30,7 +59,13 @@ func (s *publisherServiceImpl) createPublisher(request PublisherRequest) (*Publi
 		return nil, err
 	}
 
-	if err := publisher.Create(); err != nil {
+	err := s.repo.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, publisher); err != nil {
+			return err
+		}
+		return s.history.Record(ctx, entityType, publisher.ID, publisher.Version, history.ActionCreate, nil, toPublisherDetailResponse(publisher))
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -42,76 +77,259 @@ func (s *publisherServiceImpl) createPublisher(request PublisherRequest) (*Publi
 }
 
 // GetPublisher retrieves a publisher by ID
-func (s *publisherServiceImpl) GetPublisher(id uint) (*PublisherDetailResponse, error) {
-	publisher, err := FindByID(id)
+func (s *publisherServiceImpl) GetPublisher(ctx context.Context, id uint) (*PublisherDetailResponse, error) {
+	publisher, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	dto := &PublisherDetailResponse{
-		ID:          publisher.ID,
-		Name:        publisher.Name,
-		Description: publisher.Description,
+	return toPublisherDetailResponse(publisher), nil
+}
+
+// GetPublishersByIDs retrieves the publishers with the given IDs, skipping IDs that do not exist
+func (s *publisherServiceImpl) GetPublishersByIDs(ctx context.Context, ids []uint) ([]PublisherDetailResponse, error) {
+	publishers, err := s.repo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
 	}
 
-	return dto, nil
+	publisherDTOs := make([]PublisherDetailResponse, len(publishers))
+	for i := range publishers {
+		publisherDTOs[i] = *toPublisherDetailResponse(&publishers[i])
+	}
+
+	return publisherDTOs, nil
+}
+
+// GetPublisherAsOf retrieves the state a publisher had at the given time by ID
+func (s *publisherServiceImpl) GetPublisherAsOf(ctx context.Context, id uint, at time.Time) (*PublisherDetailResponse, error) {
+	var state PublisherDetailResponse
+	if err := s.history.StateAsOf(ctx, entityType, id, at, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
 }
 
 // GetPublishers retrieves all publishers
-func (s *publisherServiceImpl) GetPublishers(p uint, limit uint, sortBy string, direction string, publisherName string) (*PublisherListResponse, error) {
-	publishers, p, el, err := FindAll(p, limit, sortBy, direction, publisherName)
+func (s *publisherServiceImpl) GetPublishers(ctx context.Context, page pagination.Request, sort sorting.Spec, publisherName string, match fuzzy.Options) (*PublisherListResponse, error) {
+	publishers, total, err := s.repo.FindAll(ctx, page, sort, publisherName, match)
 	if err != nil {
 		return nil, err
 	}
 
-	dtos := make([]PublisherDTO, len(publishers))
-	for i, publisher := range publishers {
-		dtos[i] = PublisherDTO{
+	result := pagination.New(publishers, page, total).
+		WithCursors(sort, func(pub Publisher, field string) interface{} { return pub.SortValue(field) })
+
+	return pagination.Map(result, func(publisher Publisher) PublisherDTO {
+		return PublisherDTO{
 			ID:   strconv.FormatUint(uint64(publisher.ID), 10),
 			Name: publisher.Name,
 		}
+	}), nil
+}
+
+// UpdatePublisher updates a publisher by ID
+func (s *publisherServiceImpl) UpdatePublisher(ctx context.Context, id uint, request PublisherRequest, match version.Precondition) (*PublisherDetailResponse, error) {
+	publisher, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := match.Check(publisher.Version); err != nil {
+		return nil, err
 	}
 
-	return &PublisherListResponse{
-		Result:   dtos,
-		Pages:    p,
-		Elements: el,
-	}, nil
+	return s.update(ctx, publisher, request, history.ActionUpdate)
 }
 
-// UpdatePublisher updates a publisher by ID
-func (s *publisherServiceImpl) UpdatePublisher(id uint, request PublisherRequest) (*PublisherDetailResponse, error) {
-	publisher, err := FindByID(id)
+// PatchPublisher applies a merge patch or JSON patch to the fields of a publisher by ID
+func (s *publisherServiceImpl) PatchPublisher(ctx context.Context, id uint, p patch.Patch, match version.Precondition) (*PublisherDetailResponse, error) {
+	publisher, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if err := match.Check(publisher.Version); err != nil {
+		return nil, err
+	}
 
+	request := PublisherRequest{Name: publisher.Name, Description: publisher.Description}
+	if err := p.Apply(&request); err != nil {
+		return nil, err
+	}
+
+	return s.update(ctx, publisher, request, history.ActionUpdate)
+}
+
+// RevertPublisher restores the fields a publisher had at a previous version by ID
+func (s *publisherServiceImpl) RevertPublisher(ctx context.Context, id uint, to uint, match version.Precondition) (*PublisherDetailResponse, error) {
+	publisher, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := match.Check(publisher.Version); err != nil {
+		return nil, err
+	}
+
+	var state PublisherDetailResponse
+	if err := s.history.StateAtVersion(ctx, entityType, id, to, &state); err != nil {
+		return nil, err
+	}
+
+	request := PublisherRequest{Name: state.Name, Description: state.Description}
+	return s.update(ctx, publisher, request, history.ActionRevert)
+}
+
+// update replaces the fields of publisher with request, stores it and records the change as action
+func (s *publisherServiceImpl) update(ctx context.Context, publisher *Publisher, request PublisherRequest, action history.Action) (*PublisherDetailResponse, error) {
+	before := toPublisherDetailResponse(publisher)
 	publisher.Name = request.Name
 	publisher.Description = request.Description
 
-	if err := publisher.Update(); err != nil {
+	var dto *PublisherDetailResponse
+	err := s.repo.Transaction(ctx, func(ctx context.Context) error {
+		store := func(ctx context.Context) error { return s.repo.Update(ctx, publisher) }
+		// The books show the name of the publisher, so a rename changes them as well
+		if publisher.Name != before.Name {
+			if err := s.books.Touch(ctx, publisher.ID, store); err != nil {
+				return err
+			}
+		} else if err := store(ctx); err != nil {
+			return err
+		}
+		dto = toPublisherDetailResponse(publisher)
+		return s.history.Record(ctx, entityType, publisher.ID, publisher.Version, action, before, dto)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	dto := &PublisherDetailResponse{
-		ID:          publisher.ID,
-		Name:        publisher.Name,
-		Description: publisher.Description,
+	return dto, nil
+}
+
+// DeletePublisher soft delete a publisher by ID, handling its books according to options
+func (s *publisherServiceImpl) DeletePublisher(ctx context.Context, id uint, match version.Precondition, options deletion.Options) error {
+	// The books are handled in the transaction of the delete, so they are left as they were when the
+	// publisher turns out to have changed since the version check
+	return s.repo.Transaction(ctx, func(ctx context.Context) error {
+		publisher, err := s.repo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := match.Check(publisher.Version); err != nil {
+			return err
+		}
+
+		if options.Policy == deletion.PolicyReassign {
+			if options.ReassignTo == id {
+				return ErrReassignTargetInvalid
+			}
+			if _, err := s.repo.FindByID(ctx, options.ReassignTo); err != nil {
+				if errors.Is(err, ErrPublisherNotFound) {
+					return ErrReassignTargetInvalid
+				}
+				return err
+			}
+		}
+
+		if err := deletion.Apply(ctx, entityType, s.books, id, options); err != nil {
+			return err
+		}
+
+		if err := s.repo.SoftDelete(ctx, publisher); err != nil {
+			return err
+		}
+		return s.history.Record(ctx, entityType, publisher.ID, publisher.Version, history.ActionDelete, toPublisherDetailResponse(publisher), nil)
+	})
+}
+
+// GetDeletedPublishers retrieves the publishers in the trash with pagination
+func (s *publisherServiceImpl) GetDeletedPublishers(ctx context.Context, page pagination.Request, sort sorting.Spec) (*PublisherTrashListResponse, error) {
+	publishers, total, err := s.repo.FindAllDeleted(ctx, page, sort)
+	if err != nil {
+		return nil, err
+	}
+
+	result := pagination.New(publishers, page, total).
+		WithCursors(sort, func(pub Publisher, field string) interface{} { return pub.SortValue(field) })
+
+	return pagination.Map(result, func(publisher Publisher) PublisherTrashResponse {
+		return PublisherTrashResponse{
+			PublisherDTO: PublisherDTO{
+				ID:   strconv.FormatUint(uint64(publisher.ID), 10),
+				Name: publisher.Name,
+			},
+			DeletedAt: publisher.DeletedAt.Time,
+		}
+	}), nil
+}
+
+// RestorePublisher moves a publisher out of the trash
+func (s *publisherServiceImpl) RestorePublisher(ctx context.Context, id uint) (*PublisherDetailResponse, error) {
+	publisher, err := s.repo.FindDeletedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var dto *PublisherDetailResponse
+	err = s.repo.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Restore(ctx, publisher); err != nil {
+			return err
+		}
+		dto = toPublisherDetailResponse(publisher)
+		return s.history.Record(ctx, entityType, publisher.ID, publisher.Version, history.ActionRestore, nil, dto)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return dto, nil
 }
 
-// DeletePublisher soft delete a publisher by ID
-func (s *publisherServiceImpl) DeletePublisher(id uint) error {
-	publisher, err := FindByID(id)
+// PurgePublisher permanently deletes a publisher from the trash
+func (s *publisherServiceImpl) PurgePublisher(ctx context.Context, id uint) error {
+	publisher, err := s.repo.FindDeletedByID(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	if err := publisher.SoftDelete(); err != nil {
-		return err
+	return s.purge(ctx, publisher)
+}
+
+// PurgeDeletedBefore permanently deletes the publishers soft deleted before the given time that no book
+// refers to any more and returns how many were purged
+func (s *publisherServiceImpl) PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	publishers, err := s.repo.FindAllDeletedBefore(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+
+	var purged int64
+	for i := range publishers {
+		if err := s.purge(ctx, &publishers[i]); err != nil {
+			return purged, err
+		}
+		purged++
 	}
+	return purged, nil
+}
 
-	return nil
+// purge permanently deletes publisher and records the change
+func (s *publisherServiceImpl) purge(ctx context.Context, publisher *Publisher) error {
+	return s.repo.Transaction(ctx, func(ctx context.Context) error {
+		return s.books.Unlink(ctx, publisher.ID, func(ctx context.Context) error {
+			if err := s.repo.Purge(ctx, publisher); err != nil {
+				return err
+			}
+			return s.history.Record(ctx, entityType, publisher.ID, publisher.Version, history.ActionPurge, toPublisherDetailResponse(publisher), nil)
+		})
+	})
+}
+
+// toPublisherDetailResponse converts a Publisher into a PublisherDetailResponse
+func toPublisherDetailResponse(publisher *Publisher) *PublisherDetailResponse {
+	return &PublisherDetailResponse{
+		ID:          publisher.ID,
+		Version:     publisher.Version,
+		Name:        publisher.Name,
+		Description: publisher.Description,
+	}
 }
@@ -1,22 +1,57 @@
 package main
 
 import (
+	"log"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/tedysaputro/book-catalog-with-go/src/author"
 	"github.com/tedysaputro/book-catalog-with-go/src/book"
 	"github.com/tedysaputro/book-catalog-with-go/src/category"
+	"github.com/tedysaputro/book-catalog-with-go/src/citation"
+	"github.com/tedysaputro/book-catalog-with-go/src/graph"
 	"github.com/tedysaputro/book-catalog-with-go/src/hello"
+	"github.com/tedysaputro/book-catalog-with-go/src/history"
+	"github.com/tedysaputro/book-catalog-with-go/src/importer"
+	"github.com/tedysaputro/book-catalog-with-go/src/linkeddata"
+	"github.com/tedysaputro/book-catalog-with-go/src/marc"
+	"github.com/tedysaputro/book-catalog-with-go/src/oai"
+	"github.com/tedysaputro/book-catalog-with-go/src/onix"
+	"github.com/tedysaputro/book-catalog-with-go/src/opds"
 	"github.com/tedysaputro/book-catalog-with-go/src/publisher"
+	"github.com/tedysaputro/book-catalog-with-go/src/search"
+	"gorm.io/gorm"
 )
 
-// SetupRoutes configures all application routes
-func SetupRoutes(app *fiber.App) {
+// SetupRoutes configures all application routes on services.
+// Admin routes such as purging the trash are guarded by admin.
+func SetupRoutes(app *fiber.App, db *gorm.DB, services Services, admin fiber.Handler) {
+	// Initialize repositories
+	authorRepository := author.NewGormAuthorRepository(db)
+	publisherRepository := publisher.NewGormPublisherRepository(db)
+	categoryRepository := category.NewGormCategoryRepository(db)
+	bookRepository := book.NewGormBookRepository(db)
+	historyRepository := history.NewGormHistoryRepository(db)
+	searchRepository := search.NewGormSearchRepository(db)
+
 	// Initialize services
 	helloService := hello.NewHelloService()
-	authorService := author.NewAuthorService()
-	publisherService := publisher.NewPublisherService()
-	categoryService := category.NewCategoryService()
-	bookService := book.NewBookService()
+	historyService := services.History
+	authorService := services.Authors
+	publisherService := services.Publishers
+	categoryService := services.Categories
+	bookService := services.Books
+	searchService := search.NewSearchService(searchRepository, bookService)
+	importService := importer.NewImportService(importer.NewGormStore(db))
+	marcService := marc.NewMARCService(bookRepository)
+	onixService := onix.NewONIXService(publisherRepository, bookRepository, historyRepository)
+	citationService := citation.NewCitationService(bookRepository)
+	linkedDataService := linkeddata.NewLinkedDataService(bookRepository, authorRepository, publisherRepository)
+	opdsService := opds.NewOPDSService(bookRepository, authorRepository, publisherRepository, categoryRepository)
+	oaiService := oai.NewOAIService(bookRepository, categoryRepository, publisherRepository)
+	graphService, err := graph.NewGraphService(bookService, authorService, publisherService, categoryService)
+	if err != nil {
+		log.Fatal("Failed to build the GraphQL schema:", err)
+	}
 
 	// Initialize handlers
 	helloHandler := hello.NewHelloHandler(helloService)
@@ -24,11 +59,41 @@ func SetupRoutes(app *fiber.App) {
 	publisherHandler := publisher.NewPublisherHandler(publisherService)
 	categoryHandler := category.NewCategoryHandler(categoryService)
 	bookHandler := book.NewBookHandler(bookService)
+	searchHandler := search.NewSearchHandler(searchService)
+	historyHandler := history.NewHistoryHandler(historyService)
+	importHandler := importer.NewImportHandler(importService)
+	marcHandler := marc.NewMARCHandler(marcService)
+	onixHandler := onix.NewONIXHandler(onixService)
+	citationHandler := citation.NewCitationHandler(citationService)
+	linkedDataHandler := linkeddata.NewLinkedDataHandler(linkedDataService)
+	opdsHandler := opds.NewOPDSHandler(opdsService)
+	oaiHandler := oai.NewOAIHandler(oaiService)
+	graphHandler := graph.NewGraphHandler(graphService)
 
 	// Register routes from each module
 	helloHandler.RegisterRoutes(app)
+	// The linked data routes answer GET /:id requests for JSON-LD or RDF/XML and pass the
+	// others on to the author, publisher and book routes registered after them
+	linkedDataHandler.RegisterRoutes(app)
 	authorHandler.RegisterRoutes(app)
 	publisherHandler.RegisterRoutes(app)
 	categoryHandler.RegisterRoutes(app)
+	// The MARC and citation routes go before the book routes, which would take /books/marc
+	// and /books/cite for book IDs
+	marcHandler.RegisterRoutes(app)
+	citationHandler.RegisterRoutes(app)
 	bookHandler.RegisterRoutes(app)
+	searchHandler.RegisterRoutes(app)
+	historyHandler.RegisterRoutes(app)
+	importHandler.RegisterRoutes(app)
+	onixHandler.RegisterRoutes(app)
+	opdsHandler.RegisterRoutes(app)
+	oaiHandler.RegisterRoutes(app)
+	graphHandler.RegisterRoutes(app)
+
+	// Register the admin routes of each module
+	authorHandler.RegisterAdminRoutes(app, admin)
+	publisherHandler.RegisterAdminRoutes(app, admin)
+	categoryHandler.RegisterAdminRoutes(app, admin)
+	bookHandler.RegisterAdminRoutes(app, admin)
 }
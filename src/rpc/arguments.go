@@ -0,0 +1,169 @@
+package rpc
+
+import (
+	"errors"
+	"strconv"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/deletion"
+	"github.com/tedysaputro/book-catalog-with-go/src/fuzzy"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/rpc/catalogv1"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+	"github.com/tedysaputro/book-catalog-with-go/src/version"
+)
+
+// errVersionRequired is returned for a change that does not name the version it is based on
+var errVersionRequired = apperror.PreconditionRequired("the version is required, use the version of the record")
+
+// policies are the deletion policies of the DeletionPolicy values
+var policies = map[catalogv1.DeletionPolicy]deletion.Policy{
+	catalogv1.DeletionPolicy_DELETION_POLICY_RESTRICT: deletion.PolicyRestrict,
+	catalogv1.DeletionPolicy_DELETION_POLICY_CASCADE:  deletion.PolicyCascade,
+	catalogv1.DeletionPolicy_DELETION_POLICY_REASSIGN: deletion.PolicyReassign,
+}
+
+// listQuery reads the sort, page and fuzzy matching options of a list request on fields
+func listQuery(options *catalogv1.ListOptions, filter fuzzyFilter, fields sorting.Fields) (sorting.Spec, pagination.Request, fuzzy.Options, error) {
+	sort, err := sortSpec(options.GetSort(), fields)
+	if err != nil {
+		return nil, pagination.Request{}, fuzzy.Options{}, err
+	}
+	match, err := fuzzyOptions(filter)
+	if err != nil {
+		return nil, pagination.Request{}, fuzzy.Options{}, err
+	}
+	page, err := pageRequest(options, sort)
+	if err != nil {
+		return nil, pagination.Request{}, fuzzy.Options{}, err
+	}
+	if match.Enabled && page.Cursor != nil {
+		return nil, pagination.Request{}, fuzzy.Options{}, apperror.BadRequest("cursor", pagination.ErrCursorUnsupported)
+	}
+	return sort, page, match, nil
+}
+
+// sortSpec reads the sort option of a list request on fields
+func sortSpec(raw string, fields sorting.Fields) (sorting.Spec, error) {
+	sort, err := sorting.FromQuery(raw, "", "", fields)
+	if err != nil {
+		return nil, apperror.BadRequest("sort", err)
+	}
+	return sort.Stable(fields), nil
+}
+
+// fuzzyFilter is a filter message with the fuzzy and optional threshold fields
+type fuzzyFilter interface {
+	GetFuzzy() bool
+	GetThreshold() float64
+	ProtoReflect() protoreflect.Message
+}
+
+// fuzzyOptions reads the fuzzy matching options of a filter, which may be nil
+func fuzzyOptions(filter fuzzyFilter) (fuzzy.Options, error) {
+	match := fuzzy.Options{Enabled: filter.GetFuzzy(), Threshold: fuzzy.DefaultThreshold}
+	if message := filter.ProtoReflect(); message.Has(message.Descriptor().Fields().ByName("threshold")) {
+		match.Threshold = filter.GetThreshold()
+	}
+	if err := match.Validate(); err != nil {
+		return fuzzy.Options{}, apperror.BadRequest("threshold", err)
+	}
+	return match, nil
+}
+
+// pageRequest reads the page, page_size and cursor options like pagination.FromQuery
+func pageRequest(options *catalogv1.ListOptions, sort sorting.Spec) (pagination.Request, error) {
+	request := pagination.Request{Page: 1, PageSize: pagination.DefaultPageSize}
+	if options.GetPage() > 0 {
+		request.Page = uint(options.GetPage())
+	}
+	if options.GetPageSize() > 0 {
+		request.PageSize = min(uint(options.GetPageSize()), pagination.MaxPageSize)
+	}
+
+	if options.GetCursor() == "" {
+		return request, nil
+	}
+	if options.GetPage() > 0 {
+		return pagination.Request{}, apperror.BadRequest("cursor", errors.New("cursor cannot be combined with page"))
+	}
+	cursor, err := pagination.DecodeCursor(options.GetCursor(), sort)
+	if err != nil {
+		return pagination.Request{}, apperror.BadRequest("cursor", err)
+	}
+	request.Cursor = cursor
+	return request, nil
+}
+
+// pageInfo describes the position of p in its list
+func pageInfo[T any](p *pagination.Page[T]) *catalogv1.PageInfo {
+	return &catalogv1.PageInfo{
+		Page:       uint32(p.Page),
+		PageSize:   uint32(p.PageSize),
+		Total:      p.Total,
+		TotalPages: p.TotalPages,
+		NextCursor: p.NextCursor,
+		PrevCursor: p.PrevCursor,
+	}
+}
+
+// recordID reads the record ID field of a request
+func recordID(field string, id uint64) (uint, error) {
+	if id == 0 || id > 1<<32-1 {
+		return 0, apperror.BadRequest(field, errors.New("must be a positive integer ID"))
+	}
+	return uint(id), nil
+}
+
+// recordIDs reads the list of record IDs field of a request
+func recordIDs(field string, raw []uint64) ([]uint, error) {
+	ids := make([]uint, 0, len(raw))
+	for _, item := range raw {
+		id, err := recordID(field, item)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// parseID returns the ID of a reference DTO, 0 when it has none
+func parseID(raw string) uint64 {
+	id, _ := strconv.ParseUint(raw, 10, 32)
+	return id
+}
+
+// precondition reads the version of a change, which plays the part of the If-Match header
+func precondition(v uint32) (version.Precondition, error) {
+	if v == 0 {
+		return version.Precondition{}, errVersionRequired
+	}
+	return version.Precondition{Versions: []uint{uint(v)}}, nil
+}
+
+// deletionOptions reads the policy and reassign_to fields like deletion.FromQuery
+func deletionOptions(request *catalogv1.DeleteRequest) (deletion.Options, error) {
+	options := deletion.Options{Policy: deletion.DefaultPolicy}
+	if request.GetPolicy() != catalogv1.DeletionPolicy_DELETION_POLICY_UNSPECIFIED {
+		policy, ok := policies[request.GetPolicy()]
+		if !ok {
+			return deletion.Options{}, apperror.BadRequest("policy", &deletion.PolicyError{Policy: request.GetPolicy().String()})
+		}
+		options.Policy = policy
+	}
+	if options.Policy != deletion.PolicyReassign {
+		if request.GetReassignTo() != 0 {
+			return deletion.Options{}, apperror.BadRequest("reassign_to", errors.New("reassign_to only applies to the reassign policy"))
+		}
+		return options, nil
+	}
+	id, err := recordID("reassign_to", request.GetReassignTo())
+	if err != nil {
+		return deletion.Options{}, apperror.BadRequest("reassign_to", errors.New("reassign_to must be the ID of the record taking over the references"))
+	}
+	options.ReassignTo = id
+	return options, nil
+}
@@ -0,0 +1,153 @@
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/author"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/rpc/catalogv1"
+)
+
+// authorServer serves AuthorService on the AuthorService of the REST API
+type authorServer struct {
+	catalogv1.UnimplementedAuthorServiceServer
+	authors author.AuthorService
+}
+
+// GetAuthor returns an author by ID
+func (s *authorServer) GetAuthor(ctx context.Context, req *catalogv1.GetRequest) (*catalogv1.Author, error) {
+	id, err := recordID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.authors.GetAuthor(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toAuthor(result), nil
+}
+
+// ListAuthors returns a page of the authors matching the filter
+func (s *authorServer) ListAuthors(ctx context.Context, req *catalogv1.ListAuthorsRequest) (*catalogv1.ListAuthorsResponse, error) {
+	filter := req.GetFilter()
+	sort, page, match, err := listQuery(req.GetOptions(), filter, author.SortFields)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.authors.GetAuthors(ctx, page, sort, filter.GetName(), match)
+	if err != nil {
+		return nil, err
+	}
+	authors, err := s.details(ctx, result.Data)
+	if err != nil {
+		return nil, err
+	}
+	return &catalogv1.ListAuthorsResponse{Authors: authors, Page: pageInfo(result)}, nil
+}
+
+// StreamAuthors sends every author matching the filter
+func (s *authorServer) StreamAuthors(req *catalogv1.StreamAuthorsRequest, srv grpc.ServerStreamingServer[catalogv1.Author]) error {
+	ctx, filter := srv.Context(), req.GetFilter()
+	sort, err := sortSpec(req.GetSort(), author.SortFields)
+	if err != nil {
+		return err
+	}
+	match, err := fuzzyOptions(filter)
+	if err != nil {
+		return err
+	}
+
+	return stream(sort, match.Enabled, func(page pagination.Request) (*author.AuthorListResponse, error) {
+		return s.authors.GetAuthors(ctx, page, sort, filter.GetName(), match)
+	}, func(items []author.AuthorDTO) error {
+		authors, err := s.details(ctx, items)
+		if err != nil {
+			return err
+		}
+		for _, a := range authors {
+			if err := srv.Send(a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CreateAuthor creates an author
+func (s *authorServer) CreateAuthor(ctx context.Context, req *catalogv1.AuthorInput) (*catalogv1.Author, error) {
+	created, err := s.authors.CreateAuthor(ctx, authorRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.authors.GetAuthor(ctx, created.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toAuthor(result), nil
+}
+
+// UpdateAuthor replaces an author at the version of the request
+func (s *authorServer) UpdateAuthor(ctx context.Context, req *catalogv1.UpdateAuthorRequest) (*catalogv1.Author, error) {
+	id, err := recordID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	match, err := precondition(req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.authors.UpdateAuthor(ctx, id, authorRequest(req.GetAuthor()), match)
+	if err != nil {
+		return nil, err
+	}
+	return toAuthor(result), nil
+}
+
+// DeleteAuthor deletes an author at the version of the request, applying its policy to the
+// books of the author
+func (s *authorServer) DeleteAuthor(ctx context.Context, req *catalogv1.DeleteRequest) (*emptypb.Empty, error) {
+	id, err := recordID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	match, err := precondition(req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+	options, err := deletionOptions(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authors.DeleteAuthor(ctx, id, match, options); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// details returns the messages of the authors of a list page
+func (s *authorServer) details(ctx context.Context, items []author.AuthorDTO) ([]*catalogv1.Author, error) {
+	authors, err := details(items, func(a author.AuthorDTO) string { return a.ID }, func(ids []uint) ([]author.AuthorDetailResponse, error) {
+		return s.authors.GetAuthorsByIDs(ctx, ids)
+	}, func(a *author.AuthorDetailResponse) uint { return a.ID })
+	if err != nil {
+		return nil, err
+	}
+	messages := make([]*catalogv1.Author, len(authors))
+	for i := range authors {
+		messages[i] = toAuthor(&authors[i])
+	}
+	return messages, nil
+}
+
+// authorRequest reads an AuthorInput
+func authorRequest(in *catalogv1.AuthorInput) author.AuthorRequest {
+	return author.AuthorRequest{Name: in.GetName(), Description: in.GetDescription()}
+}
+
+// toAuthor converts an author into its message
+func toAuthor(a *author.AuthorDetailResponse) *catalogv1.Author {
+	return &catalogv1.Author{Id: uint64(a.ID), Version: uint32(a.Version), Name: a.Name, Description: a.Description}
+}
@@ -0,0 +1,195 @@
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/rpc/catalogv1"
+)
+
+// bookServer serves BookService on the BookService of the REST API
+type bookServer struct {
+	catalogv1.UnimplementedBookServiceServer
+	books book.BookService
+}
+
+// GetBook returns a book by ID
+func (s *bookServer) GetBook(ctx context.Context, req *catalogv1.GetRequest) (*catalogv1.Book, error) {
+	id, err := recordID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.books.GetBook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toBook(result), nil
+}
+
+// GetBookByISBN returns a book by ISBN-10 or ISBN-13
+func (s *bookServer) GetBookByISBN(ctx context.Context, req *catalogv1.GetBookByISBNRequest) (*catalogv1.Book, error) {
+	result, err := s.books.GetBookByISBN(ctx, req.GetIsbn())
+	if err != nil {
+		return nil, err
+	}
+	return toBook(result), nil
+}
+
+// ListBooks returns a page of the books matching the filter
+func (s *bookServer) ListBooks(ctx context.Context, req *catalogv1.ListBooksRequest) (*catalogv1.ListBooksResponse, error) {
+	filter := req.GetFilter()
+	sort, page, match, err := listQuery(req.GetOptions(), filter, book.SortFields)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.books.GetBooks(ctx, page, sort, book.BookFilter{Title: filter.GetTitle(), CategoryCodes: filter.GetCategories(), Fuzzy: match})
+	if err != nil {
+		return nil, err
+	}
+	return &catalogv1.ListBooksResponse{Books: toBooks(result.Data), Page: pageInfo(result)}, nil
+}
+
+// StreamBooks sends every book matching the filter
+func (s *bookServer) StreamBooks(req *catalogv1.StreamBooksRequest, srv grpc.ServerStreamingServer[catalogv1.Book]) error {
+	ctx, filter := srv.Context(), req.GetFilter()
+	sort, err := sortSpec(req.GetSort(), book.SortFields)
+	if err != nil {
+		return err
+	}
+	match, err := fuzzyOptions(filter)
+	if err != nil {
+		return err
+	}
+	bookFilter := book.BookFilter{Title: filter.GetTitle(), CategoryCodes: filter.GetCategories(), Fuzzy: match}
+
+	return stream(sort, match.Enabled, func(page pagination.Request) (*book.BookListResponse, error) {
+		return s.books.GetBooks(ctx, page, sort, bookFilter)
+	}, func(books []book.BookDetailResponse) error {
+		for i := range books {
+			if err := srv.Send(toBook(&books[i])); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CreateBook creates a book
+func (s *bookServer) CreateBook(ctx context.Context, req *catalogv1.BookInput) (*catalogv1.Book, error) {
+	request, err := bookRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	created, err := s.books.CreateBook(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.books.GetBook(ctx, created.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toBook(result), nil
+}
+
+// UpdateBook replaces a book at the version of the request
+func (s *bookServer) UpdateBook(ctx context.Context, req *catalogv1.UpdateBookRequest) (*catalogv1.Book, error) {
+	id, err := recordID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	match, err := precondition(req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+	request, err := bookRequest(req.GetBook())
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.books.UpdateBook(ctx, id, request, match)
+	if err != nil {
+		return nil, err
+	}
+	return toBook(result), nil
+}
+
+// DeleteBook deletes a book at the version of the request
+func (s *bookServer) DeleteBook(ctx context.Context, req *catalogv1.DeleteRequest) (*emptypb.Empty, error) {
+	id, err := recordID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	match, err := precondition(req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.books.DeleteBook(ctx, id, match); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// bookRequest reads a BookInput
+func bookRequest(in *catalogv1.BookInput) (book.BookRequest, error) {
+	request := book.BookRequest{
+		Title:       in.GetTitle(),
+		Description: in.GetDescription(),
+		ISBN10:      in.GetIsbn10(),
+		ISBN13:      in.GetIsbn13(),
+		Pages:       uint(in.GetPages()),
+		Year:        uint(in.GetYear()),
+	}
+
+	var err error
+	if request.PublisherID, err = recordID("publisher_id", in.GetPublisherId()); err != nil {
+		return book.BookRequest{}, err
+	}
+	if request.AuthorIDs, err = recordIDs("author_ids", in.GetAuthorIds()); err != nil {
+		return book.BookRequest{}, err
+	}
+	if request.CategoryIDs, err = recordIDs("category_ids", in.GetCategoryIds()); err != nil {
+		return book.BookRequest{}, err
+	}
+	return request, nil
+}
+
+// toBook converts a book into its message
+func toBook(b *book.BookDetailResponse) *catalogv1.Book {
+	message := &catalogv1.Book{
+		Id:          uint64(b.ID),
+		Version:     uint32(b.Version),
+		Title:       b.Title,
+		Description: b.Description,
+		Isbn10:      b.ISBN10,
+		Isbn13:      b.ISBN13,
+		Pages:       uint32(b.Pages),
+		Year:        uint32(b.Year),
+		Authors:     make([]*catalogv1.Reference, len(b.Authors)),
+		Categories:  make([]*catalogv1.CategoryReference, len(b.Categories)),
+		CreatedAt:   timestamppb.New(b.CreatedAt),
+		UpdatedAt:   timestamppb.New(b.UpdatedAt),
+	}
+	if id := parseID(b.Publisher.ID); id != 0 {
+		message.Publisher = &catalogv1.Reference{Id: id, Name: b.Publisher.Name}
+	}
+	for i, a := range b.Authors {
+		message.Authors[i] = &catalogv1.Reference{Id: parseID(a.ID), Name: a.Name}
+	}
+	for i, c := range b.Categories {
+		message.Categories[i] = &catalogv1.CategoryReference{Id: parseID(c.ID), Code: c.Code, Name: c.Name}
+	}
+	return message
+}
+
+// toBooks converts books into their messages
+func toBooks(books []book.BookDetailResponse) []*catalogv1.Book {
+	messages := make([]*catalogv1.Book, len(books))
+	for i := range books {
+		messages[i] = toBook(&books[i])
+	}
+	return messages
+}
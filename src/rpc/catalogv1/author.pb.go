@@ -0,0 +1,474 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: catalog/v1/author.proto
+
+package catalogv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Author struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       uint32                 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Author) Reset() {
+	*x = Author{}
+	mi := &file_catalog_v1_author_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Author) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Author) ProtoMessage() {}
+
+func (x *Author) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_author_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Author.ProtoReflect.Descriptor instead.
+func (*Author) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_author_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Author) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Author) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Author) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Author) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type AuthorInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthorInput) Reset() {
+	*x = AuthorInput{}
+	mi := &file_catalog_v1_author_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthorInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthorInput) ProtoMessage() {}
+
+func (x *AuthorInput) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_author_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthorInput.ProtoReflect.Descriptor instead.
+func (*AuthorInput) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_author_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AuthorInput) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AuthorInput) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type ListAuthorsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       *ListOptions           `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	Filter        *NameFilter            `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuthorsRequest) Reset() {
+	*x = ListAuthorsRequest{}
+	mi := &file_catalog_v1_author_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuthorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuthorsRequest) ProtoMessage() {}
+
+func (x *ListAuthorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_author_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuthorsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuthorsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_author_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListAuthorsRequest) GetOptions() *ListOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *ListAuthorsRequest) GetFilter() *NameFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type ListAuthorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Authors       []*Author              `protobuf:"bytes,1,rep,name=authors,proto3" json:"authors,omitempty"`
+	Page          *PageInfo              `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuthorsResponse) Reset() {
+	*x = ListAuthorsResponse{}
+	mi := &file_catalog_v1_author_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuthorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuthorsResponse) ProtoMessage() {}
+
+func (x *ListAuthorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_author_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuthorsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuthorsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_author_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListAuthorsResponse) GetAuthors() []*Author {
+	if x != nil {
+		return x.Authors
+	}
+	return nil
+}
+
+func (x *ListAuthorsResponse) GetPage() *PageInfo {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type StreamAuthorsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Comma separated sort fields, prefixed with - for descending order
+	Sort          string      `protobuf:"bytes,1,opt,name=sort,proto3" json:"sort,omitempty"`
+	Filter        *NameFilter `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamAuthorsRequest) Reset() {
+	*x = StreamAuthorsRequest{}
+	mi := &file_catalog_v1_author_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamAuthorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamAuthorsRequest) ProtoMessage() {}
+
+func (x *StreamAuthorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_author_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamAuthorsRequest.ProtoReflect.Descriptor instead.
+func (*StreamAuthorsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_author_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StreamAuthorsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *StreamAuthorsRequest) GetFilter() *NameFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// UpdateAuthorRequest replaces an author at the version the client expects, like If-Match
+type UpdateAuthorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       uint32                 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Author        *AuthorInput           `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAuthorRequest) Reset() {
+	*x = UpdateAuthorRequest{}
+	mi := &file_catalog_v1_author_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAuthorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAuthorRequest) ProtoMessage() {}
+
+func (x *UpdateAuthorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_author_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAuthorRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAuthorRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_author_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateAuthorRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateAuthorRequest) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UpdateAuthorRequest) GetAuthor() *AuthorInput {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+var File_catalog_v1_author_proto protoreflect.FileDescriptor
+
+const file_catalog_v1_author_proto_rawDesc = "" +
+	"\n" +
+	"\x17catalog/v1/author.proto\x12\n" +
+	"catalog.v1\x1a\x17catalog/v1/common.proto\x1a\x1bgoogle/protobuf/empty.proto\"h\n" +
+	"\x06Author\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\rR\aversion\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"C\n" +
+	"\vAuthorInput\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\"w\n" +
+	"\x12ListAuthorsRequest\x121\n" +
+	"\aoptions\x18\x01 \x01(\v2\x17.catalog.v1.ListOptionsR\aoptions\x12.\n" +
+	"\x06filter\x18\x02 \x01(\v2\x16.catalog.v1.NameFilterR\x06filter\"m\n" +
+	"\x13ListAuthorsResponse\x12,\n" +
+	"\aauthors\x18\x01 \x03(\v2\x12.catalog.v1.AuthorR\aauthors\x12(\n" +
+	"\x04page\x18\x02 \x01(\v2\x14.catalog.v1.PageInfoR\x04page\"Z\n" +
+	"\x14StreamAuthorsRequest\x12\x12\n" +
+	"\x04sort\x18\x01 \x01(\tR\x04sort\x12.\n" +
+	"\x06filter\x18\x02 \x01(\v2\x16.catalog.v1.NameFilterR\x06filter\"p\n" +
+	"\x13UpdateAuthorRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\rR\aversion\x12/\n" +
+	"\x06author\x18\x03 \x01(\v2\x17.catalog.v1.AuthorInputR\x06author2\xa6\x03\n" +
+	"\rAuthorService\x127\n" +
+	"\tGetAuthor\x12\x16.catalog.v1.GetRequest\x1a\x12.catalog.v1.Author\x12N\n" +
+	"\vListAuthors\x12\x1e.catalog.v1.ListAuthorsRequest\x1a\x1f.catalog.v1.ListAuthorsResponse\x12G\n" +
+	"\rStreamAuthors\x12 .catalog.v1.StreamAuthorsRequest\x1a\x12.catalog.v1.Author0\x01\x12;\n" +
+	"\fCreateAuthor\x12\x17.catalog.v1.AuthorInput\x1a\x12.catalog.v1.Author\x12C\n" +
+	"\fUpdateAuthor\x12\x1f.catalog.v1.UpdateAuthorRequest\x1a\x12.catalog.v1.Author\x12A\n" +
+	"\fDeleteAuthor\x12\x19.catalog.v1.DeleteRequest\x1a\x16.google.protobuf.EmptyBIZGgithub.com/tedysaputro/book-catalog-with-go/src/rpc/catalogv1;catalogv1b\x06proto3"
+
+var (
+	file_catalog_v1_author_proto_rawDescOnce sync.Once
+	file_catalog_v1_author_proto_rawDescData []byte
+)
+
+func file_catalog_v1_author_proto_rawDescGZIP() []byte {
+	file_catalog_v1_author_proto_rawDescOnce.Do(func() {
+		file_catalog_v1_author_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_catalog_v1_author_proto_rawDesc), len(file_catalog_v1_author_proto_rawDesc)))
+	})
+	return file_catalog_v1_author_proto_rawDescData
+}
+
+var file_catalog_v1_author_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_catalog_v1_author_proto_goTypes = []any{
+	(*Author)(nil),               // 0: catalog.v1.Author
+	(*AuthorInput)(nil),          // 1: catalog.v1.AuthorInput
+	(*ListAuthorsRequest)(nil),   // 2: catalog.v1.ListAuthorsRequest
+	(*ListAuthorsResponse)(nil),  // 3: catalog.v1.ListAuthorsResponse
+	(*StreamAuthorsRequest)(nil), // 4: catalog.v1.StreamAuthorsRequest
+	(*UpdateAuthorRequest)(nil),  // 5: catalog.v1.UpdateAuthorRequest
+	(*ListOptions)(nil),          // 6: catalog.v1.ListOptions
+	(*NameFilter)(nil),           // 7: catalog.v1.NameFilter
+	(*PageInfo)(nil),             // 8: catalog.v1.PageInfo
+	(*GetRequest)(nil),           // 9: catalog.v1.GetRequest
+	(*DeleteRequest)(nil),        // 10: catalog.v1.DeleteRequest
+	(*emptypb.Empty)(nil),        // 11: google.protobuf.Empty
+}
+var file_catalog_v1_author_proto_depIdxs = []int32{
+	6,  // 0: catalog.v1.ListAuthorsRequest.options:type_name -> catalog.v1.ListOptions
+	7,  // 1: catalog.v1.ListAuthorsRequest.filter:type_name -> catalog.v1.NameFilter
+	0,  // 2: catalog.v1.ListAuthorsResponse.authors:type_name -> catalog.v1.Author
+	8,  // 3: catalog.v1.ListAuthorsResponse.page:type_name -> catalog.v1.PageInfo
+	7,  // 4: catalog.v1.StreamAuthorsRequest.filter:type_name -> catalog.v1.NameFilter
+	1,  // 5: catalog.v1.UpdateAuthorRequest.author:type_name -> catalog.v1.AuthorInput
+	9,  // 6: catalog.v1.AuthorService.GetAuthor:input_type -> catalog.v1.GetRequest
+	2,  // 7: catalog.v1.AuthorService.ListAuthors:input_type -> catalog.v1.ListAuthorsRequest
+	4,  // 8: catalog.v1.AuthorService.StreamAuthors:input_type -> catalog.v1.StreamAuthorsRequest
+	1,  // 9: catalog.v1.AuthorService.CreateAuthor:input_type -> catalog.v1.AuthorInput
+	5,  // 10: catalog.v1.AuthorService.UpdateAuthor:input_type -> catalog.v1.UpdateAuthorRequest
+	10, // 11: catalog.v1.AuthorService.DeleteAuthor:input_type -> catalog.v1.DeleteRequest
+	0,  // 12: catalog.v1.AuthorService.GetAuthor:output_type -> catalog.v1.Author
+	3,  // 13: catalog.v1.AuthorService.ListAuthors:output_type -> catalog.v1.ListAuthorsResponse
+	0,  // 14: catalog.v1.AuthorService.StreamAuthors:output_type -> catalog.v1.Author
+	0,  // 15: catalog.v1.AuthorService.CreateAuthor:output_type -> catalog.v1.Author
+	0,  // 16: catalog.v1.AuthorService.UpdateAuthor:output_type -> catalog.v1.Author
+	11, // 17: catalog.v1.AuthorService.DeleteAuthor:output_type -> google.protobuf.Empty
+	12, // [12:18] is the sub-list for method output_type
+	6,  // [6:12] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_catalog_v1_author_proto_init() }
+func file_catalog_v1_author_proto_init() {
+	if File_catalog_v1_author_proto != nil {
+		return
+	}
+	file_catalog_v1_common_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_catalog_v1_author_proto_rawDesc), len(file_catalog_v1_author_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_catalog_v1_author_proto_goTypes,
+		DependencyIndexes: file_catalog_v1_author_proto_depIdxs,
+		MessageInfos:      file_catalog_v1_author_proto_msgTypes,
+	}.Build()
+	File_catalog_v1_author_proto = out.File
+	file_catalog_v1_author_proto_goTypes = nil
+	file_catalog_v1_author_proto_depIdxs = nil
+}
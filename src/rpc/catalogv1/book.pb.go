@@ -0,0 +1,835 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: catalog/v1/book.proto
+
+package catalogv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Book struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       uint32                 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Isbn10        string                 `protobuf:"bytes,5,opt,name=isbn10,proto3" json:"isbn10,omitempty"`
+	Isbn13        string                 `protobuf:"bytes,6,opt,name=isbn13,proto3" json:"isbn13,omitempty"`
+	Pages         uint32                 `protobuf:"varint,7,opt,name=pages,proto3" json:"pages,omitempty"`
+	Year          uint32                 `protobuf:"varint,8,opt,name=year,proto3" json:"year,omitempty"`
+	Publisher     *Reference             `protobuf:"bytes,9,opt,name=publisher,proto3" json:"publisher,omitempty"`
+	Authors       []*Reference           `protobuf:"bytes,10,rep,name=authors,proto3" json:"authors,omitempty"`
+	Categories    []*CategoryReference   `protobuf:"bytes,11,rep,name=categories,proto3" json:"categories,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Book) Reset() {
+	*x = Book{}
+	mi := &file_catalog_v1_book_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Book) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Book) ProtoMessage() {}
+
+func (x *Book) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_book_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Book.ProtoReflect.Descriptor instead.
+func (*Book) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_book_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Book) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Book) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Book) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Book) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Book) GetIsbn10() string {
+	if x != nil {
+		return x.Isbn10
+	}
+	return ""
+}
+
+func (x *Book) GetIsbn13() string {
+	if x != nil {
+		return x.Isbn13
+	}
+	return ""
+}
+
+func (x *Book) GetPages() uint32 {
+	if x != nil {
+		return x.Pages
+	}
+	return 0
+}
+
+func (x *Book) GetYear() uint32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *Book) GetPublisher() *Reference {
+	if x != nil {
+		return x.Publisher
+	}
+	return nil
+}
+
+func (x *Book) GetAuthors() []*Reference {
+	if x != nil {
+		return x.Authors
+	}
+	return nil
+}
+
+func (x *Book) GetCategories() []*CategoryReference {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *Book) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Book) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// CategoryReference names a category of a book
+type CategoryReference struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryReference) Reset() {
+	*x = CategoryReference{}
+	mi := &file_catalog_v1_book_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryReference) ProtoMessage() {}
+
+func (x *CategoryReference) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_book_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryReference.ProtoReflect.Descriptor instead.
+func (*CategoryReference) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_book_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CategoryReference) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CategoryReference) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CategoryReference) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type BookInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Isbn10        string                 `protobuf:"bytes,3,opt,name=isbn10,proto3" json:"isbn10,omitempty"`
+	Isbn13        string                 `protobuf:"bytes,4,opt,name=isbn13,proto3" json:"isbn13,omitempty"`
+	Pages         uint32                 `protobuf:"varint,5,opt,name=pages,proto3" json:"pages,omitempty"`
+	Year          uint32                 `protobuf:"varint,6,opt,name=year,proto3" json:"year,omitempty"`
+	PublisherId   uint64                 `protobuf:"varint,7,opt,name=publisher_id,json=publisherId,proto3" json:"publisher_id,omitempty"`
+	AuthorIds     []uint64               `protobuf:"varint,8,rep,packed,name=author_ids,json=authorIds,proto3" json:"author_ids,omitempty"`
+	CategoryIds   []uint64               `protobuf:"varint,9,rep,packed,name=category_ids,json=categoryIds,proto3" json:"category_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookInput) Reset() {
+	*x = BookInput{}
+	mi := &file_catalog_v1_book_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookInput) ProtoMessage() {}
+
+func (x *BookInput) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_book_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookInput.ProtoReflect.Descriptor instead.
+func (*BookInput) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_book_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BookInput) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *BookInput) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *BookInput) GetIsbn10() string {
+	if x != nil {
+		return x.Isbn10
+	}
+	return ""
+}
+
+func (x *BookInput) GetIsbn13() string {
+	if x != nil {
+		return x.Isbn13
+	}
+	return ""
+}
+
+func (x *BookInput) GetPages() uint32 {
+	if x != nil {
+		return x.Pages
+	}
+	return 0
+}
+
+func (x *BookInput) GetYear() uint32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *BookInput) GetPublisherId() uint64 {
+	if x != nil {
+		return x.PublisherId
+	}
+	return 0
+}
+
+func (x *BookInput) GetAuthorIds() []uint64 {
+	if x != nil {
+		return x.AuthorIds
+	}
+	return nil
+}
+
+func (x *BookInput) GetCategoryIds() []uint64 {
+	if x != nil {
+		return x.CategoryIds
+	}
+	return nil
+}
+
+// BookFilter matches books like the filters of GET /api/v1/books
+type BookFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Matches titles containing it, or resembling it when fuzzy is set
+	Title string `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	// Matches books in at least one of the category codes
+	Categories []string `protobuf:"bytes,2,rep,name=categories,proto3" json:"categories,omitempty"`
+	Fuzzy      bool     `protobuf:"varint,3,opt,name=fuzzy,proto3" json:"fuzzy,omitempty"`
+	// Similarity a fuzzy match needs, between 0 and 1 (default: the server's FUZZY_THRESHOLD)
+	Threshold     *float64 `protobuf:"fixed64,4,opt,name=threshold,proto3,oneof" json:"threshold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookFilter) Reset() {
+	*x = BookFilter{}
+	mi := &file_catalog_v1_book_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookFilter) ProtoMessage() {}
+
+func (x *BookFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_book_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookFilter.ProtoReflect.Descriptor instead.
+func (*BookFilter) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_book_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BookFilter) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *BookFilter) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *BookFilter) GetFuzzy() bool {
+	if x != nil {
+		return x.Fuzzy
+	}
+	return false
+}
+
+func (x *BookFilter) GetThreshold() float64 {
+	if x != nil && x.Threshold != nil {
+		return *x.Threshold
+	}
+	return 0
+}
+
+type GetBookByISBNRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ISBN-10 or ISBN-13, with or without hyphens
+	Isbn          string `protobuf:"bytes,1,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBookByISBNRequest) Reset() {
+	*x = GetBookByISBNRequest{}
+	mi := &file_catalog_v1_book_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBookByISBNRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBookByISBNRequest) ProtoMessage() {}
+
+func (x *GetBookByISBNRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_book_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBookByISBNRequest.ProtoReflect.Descriptor instead.
+func (*GetBookByISBNRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_book_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetBookByISBNRequest) GetIsbn() string {
+	if x != nil {
+		return x.Isbn
+	}
+	return ""
+}
+
+type ListBooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       *ListOptions           `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	Filter        *BookFilter            `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBooksRequest) Reset() {
+	*x = ListBooksRequest{}
+	mi := &file_catalog_v1_book_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBooksRequest) ProtoMessage() {}
+
+func (x *ListBooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_book_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBooksRequest.ProtoReflect.Descriptor instead.
+func (*ListBooksRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_book_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListBooksRequest) GetOptions() *ListOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *ListBooksRequest) GetFilter() *BookFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type ListBooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Books         []*Book                `protobuf:"bytes,1,rep,name=books,proto3" json:"books,omitempty"`
+	Page          *PageInfo              `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBooksResponse) Reset() {
+	*x = ListBooksResponse{}
+	mi := &file_catalog_v1_book_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBooksResponse) ProtoMessage() {}
+
+func (x *ListBooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_book_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBooksResponse.ProtoReflect.Descriptor instead.
+func (*ListBooksResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_book_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListBooksResponse) GetBooks() []*Book {
+	if x != nil {
+		return x.Books
+	}
+	return nil
+}
+
+func (x *ListBooksResponse) GetPage() *PageInfo {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type StreamBooksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Comma separated sort fields, prefixed with - for descending order
+	Sort          string      `protobuf:"bytes,1,opt,name=sort,proto3" json:"sort,omitempty"`
+	Filter        *BookFilter `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamBooksRequest) Reset() {
+	*x = StreamBooksRequest{}
+	mi := &file_catalog_v1_book_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamBooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamBooksRequest) ProtoMessage() {}
+
+func (x *StreamBooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_book_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamBooksRequest.ProtoReflect.Descriptor instead.
+func (*StreamBooksRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_book_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StreamBooksRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *StreamBooksRequest) GetFilter() *BookFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// UpdateBookRequest replaces a book at the version the client expects, like If-Match
+type UpdateBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       uint32                 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Book          *BookInput             `protobuf:"bytes,3,opt,name=book,proto3" json:"book,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBookRequest) Reset() {
+	*x = UpdateBookRequest{}
+	mi := &file_catalog_v1_book_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBookRequest) ProtoMessage() {}
+
+func (x *UpdateBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_book_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBookRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBookRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_book_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateBookRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateBookRequest) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UpdateBookRequest) GetBook() *BookInput {
+	if x != nil {
+		return x.Book
+	}
+	return nil
+}
+
+var File_catalog_v1_book_proto protoreflect.FileDescriptor
+
+const file_catalog_v1_book_proto_rawDesc = "" +
+	"\n" +
+	"\x15catalog/v1/book.proto\x12\n" +
+	"catalog.v1\x1a\x17catalog/v1/common.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xdd\x03\n" +
+	"\x04Book\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\rR\aversion\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06isbn10\x18\x05 \x01(\tR\x06isbn10\x12\x16\n" +
+	"\x06isbn13\x18\x06 \x01(\tR\x06isbn13\x12\x14\n" +
+	"\x05pages\x18\a \x01(\rR\x05pages\x12\x12\n" +
+	"\x04year\x18\b \x01(\rR\x04year\x123\n" +
+	"\tpublisher\x18\t \x01(\v2\x15.catalog.v1.ReferenceR\tpublisher\x12/\n" +
+	"\aauthors\x18\n" +
+	" \x03(\v2\x15.catalog.v1.ReferenceR\aauthors\x12=\n" +
+	"\n" +
+	"categories\x18\v \x03(\v2\x1d.catalog.v1.CategoryReferenceR\n" +
+	"categories\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"K\n" +
+	"\x11CategoryReference\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"\x82\x02\n" +
+	"\tBookInput\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06isbn10\x18\x03 \x01(\tR\x06isbn10\x12\x16\n" +
+	"\x06isbn13\x18\x04 \x01(\tR\x06isbn13\x12\x14\n" +
+	"\x05pages\x18\x05 \x01(\rR\x05pages\x12\x12\n" +
+	"\x04year\x18\x06 \x01(\rR\x04year\x12!\n" +
+	"\fpublisher_id\x18\a \x01(\x04R\vpublisherId\x12\x1d\n" +
+	"\n" +
+	"author_ids\x18\b \x03(\x04R\tauthorIds\x12!\n" +
+	"\fcategory_ids\x18\t \x03(\x04R\vcategoryIds\"\x89\x01\n" +
+	"\n" +
+	"BookFilter\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x1e\n" +
+	"\n" +
+	"categories\x18\x02 \x03(\tR\n" +
+	"categories\x12\x14\n" +
+	"\x05fuzzy\x18\x03 \x01(\bR\x05fuzzy\x12!\n" +
+	"\tthreshold\x18\x04 \x01(\x01H\x00R\tthreshold\x88\x01\x01B\f\n" +
+	"\n" +
+	"_threshold\"*\n" +
+	"\x14GetBookByISBNRequest\x12\x12\n" +
+	"\x04isbn\x18\x01 \x01(\tR\x04isbn\"u\n" +
+	"\x10ListBooksRequest\x121\n" +
+	"\aoptions\x18\x01 \x01(\v2\x17.catalog.v1.ListOptionsR\aoptions\x12.\n" +
+	"\x06filter\x18\x02 \x01(\v2\x16.catalog.v1.BookFilterR\x06filter\"e\n" +
+	"\x11ListBooksResponse\x12&\n" +
+	"\x05books\x18\x01 \x03(\v2\x10.catalog.v1.BookR\x05books\x12(\n" +
+	"\x04page\x18\x02 \x01(\v2\x14.catalog.v1.PageInfoR\x04page\"X\n" +
+	"\x12StreamBooksRequest\x12\x12\n" +
+	"\x04sort\x18\x01 \x01(\tR\x04sort\x12.\n" +
+	"\x06filter\x18\x02 \x01(\v2\x16.catalog.v1.BookFilterR\x06filter\"h\n" +
+	"\x11UpdateBookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\rR\aversion\x12)\n" +
+	"\x04book\x18\x03 \x01(\v2\x15.catalog.v1.BookInputR\x04book2\xcb\x03\n" +
+	"\vBookService\x123\n" +
+	"\aGetBook\x12\x16.catalog.v1.GetRequest\x1a\x10.catalog.v1.Book\x12C\n" +
+	"\rGetBookByISBN\x12 .catalog.v1.GetBookByISBNRequest\x1a\x10.catalog.v1.Book\x12H\n" +
+	"\tListBooks\x12\x1c.catalog.v1.ListBooksRequest\x1a\x1d.catalog.v1.ListBooksResponse\x12A\n" +
+	"\vStreamBooks\x12\x1e.catalog.v1.StreamBooksRequest\x1a\x10.catalog.v1.Book0\x01\x125\n" +
+	"\n" +
+	"CreateBook\x12\x15.catalog.v1.BookInput\x1a\x10.catalog.v1.Book\x12=\n" +
+	"\n" +
+	"UpdateBook\x12\x1d.catalog.v1.UpdateBookRequest\x1a\x10.catalog.v1.Book\x12?\n" +
+	"\n" +
+	"DeleteBook\x12\x19.catalog.v1.DeleteRequest\x1a\x16.google.protobuf.EmptyBIZGgithub.com/tedysaputro/book-catalog-with-go/src/rpc/catalogv1;catalogv1b\x06proto3"
+
+var (
+	file_catalog_v1_book_proto_rawDescOnce sync.Once
+	file_catalog_v1_book_proto_rawDescData []byte
+)
+
+func file_catalog_v1_book_proto_rawDescGZIP() []byte {
+	file_catalog_v1_book_proto_rawDescOnce.Do(func() {
+		file_catalog_v1_book_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_catalog_v1_book_proto_rawDesc), len(file_catalog_v1_book_proto_rawDesc)))
+	})
+	return file_catalog_v1_book_proto_rawDescData
+}
+
+var file_catalog_v1_book_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_catalog_v1_book_proto_goTypes = []any{
+	(*Book)(nil),                  // 0: catalog.v1.Book
+	(*CategoryReference)(nil),     // 1: catalog.v1.CategoryReference
+	(*BookInput)(nil),             // 2: catalog.v1.BookInput
+	(*BookFilter)(nil),            // 3: catalog.v1.BookFilter
+	(*GetBookByISBNRequest)(nil),  // 4: catalog.v1.GetBookByISBNRequest
+	(*ListBooksRequest)(nil),      // 5: catalog.v1.ListBooksRequest
+	(*ListBooksResponse)(nil),     // 6: catalog.v1.ListBooksResponse
+	(*StreamBooksRequest)(nil),    // 7: catalog.v1.StreamBooksRequest
+	(*UpdateBookRequest)(nil),     // 8: catalog.v1.UpdateBookRequest
+	(*Reference)(nil),             // 9: catalog.v1.Reference
+	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
+	(*ListOptions)(nil),           // 11: catalog.v1.ListOptions
+	(*PageInfo)(nil),              // 12: catalog.v1.PageInfo
+	(*GetRequest)(nil),            // 13: catalog.v1.GetRequest
+	(*DeleteRequest)(nil),         // 14: catalog.v1.DeleteRequest
+	(*emptypb.Empty)(nil),         // 15: google.protobuf.Empty
+}
+var file_catalog_v1_book_proto_depIdxs = []int32{
+	9,  // 0: catalog.v1.Book.publisher:type_name -> catalog.v1.Reference
+	9,  // 1: catalog.v1.Book.authors:type_name -> catalog.v1.Reference
+	1,  // 2: catalog.v1.Book.categories:type_name -> catalog.v1.CategoryReference
+	10, // 3: catalog.v1.Book.created_at:type_name -> google.protobuf.Timestamp
+	10, // 4: catalog.v1.Book.updated_at:type_name -> google.protobuf.Timestamp
+	11, // 5: catalog.v1.ListBooksRequest.options:type_name -> catalog.v1.ListOptions
+	3,  // 6: catalog.v1.ListBooksRequest.filter:type_name -> catalog.v1.BookFilter
+	0,  // 7: catalog.v1.ListBooksResponse.books:type_name -> catalog.v1.Book
+	12, // 8: catalog.v1.ListBooksResponse.page:type_name -> catalog.v1.PageInfo
+	3,  // 9: catalog.v1.StreamBooksRequest.filter:type_name -> catalog.v1.BookFilter
+	2,  // 10: catalog.v1.UpdateBookRequest.book:type_name -> catalog.v1.BookInput
+	13, // 11: catalog.v1.BookService.GetBook:input_type -> catalog.v1.GetRequest
+	4,  // 12: catalog.v1.BookService.GetBookByISBN:input_type -> catalog.v1.GetBookByISBNRequest
+	5,  // 13: catalog.v1.BookService.ListBooks:input_type -> catalog.v1.ListBooksRequest
+	7,  // 14: catalog.v1.BookService.StreamBooks:input_type -> catalog.v1.StreamBooksRequest
+	2,  // 15: catalog.v1.BookService.CreateBook:input_type -> catalog.v1.BookInput
+	8,  // 16: catalog.v1.BookService.UpdateBook:input_type -> catalog.v1.UpdateBookRequest
+	14, // 17: catalog.v1.BookService.DeleteBook:input_type -> catalog.v1.DeleteRequest
+	0,  // 18: catalog.v1.BookService.GetBook:output_type -> catalog.v1.Book
+	0,  // 19: catalog.v1.BookService.GetBookByISBN:output_type -> catalog.v1.Book
+	6,  // 20: catalog.v1.BookService.ListBooks:output_type -> catalog.v1.ListBooksResponse
+	0,  // 21: catalog.v1.BookService.StreamBooks:output_type -> catalog.v1.Book
+	0,  // 22: catalog.v1.BookService.CreateBook:output_type -> catalog.v1.Book
+	0,  // 23: catalog.v1.BookService.UpdateBook:output_type -> catalog.v1.Book
+	15, // 24: catalog.v1.BookService.DeleteBook:output_type -> google.protobuf.Empty
+	18, // [18:25] is the sub-list for method output_type
+	11, // [11:18] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_catalog_v1_book_proto_init() }
+func file_catalog_v1_book_proto_init() {
+	if File_catalog_v1_book_proto != nil {
+		return
+	}
+	file_catalog_v1_common_proto_init()
+	file_catalog_v1_book_proto_msgTypes[3].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_catalog_v1_book_proto_rawDesc), len(file_catalog_v1_book_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_catalog_v1_book_proto_goTypes,
+		DependencyIndexes: file_catalog_v1_book_proto_depIdxs,
+		MessageInfos:      file_catalog_v1_book_proto_msgTypes,
+	}.Build()
+	File_catalog_v1_book_proto = out.File
+	file_catalog_v1_book_proto_goTypes = nil
+	file_catalog_v1_book_proto_depIdxs = nil
+}
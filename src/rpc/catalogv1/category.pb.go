@@ -0,0 +1,518 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: catalog/v1/category.proto
+
+package catalogv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Category struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       uint32                 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Code          string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Category) Reset() {
+	*x = Category{}
+	mi := &file_catalog_v1_category_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Category) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Category) ProtoMessage() {}
+
+func (x *Category) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_category_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Category.ProtoReflect.Descriptor instead.
+func (*Category) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_category_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Category) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Category) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Category) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Category) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Category) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Category) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Category) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CategoryInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryInput) Reset() {
+	*x = CategoryInput{}
+	mi := &file_catalog_v1_category_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryInput) ProtoMessage() {}
+
+func (x *CategoryInput) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_category_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryInput.ProtoReflect.Descriptor instead.
+func (*CategoryInput) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_category_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CategoryInput) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CategoryInput) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CategoryInput) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type ListCategoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       *ListOptions           `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	Filter        *NameFilter            `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoriesRequest) Reset() {
+	*x = ListCategoriesRequest{}
+	mi := &file_catalog_v1_category_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesRequest) ProtoMessage() {}
+
+func (x *ListCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_category_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*ListCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_category_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListCategoriesRequest) GetOptions() *ListOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *ListCategoriesRequest) GetFilter() *NameFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type ListCategoriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Categories    []*Category            `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	Page          *PageInfo              `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoriesResponse) Reset() {
+	*x = ListCategoriesResponse{}
+	mi := &file_catalog_v1_category_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesResponse) ProtoMessage() {}
+
+func (x *ListCategoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_category_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesResponse.ProtoReflect.Descriptor instead.
+func (*ListCategoriesResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_category_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListCategoriesResponse) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *ListCategoriesResponse) GetPage() *PageInfo {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type StreamCategoriesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Comma separated sort fields, prefixed with - for descending order
+	Sort          string      `protobuf:"bytes,1,opt,name=sort,proto3" json:"sort,omitempty"`
+	Filter        *NameFilter `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamCategoriesRequest) Reset() {
+	*x = StreamCategoriesRequest{}
+	mi := &file_catalog_v1_category_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamCategoriesRequest) ProtoMessage() {}
+
+func (x *StreamCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_category_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*StreamCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_category_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StreamCategoriesRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *StreamCategoriesRequest) GetFilter() *NameFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// UpdateCategoryRequest replaces a category at the version the client expects, like If-Match
+type UpdateCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       uint32                 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Category      *CategoryInput         `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCategoryRequest) Reset() {
+	*x = UpdateCategoryRequest{}
+	mi := &file_catalog_v1_category_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCategoryRequest) ProtoMessage() {}
+
+func (x *UpdateCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_category_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCategoryRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_category_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateCategoryRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateCategoryRequest) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UpdateCategoryRequest) GetCategory() *CategoryInput {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+var File_catalog_v1_category_proto protoreflect.FileDescriptor
+
+const file_catalog_v1_category_proto_rawDesc = "" +
+	"\n" +
+	"\x19catalog/v1/category.proto\x12\n" +
+	"catalog.v1\x1a\x17catalog/v1/common.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf4\x01\n" +
+	"\bCategory\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\rR\aversion\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"Y\n" +
+	"\rCategoryInput\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\"z\n" +
+	"\x15ListCategoriesRequest\x121\n" +
+	"\aoptions\x18\x01 \x01(\v2\x17.catalog.v1.ListOptionsR\aoptions\x12.\n" +
+	"\x06filter\x18\x02 \x01(\v2\x16.catalog.v1.NameFilterR\x06filter\"x\n" +
+	"\x16ListCategoriesResponse\x124\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x14.catalog.v1.CategoryR\n" +
+	"categories\x12(\n" +
+	"\x04page\x18\x02 \x01(\v2\x14.catalog.v1.PageInfoR\x04page\"]\n" +
+	"\x17StreamCategoriesRequest\x12\x12\n" +
+	"\x04sort\x18\x01 \x01(\tR\x04sort\x12.\n" +
+	"\x06filter\x18\x02 \x01(\v2\x16.catalog.v1.NameFilterR\x06filter\"x\n" +
+	"\x15UpdateCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\rR\aversion\x125\n" +
+	"\bcategory\x18\x03 \x01(\v2\x19.catalog.v1.CategoryInputR\bcategory2\xcb\x03\n" +
+	"\x0fCategoryService\x12;\n" +
+	"\vGetCategory\x12\x16.catalog.v1.GetRequest\x1a\x14.catalog.v1.Category\x12W\n" +
+	"\x0eListCategories\x12!.catalog.v1.ListCategoriesRequest\x1a\".catalog.v1.ListCategoriesResponse\x12O\n" +
+	"\x10StreamCategories\x12#.catalog.v1.StreamCategoriesRequest\x1a\x14.catalog.v1.Category0\x01\x12A\n" +
+	"\x0eCreateCategory\x12\x19.catalog.v1.CategoryInput\x1a\x14.catalog.v1.Category\x12I\n" +
+	"\x0eUpdateCategory\x12!.catalog.v1.UpdateCategoryRequest\x1a\x14.catalog.v1.Category\x12C\n" +
+	"\x0eDeleteCategory\x12\x19.catalog.v1.DeleteRequest\x1a\x16.google.protobuf.EmptyBIZGgithub.com/tedysaputro/book-catalog-with-go/src/rpc/catalogv1;catalogv1b\x06proto3"
+
+var (
+	file_catalog_v1_category_proto_rawDescOnce sync.Once
+	file_catalog_v1_category_proto_rawDescData []byte
+)
+
+func file_catalog_v1_category_proto_rawDescGZIP() []byte {
+	file_catalog_v1_category_proto_rawDescOnce.Do(func() {
+		file_catalog_v1_category_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_catalog_v1_category_proto_rawDesc), len(file_catalog_v1_category_proto_rawDesc)))
+	})
+	return file_catalog_v1_category_proto_rawDescData
+}
+
+var file_catalog_v1_category_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_catalog_v1_category_proto_goTypes = []any{
+	(*Category)(nil),                // 0: catalog.v1.Category
+	(*CategoryInput)(nil),           // 1: catalog.v1.CategoryInput
+	(*ListCategoriesRequest)(nil),   // 2: catalog.v1.ListCategoriesRequest
+	(*ListCategoriesResponse)(nil),  // 3: catalog.v1.ListCategoriesResponse
+	(*StreamCategoriesRequest)(nil), // 4: catalog.v1.StreamCategoriesRequest
+	(*UpdateCategoryRequest)(nil),   // 5: catalog.v1.UpdateCategoryRequest
+	(*timestamppb.Timestamp)(nil),   // 6: google.protobuf.Timestamp
+	(*ListOptions)(nil),             // 7: catalog.v1.ListOptions
+	(*NameFilter)(nil),              // 8: catalog.v1.NameFilter
+	(*PageInfo)(nil),                // 9: catalog.v1.PageInfo
+	(*GetRequest)(nil),              // 10: catalog.v1.GetRequest
+	(*DeleteRequest)(nil),           // 11: catalog.v1.DeleteRequest
+	(*emptypb.Empty)(nil),           // 12: google.protobuf.Empty
+}
+var file_catalog_v1_category_proto_depIdxs = []int32{
+	6,  // 0: catalog.v1.Category.created_at:type_name -> google.protobuf.Timestamp
+	6,  // 1: catalog.v1.Category.updated_at:type_name -> google.protobuf.Timestamp
+	7,  // 2: catalog.v1.ListCategoriesRequest.options:type_name -> catalog.v1.ListOptions
+	8,  // 3: catalog.v1.ListCategoriesRequest.filter:type_name -> catalog.v1.NameFilter
+	0,  // 4: catalog.v1.ListCategoriesResponse.categories:type_name -> catalog.v1.Category
+	9,  // 5: catalog.v1.ListCategoriesResponse.page:type_name -> catalog.v1.PageInfo
+	8,  // 6: catalog.v1.StreamCategoriesRequest.filter:type_name -> catalog.v1.NameFilter
+	1,  // 7: catalog.v1.UpdateCategoryRequest.category:type_name -> catalog.v1.CategoryInput
+	10, // 8: catalog.v1.CategoryService.GetCategory:input_type -> catalog.v1.GetRequest
+	2,  // 9: catalog.v1.CategoryService.ListCategories:input_type -> catalog.v1.ListCategoriesRequest
+	4,  // 10: catalog.v1.CategoryService.StreamCategories:input_type -> catalog.v1.StreamCategoriesRequest
+	1,  // 11: catalog.v1.CategoryService.CreateCategory:input_type -> catalog.v1.CategoryInput
+	5,  // 12: catalog.v1.CategoryService.UpdateCategory:input_type -> catalog.v1.UpdateCategoryRequest
+	11, // 13: catalog.v1.CategoryService.DeleteCategory:input_type -> catalog.v1.DeleteRequest
+	0,  // 14: catalog.v1.CategoryService.GetCategory:output_type -> catalog.v1.Category
+	3,  // 15: catalog.v1.CategoryService.ListCategories:output_type -> catalog.v1.ListCategoriesResponse
+	0,  // 16: catalog.v1.CategoryService.StreamCategories:output_type -> catalog.v1.Category
+	0,  // 17: catalog.v1.CategoryService.CreateCategory:output_type -> catalog.v1.Category
+	0,  // 18: catalog.v1.CategoryService.UpdateCategory:output_type -> catalog.v1.Category
+	12, // 19: catalog.v1.CategoryService.DeleteCategory:output_type -> google.protobuf.Empty
+	14, // [14:20] is the sub-list for method output_type
+	8,  // [8:14] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_catalog_v1_category_proto_init() }
+func file_catalog_v1_category_proto_init() {
+	if File_catalog_v1_category_proto != nil {
+		return
+	}
+	file_catalog_v1_common_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_catalog_v1_category_proto_rawDesc), len(file_catalog_v1_category_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_catalog_v1_category_proto_goTypes,
+		DependencyIndexes: file_catalog_v1_category_proto_depIdxs,
+		MessageInfos:      file_catalog_v1_category_proto_msgTypes,
+	}.Build()
+	File_catalog_v1_category_proto = out.File
+	file_catalog_v1_category_proto_goTypes = nil
+	file_catalog_v1_category_proto_depIdxs = nil
+}
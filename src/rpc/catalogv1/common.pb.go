@@ -0,0 +1,573 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: catalog/v1/common.proto
+
+package catalogv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// DeletionPolicy decides what happens to the books of a deleted author or publisher
+type DeletionPolicy int32
+
+const (
+	// The server's DELETE_POLICY
+	DeletionPolicy_DELETION_POLICY_UNSPECIFIED DeletionPolicy = 0
+	// Refuse the delete while books refer to the record
+	DeletionPolicy_DELETION_POLICY_RESTRICT DeletionPolicy = 1
+	// Delete the books referring to the record along with it
+	DeletionPolicy_DELETION_POLICY_CASCADE DeletionPolicy = 2
+	// Move the books to the record reassign_to before the delete
+	DeletionPolicy_DELETION_POLICY_REASSIGN DeletionPolicy = 3
+)
+
+// Enum value maps for DeletionPolicy.
+var (
+	DeletionPolicy_name = map[int32]string{
+		0: "DELETION_POLICY_UNSPECIFIED",
+		1: "DELETION_POLICY_RESTRICT",
+		2: "DELETION_POLICY_CASCADE",
+		3: "DELETION_POLICY_REASSIGN",
+	}
+	DeletionPolicy_value = map[string]int32{
+		"DELETION_POLICY_UNSPECIFIED": 0,
+		"DELETION_POLICY_RESTRICT":    1,
+		"DELETION_POLICY_CASCADE":     2,
+		"DELETION_POLICY_REASSIGN":    3,
+	}
+)
+
+func (x DeletionPolicy) Enum() *DeletionPolicy {
+	p := new(DeletionPolicy)
+	*p = x
+	return p
+}
+
+func (x DeletionPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeletionPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_catalog_v1_common_proto_enumTypes[0].Descriptor()
+}
+
+func (DeletionPolicy) Type() protoreflect.EnumType {
+	return &file_catalog_v1_common_proto_enumTypes[0]
+}
+
+func (x DeletionPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeletionPolicy.Descriptor instead.
+func (DeletionPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_catalog_v1_common_proto_rawDescGZIP(), []int{0}
+}
+
+// ListOptions selects a page of a list like the query parameters of the REST list endpoints
+type ListOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Page to return, from 1 (default: 1)
+	Page uint32 `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	// Items per page (default: 10, at most 100; larger values are capped)
+	PageSize uint32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Cursor of the page to return, taken from PageInfo; cannot be combined with page
+	Cursor string `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Comma separated sort fields, prefixed with - for descending order, e.g. "-year,title"
+	Sort          string `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOptions) Reset() {
+	*x = ListOptions{}
+	mi := &file_catalog_v1_common_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOptions) ProtoMessage() {}
+
+func (x *ListOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_common_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOptions.ProtoReflect.Descriptor instead.
+func (*ListOptions) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_common_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListOptions) GetPage() uint32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListOptions) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListOptions) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListOptions) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+// PageInfo describes the position of a page in its list
+type PageInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Page number, 0 for pages fetched by cursor
+	Page          uint32 `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      uint32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Total         uint64 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	TotalPages    uint64 `protobuf:"varint,4,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	NextCursor    string `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	PrevCursor    string `protobuf:"bytes,6,opt,name=prev_cursor,json=prevCursor,proto3" json:"prev_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PageInfo) Reset() {
+	*x = PageInfo{}
+	mi := &file_catalog_v1_common_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageInfo) ProtoMessage() {}
+
+func (x *PageInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_common_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageInfo.ProtoReflect.Descriptor instead.
+func (*PageInfo) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_common_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PageInfo) GetPage() uint32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *PageInfo) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *PageInfo) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *PageInfo) GetTotalPages() uint64 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *PageInfo) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *PageInfo) GetPrevCursor() string {
+	if x != nil {
+		return x.PrevCursor
+	}
+	return ""
+}
+
+// NameFilter matches authors, publishers or categories by name
+type NameFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Matches names containing it, or resembling it when fuzzy is set
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Fuzzy bool   `protobuf:"varint,2,opt,name=fuzzy,proto3" json:"fuzzy,omitempty"`
+	// Similarity a fuzzy match needs, between 0 and 1 (default: the server's FUZZY_THRESHOLD)
+	Threshold     *float64 `protobuf:"fixed64,3,opt,name=threshold,proto3,oneof" json:"threshold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NameFilter) Reset() {
+	*x = NameFilter{}
+	mi := &file_catalog_v1_common_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NameFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NameFilter) ProtoMessage() {}
+
+func (x *NameFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_common_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NameFilter.ProtoReflect.Descriptor instead.
+func (*NameFilter) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_common_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *NameFilter) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NameFilter) GetFuzzy() bool {
+	if x != nil {
+		return x.Fuzzy
+	}
+	return false
+}
+
+func (x *NameFilter) GetThreshold() float64 {
+	if x != nil && x.Threshold != nil {
+		return *x.Threshold
+	}
+	return 0
+}
+
+// Reference names a record related to another one
+type Reference struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reference) Reset() {
+	*x = Reference{}
+	mi := &file_catalog_v1_common_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reference) ProtoMessage() {}
+
+func (x *Reference) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_common_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reference.ProtoReflect.Descriptor instead.
+func (*Reference) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_common_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Reference) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Reference) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// GetRequest identifies a record
+type GetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRequest) Reset() {
+	*x = GetRequest{}
+	mi := &file_catalog_v1_common_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRequest) ProtoMessage() {}
+
+func (x *GetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_common_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRequest.ProtoReflect.Descriptor instead.
+func (*GetRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_common_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// DeleteRequest deletes a record at the version the client expects, like If-Match
+type DeleteRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version uint32                 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Only applies to authors and publishers
+	Policy        DeletionPolicy `protobuf:"varint,3,opt,name=policy,proto3,enum=catalog.v1.DeletionPolicy" json:"policy,omitempty"`
+	ReassignTo    uint64         `protobuf:"varint,4,opt,name=reassign_to,json=reassignTo,proto3" json:"reassign_to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRequest) Reset() {
+	*x = DeleteRequest{}
+	mi := &file_catalog_v1_common_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRequest) ProtoMessage() {}
+
+func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_common_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_common_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteRequest) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *DeleteRequest) GetPolicy() DeletionPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return DeletionPolicy_DELETION_POLICY_UNSPECIFIED
+}
+
+func (x *DeleteRequest) GetReassignTo() uint64 {
+	if x != nil {
+		return x.ReassignTo
+	}
+	return 0
+}
+
+var File_catalog_v1_common_proto protoreflect.FileDescriptor
+
+const file_catalog_v1_common_proto_rawDesc = "" +
+	"\n" +
+	"\x17catalog/v1/common.proto\x12\n" +
+	"catalog.v1\"j\n" +
+	"\vListOptions\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\rR\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\rR\bpageSize\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x12\n" +
+	"\x04sort\x18\x04 \x01(\tR\x04sort\"\xb4\x01\n" +
+	"\bPageInfo\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\rR\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\rR\bpageSize\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x04R\x05total\x12\x1f\n" +
+	"\vtotal_pages\x18\x04 \x01(\x04R\n" +
+	"totalPages\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vprev_cursor\x18\x06 \x01(\tR\n" +
+	"prevCursor\"g\n" +
+	"\n" +
+	"NameFilter\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05fuzzy\x18\x02 \x01(\bR\x05fuzzy\x12!\n" +
+	"\tthreshold\x18\x03 \x01(\x01H\x00R\tthreshold\x88\x01\x01B\f\n" +
+	"\n" +
+	"_threshold\"/\n" +
+	"\tReference\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\x1c\n" +
+	"\n" +
+	"GetRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"\x8e\x01\n" +
+	"\rDeleteRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\rR\aversion\x122\n" +
+	"\x06policy\x18\x03 \x01(\x0e2\x1a.catalog.v1.DeletionPolicyR\x06policy\x12\x1f\n" +
+	"\vreassign_to\x18\x04 \x01(\x04R\n" +
+	"reassignTo*\x8a\x01\n" +
+	"\x0eDeletionPolicy\x12\x1f\n" +
+	"\x1bDELETION_POLICY_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18DELETION_POLICY_RESTRICT\x10\x01\x12\x1b\n" +
+	"\x17DELETION_POLICY_CASCADE\x10\x02\x12\x1c\n" +
+	"\x18DELETION_POLICY_REASSIGN\x10\x03BIZGgithub.com/tedysaputro/book-catalog-with-go/src/rpc/catalogv1;catalogv1b\x06proto3"
+
+var (
+	file_catalog_v1_common_proto_rawDescOnce sync.Once
+	file_catalog_v1_common_proto_rawDescData []byte
+)
+
+func file_catalog_v1_common_proto_rawDescGZIP() []byte {
+	file_catalog_v1_common_proto_rawDescOnce.Do(func() {
+		file_catalog_v1_common_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_catalog_v1_common_proto_rawDesc), len(file_catalog_v1_common_proto_rawDesc)))
+	})
+	return file_catalog_v1_common_proto_rawDescData
+}
+
+var file_catalog_v1_common_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_catalog_v1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_catalog_v1_common_proto_goTypes = []any{
+	(DeletionPolicy)(0),   // 0: catalog.v1.DeletionPolicy
+	(*ListOptions)(nil),   // 1: catalog.v1.ListOptions
+	(*PageInfo)(nil),      // 2: catalog.v1.PageInfo
+	(*NameFilter)(nil),    // 3: catalog.v1.NameFilter
+	(*Reference)(nil),     // 4: catalog.v1.Reference
+	(*GetRequest)(nil),    // 5: catalog.v1.GetRequest
+	(*DeleteRequest)(nil), // 6: catalog.v1.DeleteRequest
+}
+var file_catalog_v1_common_proto_depIdxs = []int32{
+	0, // 0: catalog.v1.DeleteRequest.policy:type_name -> catalog.v1.DeletionPolicy
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_catalog_v1_common_proto_init() }
+func file_catalog_v1_common_proto_init() {
+	if File_catalog_v1_common_proto != nil {
+		return
+	}
+	file_catalog_v1_common_proto_msgTypes[2].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_catalog_v1_common_proto_rawDesc), len(file_catalog_v1_common_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_catalog_v1_common_proto_goTypes,
+		DependencyIndexes: file_catalog_v1_common_proto_depIdxs,
+		EnumInfos:         file_catalog_v1_common_proto_enumTypes,
+		MessageInfos:      file_catalog_v1_common_proto_msgTypes,
+	}.Build()
+	File_catalog_v1_common_proto = out.File
+	file_catalog_v1_common_proto_goTypes = nil
+	file_catalog_v1_common_proto_depIdxs = nil
+}
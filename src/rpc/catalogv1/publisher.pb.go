@@ -0,0 +1,476 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: catalog/v1/publisher.proto
+
+package catalogv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Publisher struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       uint32                 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Publisher) Reset() {
+	*x = Publisher{}
+	mi := &file_catalog_v1_publisher_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Publisher) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Publisher) ProtoMessage() {}
+
+func (x *Publisher) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_publisher_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Publisher.ProtoReflect.Descriptor instead.
+func (*Publisher) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_publisher_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Publisher) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Publisher) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Publisher) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Publisher) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type PublisherInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublisherInput) Reset() {
+	*x = PublisherInput{}
+	mi := &file_catalog_v1_publisher_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublisherInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublisherInput) ProtoMessage() {}
+
+func (x *PublisherInput) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_publisher_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublisherInput.ProtoReflect.Descriptor instead.
+func (*PublisherInput) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_publisher_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PublisherInput) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PublisherInput) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type ListPublishersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       *ListOptions           `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	Filter        *NameFilter            `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPublishersRequest) Reset() {
+	*x = ListPublishersRequest{}
+	mi := &file_catalog_v1_publisher_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPublishersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPublishersRequest) ProtoMessage() {}
+
+func (x *ListPublishersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_publisher_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPublishersRequest.ProtoReflect.Descriptor instead.
+func (*ListPublishersRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_publisher_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListPublishersRequest) GetOptions() *ListOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *ListPublishersRequest) GetFilter() *NameFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type ListPublishersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Publishers    []*Publisher           `protobuf:"bytes,1,rep,name=publishers,proto3" json:"publishers,omitempty"`
+	Page          *PageInfo              `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPublishersResponse) Reset() {
+	*x = ListPublishersResponse{}
+	mi := &file_catalog_v1_publisher_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPublishersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPublishersResponse) ProtoMessage() {}
+
+func (x *ListPublishersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_publisher_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPublishersResponse.ProtoReflect.Descriptor instead.
+func (*ListPublishersResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_publisher_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListPublishersResponse) GetPublishers() []*Publisher {
+	if x != nil {
+		return x.Publishers
+	}
+	return nil
+}
+
+func (x *ListPublishersResponse) GetPage() *PageInfo {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type StreamPublishersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Comma separated sort fields, prefixed with - for descending order
+	Sort          string      `protobuf:"bytes,1,opt,name=sort,proto3" json:"sort,omitempty"`
+	Filter        *NameFilter `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamPublishersRequest) Reset() {
+	*x = StreamPublishersRequest{}
+	mi := &file_catalog_v1_publisher_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamPublishersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamPublishersRequest) ProtoMessage() {}
+
+func (x *StreamPublishersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_publisher_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamPublishersRequest.ProtoReflect.Descriptor instead.
+func (*StreamPublishersRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_publisher_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StreamPublishersRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *StreamPublishersRequest) GetFilter() *NameFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// UpdatePublisherRequest replaces a publisher at the version the client expects, like If-Match
+type UpdatePublisherRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       uint32                 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Publisher     *PublisherInput        `protobuf:"bytes,3,opt,name=publisher,proto3" json:"publisher,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePublisherRequest) Reset() {
+	*x = UpdatePublisherRequest{}
+	mi := &file_catalog_v1_publisher_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePublisherRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePublisherRequest) ProtoMessage() {}
+
+func (x *UpdatePublisherRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_publisher_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePublisherRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePublisherRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_publisher_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdatePublisherRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdatePublisherRequest) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UpdatePublisherRequest) GetPublisher() *PublisherInput {
+	if x != nil {
+		return x.Publisher
+	}
+	return nil
+}
+
+var File_catalog_v1_publisher_proto protoreflect.FileDescriptor
+
+const file_catalog_v1_publisher_proto_rawDesc = "" +
+	"\n" +
+	"\x1acatalog/v1/publisher.proto\x12\n" +
+	"catalog.v1\x1a\x17catalog/v1/common.proto\x1a\x1bgoogle/protobuf/empty.proto\"k\n" +
+	"\tPublisher\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\rR\aversion\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"F\n" +
+	"\x0ePublisherInput\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\"z\n" +
+	"\x15ListPublishersRequest\x121\n" +
+	"\aoptions\x18\x01 \x01(\v2\x17.catalog.v1.ListOptionsR\aoptions\x12.\n" +
+	"\x06filter\x18\x02 \x01(\v2\x16.catalog.v1.NameFilterR\x06filter\"y\n" +
+	"\x16ListPublishersResponse\x125\n" +
+	"\n" +
+	"publishers\x18\x01 \x03(\v2\x15.catalog.v1.PublisherR\n" +
+	"publishers\x12(\n" +
+	"\x04page\x18\x02 \x01(\v2\x14.catalog.v1.PageInfoR\x04page\"]\n" +
+	"\x17StreamPublishersRequest\x12\x12\n" +
+	"\x04sort\x18\x01 \x01(\tR\x04sort\x12.\n" +
+	"\x06filter\x18\x02 \x01(\v2\x16.catalog.v1.NameFilterR\x06filter\"|\n" +
+	"\x16UpdatePublisherRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\rR\aversion\x128\n" +
+	"\tpublisher\x18\x03 \x01(\v2\x1a.catalog.v1.PublisherInputR\tpublisher2\xd6\x03\n" +
+	"\x10PublisherService\x12=\n" +
+	"\fGetPublisher\x12\x16.catalog.v1.GetRequest\x1a\x15.catalog.v1.Publisher\x12W\n" +
+	"\x0eListPublishers\x12!.catalog.v1.ListPublishersRequest\x1a\".catalog.v1.ListPublishersResponse\x12P\n" +
+	"\x10StreamPublishers\x12#.catalog.v1.StreamPublishersRequest\x1a\x15.catalog.v1.Publisher0\x01\x12D\n" +
+	"\x0fCreatePublisher\x12\x1a.catalog.v1.PublisherInput\x1a\x15.catalog.v1.Publisher\x12L\n" +
+	"\x0fUpdatePublisher\x12\".catalog.v1.UpdatePublisherRequest\x1a\x15.catalog.v1.Publisher\x12D\n" +
+	"\x0fDeletePublisher\x12\x19.catalog.v1.DeleteRequest\x1a\x16.google.protobuf.EmptyBIZGgithub.com/tedysaputro/book-catalog-with-go/src/rpc/catalogv1;catalogv1b\x06proto3"
+
+var (
+	file_catalog_v1_publisher_proto_rawDescOnce sync.Once
+	file_catalog_v1_publisher_proto_rawDescData []byte
+)
+
+func file_catalog_v1_publisher_proto_rawDescGZIP() []byte {
+	file_catalog_v1_publisher_proto_rawDescOnce.Do(func() {
+		file_catalog_v1_publisher_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_catalog_v1_publisher_proto_rawDesc), len(file_catalog_v1_publisher_proto_rawDesc)))
+	})
+	return file_catalog_v1_publisher_proto_rawDescData
+}
+
+var file_catalog_v1_publisher_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_catalog_v1_publisher_proto_goTypes = []any{
+	(*Publisher)(nil),               // 0: catalog.v1.Publisher
+	(*PublisherInput)(nil),          // 1: catalog.v1.PublisherInput
+	(*ListPublishersRequest)(nil),   // 2: catalog.v1.ListPublishersRequest
+	(*ListPublishersResponse)(nil),  // 3: catalog.v1.ListPublishersResponse
+	(*StreamPublishersRequest)(nil), // 4: catalog.v1.StreamPublishersRequest
+	(*UpdatePublisherRequest)(nil),  // 5: catalog.v1.UpdatePublisherRequest
+	(*ListOptions)(nil),             // 6: catalog.v1.ListOptions
+	(*NameFilter)(nil),              // 7: catalog.v1.NameFilter
+	(*PageInfo)(nil),                // 8: catalog.v1.PageInfo
+	(*GetRequest)(nil),              // 9: catalog.v1.GetRequest
+	(*DeleteRequest)(nil),           // 10: catalog.v1.DeleteRequest
+	(*emptypb.Empty)(nil),           // 11: google.protobuf.Empty
+}
+var file_catalog_v1_publisher_proto_depIdxs = []int32{
+	6,  // 0: catalog.v1.ListPublishersRequest.options:type_name -> catalog.v1.ListOptions
+	7,  // 1: catalog.v1.ListPublishersRequest.filter:type_name -> catalog.v1.NameFilter
+	0,  // 2: catalog.v1.ListPublishersResponse.publishers:type_name -> catalog.v1.Publisher
+	8,  // 3: catalog.v1.ListPublishersResponse.page:type_name -> catalog.v1.PageInfo
+	7,  // 4: catalog.v1.StreamPublishersRequest.filter:type_name -> catalog.v1.NameFilter
+	1,  // 5: catalog.v1.UpdatePublisherRequest.publisher:type_name -> catalog.v1.PublisherInput
+	9,  // 6: catalog.v1.PublisherService.GetPublisher:input_type -> catalog.v1.GetRequest
+	2,  // 7: catalog.v1.PublisherService.ListPublishers:input_type -> catalog.v1.ListPublishersRequest
+	4,  // 8: catalog.v1.PublisherService.StreamPublishers:input_type -> catalog.v1.StreamPublishersRequest
+	1,  // 9: catalog.v1.PublisherService.CreatePublisher:input_type -> catalog.v1.PublisherInput
+	5,  // 10: catalog.v1.PublisherService.UpdatePublisher:input_type -> catalog.v1.UpdatePublisherRequest
+	10, // 11: catalog.v1.PublisherService.DeletePublisher:input_type -> catalog.v1.DeleteRequest
+	0,  // 12: catalog.v1.PublisherService.GetPublisher:output_type -> catalog.v1.Publisher
+	3,  // 13: catalog.v1.PublisherService.ListPublishers:output_type -> catalog.v1.ListPublishersResponse
+	0,  // 14: catalog.v1.PublisherService.StreamPublishers:output_type -> catalog.v1.Publisher
+	0,  // 15: catalog.v1.PublisherService.CreatePublisher:output_type -> catalog.v1.Publisher
+	0,  // 16: catalog.v1.PublisherService.UpdatePublisher:output_type -> catalog.v1.Publisher
+	11, // 17: catalog.v1.PublisherService.DeletePublisher:output_type -> google.protobuf.Empty
+	12, // [12:18] is the sub-list for method output_type
+	6,  // [6:12] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_catalog_v1_publisher_proto_init() }
+func file_catalog_v1_publisher_proto_init() {
+	if File_catalog_v1_publisher_proto != nil {
+		return
+	}
+	file_catalog_v1_common_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_catalog_v1_publisher_proto_rawDesc), len(file_catalog_v1_publisher_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_catalog_v1_publisher_proto_goTypes,
+		DependencyIndexes: file_catalog_v1_publisher_proto_depIdxs,
+		MessageInfos:      file_catalog_v1_publisher_proto_msgTypes,
+	}.Build()
+	File_catalog_v1_publisher_proto = out.File
+	file_catalog_v1_publisher_proto_goTypes = nil
+	file_catalog_v1_publisher_proto_depIdxs = nil
+}
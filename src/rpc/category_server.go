@@ -0,0 +1,134 @@
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/category"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/rpc/catalogv1"
+)
+
+// categoryServer serves CategoryService on the CategoryService of the REST API
+type categoryServer struct {
+	catalogv1.UnimplementedCategoryServiceServer
+	categories category.CategoryService
+}
+
+// GetCategory returns a category by ID
+func (s *categoryServer) GetCategory(ctx context.Context, req *catalogv1.GetRequest) (*catalogv1.Category, error) {
+	id, err := recordID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.categories.GetCategory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toCategory(result), nil
+}
+
+// ListCategories returns a page of the categories matching the filter
+func (s *categoryServer) ListCategories(ctx context.Context, req *catalogv1.ListCategoriesRequest) (*catalogv1.ListCategoriesResponse, error) {
+	filter := req.GetFilter()
+	sort, page, match, err := listQuery(req.GetOptions(), filter, category.SortFields)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.categories.GetCategories(ctx, page, sort, filter.GetName(), match)
+	if err != nil {
+		return nil, err
+	}
+	categories := make([]*catalogv1.Category, len(result.Data))
+	for i := range result.Data {
+		categories[i] = toCategory(&result.Data[i])
+	}
+	return &catalogv1.ListCategoriesResponse{Categories: categories, Page: pageInfo(result)}, nil
+}
+
+// StreamCategories sends every category matching the filter
+func (s *categoryServer) StreamCategories(req *catalogv1.StreamCategoriesRequest, srv grpc.ServerStreamingServer[catalogv1.Category]) error {
+	ctx, filter := srv.Context(), req.GetFilter()
+	sort, err := sortSpec(req.GetSort(), category.SortFields)
+	if err != nil {
+		return err
+	}
+	match, err := fuzzyOptions(filter)
+	if err != nil {
+		return err
+	}
+
+	return stream(sort, match.Enabled, func(page pagination.Request) (*category.CategoryListResponse, error) {
+		return s.categories.GetCategories(ctx, page, sort, filter.GetName(), match)
+	}, func(categories []category.CategoryDetailResponse) error {
+		for i := range categories {
+			if err := srv.Send(toCategory(&categories[i])); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CreateCategory creates a category
+func (s *categoryServer) CreateCategory(ctx context.Context, req *catalogv1.CategoryInput) (*catalogv1.Category, error) {
+	result, err := s.categories.CreateCategory(ctx, categoryRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	return toCategory(result), nil
+}
+
+// UpdateCategory replaces a category at the version of the request
+func (s *categoryServer) UpdateCategory(ctx context.Context, req *catalogv1.UpdateCategoryRequest) (*catalogv1.Category, error) {
+	id, err := recordID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	match, err := precondition(req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.categories.UpdateCategory(ctx, id, categoryRequest(req.GetCategory()), match)
+	if err != nil {
+		return nil, err
+	}
+	return toCategory(result), nil
+}
+
+// DeleteCategory deletes a category at the version of the request; the deletion policy does not apply
+func (s *categoryServer) DeleteCategory(ctx context.Context, req *catalogv1.DeleteRequest) (*emptypb.Empty, error) {
+	id, err := recordID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	match, err := precondition(req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.categories.DeleteCategory(ctx, id, match); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// categoryRequest reads a CategoryInput
+func categoryRequest(in *catalogv1.CategoryInput) category.CategoryRequest {
+	return category.CategoryRequest{Code: in.GetCode(), Name: in.GetName(), Description: in.GetDescription()}
+}
+
+// toCategory converts a category into its message
+func toCategory(c *category.CategoryDetailResponse) *catalogv1.Category {
+	return &catalogv1.Category{
+		Id:          uint64(c.ID),
+		Version:     uint32(c.Version),
+		Code:        c.Code,
+		Name:        c.Name,
+		Description: c.Description,
+		CreatedAt:   timestamppb.New(c.CreatedAt),
+		UpdatedAt:   timestamppb.New(c.UpdatedAt),
+	}
+}
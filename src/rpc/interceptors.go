@@ -0,0 +1,78 @@
+package rpc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/history"
+)
+
+// ActorMetadata names the actor of the changes a call makes, like the X-Actor header
+const ActorMetadata = "x-actor"
+
+// unaryInterceptor bounds unary calls by timeout, records their changes under their actor and
+// converts the errors of the services into statuses
+func unaryInterceptor(timeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		ctx, err := withActor(ctx)
+		if err != nil {
+			return nil, toStatus(ctx, err)
+		}
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, toStatus(ctx, err)
+		}
+		return resp, nil
+	}
+}
+
+// streamInterceptor records the changes of streaming calls under their actor and converts the
+// errors of the services into statuses
+func streamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := withActor(ss.Context())
+		if err != nil {
+			return toStatus(ctx, err)
+		}
+		if err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx}); err != nil {
+			return toStatus(ss.Context(), err)
+		}
+		return nil
+	}
+}
+
+// serverStream is a grpc.ServerStream with its own context
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context of the stream
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// withActor stores the actor named by the x-actor metadata of the call in ctx, refusing names
+// the history cannot store
+func withActor(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, actor := range md.Get(ActorMetadata) {
+		if actor = strings.TrimSpace(actor); actor != "" {
+			if err := history.CheckActor(actor); err != nil {
+				return ctx, apperror.BadRequest(ActorMetadata, err)
+			}
+			return history.WithActor(ctx, actor), nil
+		}
+	}
+	return ctx, nil
+}
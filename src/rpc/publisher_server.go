@@ -0,0 +1,153 @@
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/publisher"
+	"github.com/tedysaputro/book-catalog-with-go/src/rpc/catalogv1"
+)
+
+// publisherServer serves PublisherService on the PublisherService of the REST API
+type publisherServer struct {
+	catalogv1.UnimplementedPublisherServiceServer
+	publishers publisher.PublisherService
+}
+
+// GetPublisher returns a publisher by ID
+func (s *publisherServer) GetPublisher(ctx context.Context, req *catalogv1.GetRequest) (*catalogv1.Publisher, error) {
+	id, err := recordID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.publishers.GetPublisher(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toPublisher(result), nil
+}
+
+// ListPublishers returns a page of the publishers matching the filter
+func (s *publisherServer) ListPublishers(ctx context.Context, req *catalogv1.ListPublishersRequest) (*catalogv1.ListPublishersResponse, error) {
+	filter := req.GetFilter()
+	sort, page, match, err := listQuery(req.GetOptions(), filter, publisher.SortFields)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.publishers.GetPublishers(ctx, page, sort, filter.GetName(), match)
+	if err != nil {
+		return nil, err
+	}
+	publishers, err := s.details(ctx, result.Data)
+	if err != nil {
+		return nil, err
+	}
+	return &catalogv1.ListPublishersResponse{Publishers: publishers, Page: pageInfo(result)}, nil
+}
+
+// StreamPublishers sends every publisher matching the filter
+func (s *publisherServer) StreamPublishers(req *catalogv1.StreamPublishersRequest, srv grpc.ServerStreamingServer[catalogv1.Publisher]) error {
+	ctx, filter := srv.Context(), req.GetFilter()
+	sort, err := sortSpec(req.GetSort(), publisher.SortFields)
+	if err != nil {
+		return err
+	}
+	match, err := fuzzyOptions(filter)
+	if err != nil {
+		return err
+	}
+
+	return stream(sort, match.Enabled, func(page pagination.Request) (*publisher.PublisherListResponse, error) {
+		return s.publishers.GetPublishers(ctx, page, sort, filter.GetName(), match)
+	}, func(items []publisher.PublisherDTO) error {
+		publishers, err := s.details(ctx, items)
+		if err != nil {
+			return err
+		}
+		for _, p := range publishers {
+			if err := srv.Send(p); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CreatePublisher creates a publisher
+func (s *publisherServer) CreatePublisher(ctx context.Context, req *catalogv1.PublisherInput) (*catalogv1.Publisher, error) {
+	created, err := s.publishers.CreatePublisher(ctx, publisherRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.publishers.GetPublisher(ctx, created.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toPublisher(result), nil
+}
+
+// UpdatePublisher replaces a publisher at the version of the request
+func (s *publisherServer) UpdatePublisher(ctx context.Context, req *catalogv1.UpdatePublisherRequest) (*catalogv1.Publisher, error) {
+	id, err := recordID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	match, err := precondition(req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.publishers.UpdatePublisher(ctx, id, publisherRequest(req.GetPublisher()), match)
+	if err != nil {
+		return nil, err
+	}
+	return toPublisher(result), nil
+}
+
+// DeletePublisher deletes a publisher at the version of the request, applying its policy to the
+// books of the publisher
+func (s *publisherServer) DeletePublisher(ctx context.Context, req *catalogv1.DeleteRequest) (*emptypb.Empty, error) {
+	id, err := recordID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	match, err := precondition(req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+	options, err := deletionOptions(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.publishers.DeletePublisher(ctx, id, match, options); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// details returns the messages of the publishers of a list page
+func (s *publisherServer) details(ctx context.Context, items []publisher.PublisherDTO) ([]*catalogv1.Publisher, error) {
+	publishers, err := details(items, func(pub publisher.PublisherDTO) string { return pub.ID }, func(ids []uint) ([]publisher.PublisherDetailResponse, error) {
+		return s.publishers.GetPublishersByIDs(ctx, ids)
+	}, func(pub *publisher.PublisherDetailResponse) uint { return pub.ID })
+	if err != nil {
+		return nil, err
+	}
+	messages := make([]*catalogv1.Publisher, len(publishers))
+	for i := range publishers {
+		messages[i] = toPublisher(&publishers[i])
+	}
+	return messages, nil
+}
+
+// publisherRequest reads a PublisherInput
+func publisherRequest(in *catalogv1.PublisherInput) publisher.PublisherRequest {
+	return publisher.PublisherRequest{Name: in.GetName(), Description: in.GetDescription()}
+}
+
+// toPublisher converts a publisher into its message
+func toPublisher(p *publisher.PublisherDetailResponse) *catalogv1.Publisher {
+	return &catalogv1.Publisher{Id: uint64(p.ID), Version: uint32(p.Version), Name: p.Name, Description: p.Description}
+}
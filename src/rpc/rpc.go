@@ -0,0 +1,44 @@
+package rpc
+
+import (
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/author"
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/category"
+	"github.com/tedysaputro/book-catalog-with-go/src/publisher"
+	"github.com/tedysaputro/book-catalog-with-go/src/rpc/catalogv1"
+)
+
+// Config configures the gRPC server
+type Config struct {
+	// Timeout is the deadline of unary calls that do not set a shorter one, 0 for none.
+	// Streams are only bounded by the deadline of the client.
+	Timeout time.Duration
+}
+
+// NewServer creates the gRPC server of the catalog on the services behind the REST API,
+// along with the health checking and reflection services
+func NewServer(config Config, books book.BookService, authors author.AuthorService, publishers publisher.PublisherService, categories category.CategoryService) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryInterceptor(config.Timeout)),
+		grpc.ChainStreamInterceptor(streamInterceptor()),
+	)
+	catalogv1.RegisterBookServiceServer(server, &bookServer{books: books})
+	catalogv1.RegisterAuthorServiceServer(server, &authorServer{authors: authors})
+	catalogv1.RegisterPublisherServiceServer(server, &publisherServer{publishers: publishers})
+	catalogv1.RegisterCategoryServiceServer(server, &categoryServer{categories: categories})
+
+	healthServer := health.NewServer()
+	for name := range server.GetServiceInfo() {
+		healthServer.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthpb.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+	return server
+}
@@ -0,0 +1,65 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+)
+
+// statusCodes are the status codes of the domain errors, by apperror.Kind
+var statusCodes = map[apperror.Kind]codes.Code{
+	apperror.KindBadRequest:           codes.InvalidArgument,
+	apperror.KindNotFound:             codes.NotFound,
+	apperror.KindValidation:           codes.InvalidArgument,
+	apperror.KindConflict:             codes.FailedPrecondition,
+	apperror.KindPreconditionFailed:   codes.Aborted,
+	apperror.KindPreconditionRequired: codes.FailedPrecondition,
+}
+
+// toStatus converts the error of a service running on ctx into a status. Failures caused by the
+// deadline or cancellation of ctx keep its code. The invalid fields of a domain error become
+// BadRequest details and the records it refers to ResourceInfo details. Errors that are not
+// domain errors are only logged.
+func toStatus(ctx context.Context, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if ctx.Err() != nil {
+		return status.FromContextError(ctx.Err()).Err()
+	}
+
+	var appErr *apperror.Error
+	if !errors.As(err, &appErr) {
+		log.Printf("grpc handler error: %v", err)
+		return status.Error(codes.Internal, "internal server error")
+	}
+
+	st := status.New(statusCodes[appErr.Kind], appErr.Message)
+	var details []protoadapt.MessageV1
+	if len(appErr.Fields) > 0 {
+		violations := make([]*errdetails.BadRequest_FieldViolation, len(appErr.Fields))
+		for i, field := range appErr.Fields {
+			violations[i] = &errdetails.BadRequest_FieldViolation{Field: field.Field, Description: field.Message}
+		}
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+	for _, reference := range appErr.References {
+		details = append(details, &errdetails.ResourceInfo{
+			ResourceType: reference.Type,
+			ResourceName: strconv.FormatUint(uint64(reference.ID), 10),
+			Description:  reference.Title,
+		})
+	}
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
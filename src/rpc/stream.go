@@ -0,0 +1,64 @@
+package rpc
+
+import (
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+)
+
+// stream reads a whole list page by page with fetch and hands each page to send. Lists in sort
+// order are read by cursor so changes made meanwhile do not shift items between pages; lists
+// ordered by relevance, which take no cursor, are read by page number.
+func stream[T any](sort sorting.Spec, relevance bool, fetch func(pagination.Request) (*pagination.Page[T], error), send func([]T) error) error {
+	request := pagination.Request{Page: 1, PageSize: pagination.MaxPageSize}
+	if !relevance {
+		request.Cursor = &pagination.Cursor{Sort: sort.String()}
+	}
+	for {
+		p, err := fetch(request)
+		if err != nil {
+			return err
+		}
+		if err := send(p.Data); err != nil {
+			return err
+		}
+
+		if relevance {
+			if uint64(request.Page) >= p.TotalPages {
+				return nil
+			}
+			request.Page++
+			continue
+		}
+		if p.NextCursor == "" {
+			return nil
+		}
+		if request.Cursor, err = pagination.DecodeCursor(p.NextCursor, sort); err != nil {
+			return err
+		}
+	}
+}
+
+// details returns the details of the records referred to by items, fetched together by ID in
+// the order of items
+func details[T any, D any](items []T, idOf func(T) string, fetch func(ids []uint) ([]D, error), detailID func(*D) uint) ([]D, error) {
+	ids := make([]uint, len(items))
+	for i, item := range items {
+		ids[i] = uint(parseID(idOf(item)))
+	}
+	found, err := fetch(ids)
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[uint]*D, len(found))
+	for i := range found {
+		index[detailID(&found[i])] = &found[i]
+	}
+
+	result := make([]D, 0, len(ids))
+	for _, id := range ids {
+		if detail, ok := index[id]; ok {
+			result = append(result, *detail)
+		}
+	}
+	return result, nil
+}
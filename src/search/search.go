@@ -0,0 +1,9 @@
+package search
+
+// Result is a book matching a search query
+type Result struct {
+	BookID    uint
+	Rank      float64
+	Highlight string
+	Snippet   string
+}
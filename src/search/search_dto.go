@@ -0,0 +1,17 @@
+package search
+
+import (
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+)
+
+// SearchResultResponse represents a single ranked search hit
+type SearchResultResponse struct {
+	Book      book.BookDetailResponse `json:"book"`
+	Rank      float64                 `json:"rank"`
+	Highlight string                  `json:"highlight"`
+	Snippet   string                  `json:"snippet"`
+}
+
+// SearchListResponse represents the response payload for a search
+type SearchListResponse = pagination.Page[SearchResultResponse]
@@ -0,0 +1,43 @@
+package search
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+)
+
+// SearchHandler handles HTTP requests for full-text search
+type SearchHandler struct {
+	service SearchService
+}
+
+// NewSearchHandler creates a new instance of SearchHandler
+func NewSearchHandler(service SearchService) *SearchHandler {
+	return &SearchHandler{service: service}
+}
+
+// Search handles GET /search request
+func (h *SearchHandler) Search(c *fiber.Ctx) error {
+	q := strings.TrimSpace(c.Query("q"))
+	if q == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Query parameter q is required")
+	}
+
+	page, err := pagination.FromQuery(c, nil)
+	if err != nil {
+		return err
+	}
+
+	results, err := h.service.Search(c.UserContext(), q, page)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(results.WithLinks(c))
+}
+
+// RegisterRoutes registers the search routes
+func (h *SearchHandler) RegisterRoutes(app *fiber.App) {
+	app.Get("/api/v1/search", h.Search)
+}
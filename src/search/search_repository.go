@@ -0,0 +1,57 @@
+package search
+
+import (
+	"context"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/transaction"
+	"gorm.io/gorm"
+)
+
+// SearchRepository defines the full-text queries over books
+type SearchRepository interface {
+	Search(ctx context.Context, q string, page pagination.Request) ([]Result, uint64, error)
+}
+
+type gormSearchRepository struct {
+	db *gorm.DB
+}
+
+// NewGormSearchRepository creates a SearchRepository backed by PostgreSQL full-text search
+func NewGormSearchRepository(db *gorm.DB) SearchRepository {
+	return &gormSearchRepository{db: db}
+}
+
+// Search runs a ranked full-text query over live books
+func (r *gormSearchRepository) Search(ctx context.Context, q string, page pagination.Request) ([]Result, uint64, error) {
+	var results []Result
+	var total int64
+
+	// Count total matches
+	err := transaction.DB(ctx, r.db).Raw(`
+		SELECT count(*)
+		FROM books
+		WHERE deleted_at IS NULL AND search_vector @@ websearch_to_tsquery('simple', ?)`, q).
+		Scan(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Get ranked matches with pagination
+	err = transaction.DB(ctx, r.db).Raw(`
+		SELECT
+			books.id AS book_id,
+			ts_rank_cd(books.search_vector, query) AS rank,
+			ts_headline('simple', books.title, query, 'HighlightAll=true') AS highlight,
+			ts_headline('simple', coalesce(books.description, ''), query, 'MaxFragments=2, MaxWords=20, MinWords=5') AS snippet
+		FROM books, websearch_to_tsquery('simple', ?) AS query
+		WHERE books.deleted_at IS NULL AND books.search_vector @@ query
+		ORDER BY rank DESC, books.id
+		OFFSET ? LIMIT ?`, q, page.Offset(), page.PageSize).
+		Scan(&results).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return results, uint64(total), nil
+}
@@ -0,0 +1,134 @@
+package search
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/memory"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+)
+
+// Weights of the title, author names, publisher name and description in the rank of a match,
+// those PostgreSQL gives the A, B, C and D weights of the search vector
+var weights = []float64{1.0, 0.4, 0.2, 0.1}
+
+type memorySearchRepository struct {
+	books book.BookRepository
+}
+
+// NewMemorySearchRepository creates a SearchRepository that searches the books kept by books word by word
+func NewMemorySearchRepository(books book.BookRepository) SearchRepository {
+	return &memorySearchRepository{books: books}
+}
+
+// Search ranks the live books holding every word of q and none of the words prefixed with a minus
+func (r *memorySearchRepository) Search(ctx context.Context, q string, page pagination.Request) ([]Result, uint64, error) {
+	include, exclude := parseQuery(q)
+	if len(include) == 0 {
+		return []Result{}, 0, nil
+	}
+
+	books, err := book.FindAllMatching(ctx, r.books, book.BookFilter{})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results := []Result{}
+	for _, b := range books {
+		authors := make([]string, len(b.Authors))
+		for i, a := range b.Authors {
+			authors[i] = a.Name
+		}
+		fields := [][]string{words(b.Title), words(strings.Join(authors, " ")), words(b.Publisher.Name), words(b.Description)}
+
+		var rank float64
+		matches := func(term string) bool {
+			found := false
+			for i, field := range fields {
+				for _, word := range field {
+					if word == term {
+						rank += weights[i]
+						found = true
+					}
+				}
+			}
+			return found
+		}
+		if slices.ContainsFunc(exclude, matches) || !allMatch(include, matches) {
+			continue
+		}
+		results = append(results, Result{
+			BookID:    b.ID,
+			Rank:      rank,
+			Highlight: highlight(b.Title, include),
+			Snippet:   highlight(b.Description, include),
+		})
+	}
+
+	// Books are read ordered by ID, so equal ranks keep that order
+	memory.Sort(results, nil, nil, func(result Result) float64 { return result.Rank })
+	return memory.Paginate(results, page, nil, nil), uint64(len(results)), nil
+}
+
+// parseQuery splits q into the words a match must hold and the ones it must not
+func parseQuery(q string) (include []string, exclude []string) {
+	for _, token := range strings.Fields(q) {
+		if negated, ok := strings.CutPrefix(token, "-"); ok {
+			exclude = append(exclude, words(negated)...)
+			continue
+		}
+		include = append(include, words(token)...)
+	}
+	return include, exclude
+}
+
+// allMatch reports whether matches holds for every term, checking each of them
+func allMatch(terms []string, matches func(term string) bool) bool {
+	all := true
+	for _, term := range terms {
+		if !matches(term) {
+			all = false
+		}
+	}
+	return all
+}
+
+// words returns the lower case words of text
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), isSeparator)
+}
+
+// isSeparator reports whether r separates words
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+}
+
+// highlight wraps the words of text that are terms in <b> tags, like ts_headline
+func highlight(text string, terms []string) string {
+	var out strings.Builder
+	start := -1
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		word := text[start:end]
+		if slices.Contains(terms, strings.ToLower(word)) {
+			word = "<b>" + word + "</b>"
+		}
+		out.WriteString(word)
+		start = -1
+	}
+	for i, r := range text {
+		if isSeparator(r) {
+			flush(i)
+			out.WriteRune(r)
+		} else if start < 0 {
+			start = i
+		}
+	}
+	flush(len(text))
+	return out.String()
+}
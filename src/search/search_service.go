@@ -0,0 +1,62 @@
+package search
+
+import (
+	"context"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+)
+
+// SearchService defines the interface for search operations
+type SearchService interface {
+	Search(ctx context.Context, q string, page pagination.Request) (*SearchListResponse, error)
+}
+
+type searchServiceImpl struct {
+	repo        SearchRepository
+	bookService book.BookService
+}
+
+// NewSearchService creates a new instance of SearchService
+func NewSearchService(repo SearchRepository, bookService book.BookService) SearchService {
+	return &searchServiceImpl{repo: repo, bookService: bookService}
+}
+
+// Search retrieves the books matching q ordered by relevance
+func (s *searchServiceImpl) Search(ctx context.Context, q string, page pagination.Request) (*SearchListResponse, error) {
+	results, total, err := s.repo.Search(ctx, q, page)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, len(results))
+	for i, result := range results {
+		ids[i] = result.BookID
+	}
+	books, err := s.bookService.GetBooksByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	booksByID := make(map[uint]book.BookDetailResponse, len(books))
+	for _, b := range books {
+		booksByID[b.ID] = b
+	}
+
+	dtos := make([]SearchResultResponse, 0, len(results))
+	for _, result := range results {
+		// Skip books deleted between the search and the lookup
+		b, ok := booksByID[result.BookID]
+		if !ok {
+			continue
+		}
+		dtos = append(dtos, SearchResultResponse{
+			Book:      b,
+			Rank:      result.Rank,
+			Highlight: result.Highlight,
+			Snippet:   result.Snippet,
+		})
+	}
+
+	return pagination.New(dtos, page, total), nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"github.com/tedysaputro/book-catalog-with-go/src/author"
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/category"
+	"github.com/tedysaputro/book-catalog-with-go/src/history"
+	"github.com/tedysaputro/book-catalog-with-go/src/publisher"
+	"gorm.io/gorm"
+)
+
+// Services are the catalog services shared by the HTTP and gRPC servers
+type Services struct {
+	Books      book.BookService
+	Authors    author.AuthorService
+	Publishers publisher.PublisherService
+	Categories category.CategoryService
+	History    history.HistoryService
+}
+
+// NewServices creates the catalog services on db
+func NewServices(db *gorm.DB) Services {
+	authorRepository := author.NewGormAuthorRepository(db)
+	publisherRepository := publisher.NewGormPublisherRepository(db)
+	categoryRepository := category.NewGormCategoryRepository(db)
+	bookRepository := book.NewGormBookRepository(db)
+
+	historyService := history.NewHistoryService(history.NewGormHistoryRepository(db))
+	return Services{
+		Books:      book.NewBookService(bookRepository, authorRepository, publisherRepository, categoryRepository, historyService),
+		Authors:    author.NewAuthorService(authorRepository, book.NewAuthorDependents(bookRepository, historyService), historyService),
+		Publishers: publisher.NewPublisherService(publisherRepository, book.NewPublisherDependents(bookRepository, historyService), historyService),
+		Categories: category.NewCategoryService(categoryRepository, book.NewCategoryLinks(bookRepository, historyService), historyService),
+		History:    historyService,
+	}
+}
@@ -0,0 +1,229 @@
+package sorting
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Type is the kind of values a sort column holds, which the keys of cursors must match
+type Type int
+
+const (
+	// Text columns hold strings
+	Text Type = iota
+	// Integer columns hold whole numbers
+	Integer
+	// Time columns hold timestamps, written as RFC 3339 strings in cursors
+	Time
+)
+
+// Column describes how a public sort field maps to SQL
+type Column struct {
+	// Name is the qualified column, e.g. "books.title"
+	Name string
+	// Join is the join clause required to reach Name, if any
+	Join string
+	// Type is the kind of values of the column
+	Type Type
+}
+
+// Fields is the whitelist of sortable fields of an entity, keyed by public name
+type Fields map[string]Column
+
+// Names returns the public field names in alphabetical order
+func (f Fields) Names() []string {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Field is a single whitelisted field of a sort specification
+type Field struct {
+	Name   string
+	Column Column
+	Desc   bool
+}
+
+// Spec is an ordered list of sort fields, e.g. parsed from "-year,title"
+type Spec []Field
+
+// Error describes a sort parameter that could not be accepted
+type Error struct {
+	Message string   `json:"error"`
+	Field   string   `json:"field"`
+	Allowed []string `json:"allowed,omitempty"`
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %q", e.Message, e.Field)
+}
+
+// AllowedValues returns the sort fields that would have been accepted
+func (e *Error) AllowedValues() []string {
+	return e.Allowed
+}
+
+// Parse parses a comma separated sort expression such as "-year,title".
+// A leading "-" sorts the field descending, a leading "+" or none ascending.
+// Every field must be present in fields.
+func Parse(raw string, fields Fields) (Spec, error) {
+	var spec Spec
+	seen := make(map[string]bool)
+
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		desc := false
+		switch {
+		case strings.HasPrefix(item, "-"):
+			desc = true
+			item = item[1:]
+		case strings.HasPrefix(item, "+"):
+			item = item[1:]
+		}
+
+		if item == "" {
+			return nil, &Error{Message: "empty sort field", Field: raw, Allowed: fields.Names()}
+		}
+		column, ok := fields[item]
+		if !ok {
+			return nil, &Error{Message: "unknown sort field", Field: item, Allowed: fields.Names()}
+		}
+		if seen[item] {
+			return nil, &Error{Message: "duplicate sort field", Field: item}
+		}
+		seen[item] = true
+
+		spec = append(spec, Field{Name: item, Column: column, Desc: desc})
+	}
+
+	return spec, nil
+}
+
+// FromQuery builds a Spec from the "sort" query parameter, falling back to the
+// deprecated "sortBy" and "direction" pair when "sort" is empty
+func FromQuery(sortParam string, sortBy string, direction string, fields Fields) (Spec, error) {
+	if sortParam != "" {
+		return Parse(sortParam, fields)
+	}
+
+	if sortBy == "" {
+		sortBy = "id"
+	}
+	switch strings.ToLower(direction) {
+	case "", "asc":
+		return Parse(sortBy, fields)
+	case "desc":
+		return Parse("-"+sortBy, fields)
+	default:
+		return nil, &Error{Message: "invalid sort direction", Field: direction, Allowed: []string{"asc", "desc"}}
+	}
+}
+
+// String formats the spec back into its "-year,title" form
+func (s Spec) String() string {
+	items := make([]string, len(s))
+	for i, f := range s {
+		items[i] = f.Name
+		if f.Desc {
+			items[i] = "-" + f.Name
+		}
+	}
+	return strings.Join(items, ",")
+}
+
+// Apply adds the joins and the ORDER BY clause of the spec to query.
+// Expressions in first, such as a relevance score, are ordered by before the fields of the spec.
+func (s Spec) Apply(query *gorm.DB, first ...clause.Expr) *gorm.DB {
+	var items []string
+	var vars []interface{}
+	for _, expr := range first {
+		items = append(items, expr.SQL)
+		vars = append(vars, expr.Vars...)
+	}
+	if order := s.OrderBy(); order != "" {
+		items = append(items, order)
+	}
+	query = s.Joins(query)
+	if len(items) == 0 {
+		return query
+	}
+	return query.Order(clause.OrderBy{
+		Expression: clause.Expr{SQL: strings.Join(items, ", "), Vars: vars},
+	})
+}
+
+// Joins adds the joins the columns of the spec need to query, once each
+func (s Spec) Joins(query *gorm.DB) *gorm.DB {
+	joined := make(map[string]bool)
+	for _, f := range s {
+		if f.Column.Join != "" && !joined[f.Column.Join] {
+			joined[f.Column.Join] = true
+			query = query.Joins(f.Column.Join)
+		}
+	}
+	return query
+}
+
+// OrderBy returns the columns of the spec as the list of an ORDER BY clause, e.g. "books.year DESC, books.id"
+func (s Spec) OrderBy() string {
+	items := make([]string, len(s))
+	for i, f := range s {
+		items[i] = f.Column.Name
+		if f.Desc {
+			items[i] += " DESC"
+		}
+	}
+	return strings.Join(items, ", ")
+}
+
+// Stable appends the id field of fields to the spec unless it already sorts by id,
+// so that rows with equal sort keys keep a fixed order
+func (s Spec) Stable(fields Fields) Spec {
+	for _, f := range s {
+		if f.Name == "id" {
+			return s
+		}
+	}
+	stable := append(Spec{}, s...)
+	return append(stable, Field{Name: "id", Column: fields["id"]})
+}
+
+// Reverse returns the spec with the direction of every field flipped
+func (s Spec) Reverse() Spec {
+	reversed := make(Spec, len(s))
+	for i, f := range s {
+		f.Desc = !f.Desc
+		reversed[i] = f
+	}
+	return reversed
+}
+
+// After returns the condition matching the rows that come after key in the order of the spec.
+// key holds one value per field of the spec.
+func (s Spec) After(key []interface{}) clause.Expr {
+	var terms []string
+	var vars []interface{}
+	for i, f := range s {
+		var conditions []string
+		for j, previous := range s[:i] {
+			conditions = append(conditions, previous.Column.Name+" = ?")
+			vars = append(vars, key[j])
+		}
+		operator := " > ?"
+		if f.Desc {
+			operator = " < ?"
+		}
+		conditions = append(conditions, f.Column.Name+operator)
+		vars = append(vars, key[i])
+		terms = append(terms, "("+strings.Join(conditions, " AND ")+")")
+	}
+	return clause.Expr{SQL: "(" + strings.Join(terms, " OR ") + ")", Vars: vars}
+}
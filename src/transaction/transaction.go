@@ -0,0 +1,30 @@
+package transaction
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// txKey keys the database transaction carried by a context
+type txKey struct{}
+
+// Run runs fn in a transaction on db whose writes are kept only if fn returns nil.
+// The context passed to fn carries the transaction, so every repository reading through DB
+// joins it; when ctx already carries one, fn simply joins it as well.
+func Run(ctx context.Context, db *gorm.DB, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// DB returns the transaction carried by ctx, or db outside of a transaction, bound to ctx
+func DB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
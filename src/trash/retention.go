@@ -0,0 +1,56 @@
+package trash
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Purger permanently deletes the records that were soft deleted before a point in time
+type Purger interface {
+	PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Retention purges records once they have been in the trash for longer than Period
+type Retention struct {
+	Period time.Duration
+	// Interval is the time between two purges
+	Interval time.Duration
+	// Purgers are run in order, so records are purged before the records they reference
+	Purgers []Purger
+}
+
+// Purge permanently deletes the records deleted before now minus Period and returns how many were purged
+func (r Retention) Purge(ctx context.Context, now time.Time) (int64, error) {
+	before := now.Add(-r.Period)
+	var purged int64
+	for _, purger := range r.Purgers {
+		n, err := purger.PurgeDeletedBefore(ctx, before)
+		purged += n
+		if err != nil {
+			return purged, err
+		}
+	}
+	return purged, nil
+}
+
+// Run purges expired records right away and then every Interval until ctx is done
+func (r Retention) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := r.Purge(ctx, time.Now())
+		if err != nil {
+			log.Printf("trash retention: %v", err)
+		} else if purged > 0 {
+			log.Printf("trash retention: purged %d records deleted more than %s ago", purged, r.Period)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
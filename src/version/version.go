@@ -0,0 +1,118 @@
+package version
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrStale is returned when a record changed since the version a request was based on
+	ErrStale = apperror.PreconditionFailed("the resource was modified, fetch it again and retry")
+	// ErrRequired is returned when a request that modifies a record has no If-Match header
+	ErrRequired = apperror.PreconditionRequired("the If-Match header is required, use the ETag of the resource")
+)
+
+// ETag returns the strong entity tag of a record version
+func ETag(version uint) string {
+	return `"` + strconv.FormatUint(uint64(version), 10) + `"`
+}
+
+// Precondition is the set of record versions a request may modify, read from its If-Match header
+type Precondition struct {
+	// Any is set by "If-Match: *", which accepts every version of an existing record
+	Any      bool
+	Versions []uint
+}
+
+// IfMatch reads the If-Match header of c. Weak and unknown entity tags are kept out of the
+// precondition since they never match a version under the strong comparison If-Match uses.
+func IfMatch(c *fiber.Ctx) (Precondition, error) {
+	header := strings.TrimSpace(c.Get(fiber.HeaderIfMatch))
+	if header == "" {
+		return Precondition{}, ErrRequired
+	}
+
+	var match Precondition
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			match.Any = true
+			continue
+		}
+		if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			continue
+		}
+		if version, err := strconv.ParseUint(tag[1:len(tag)-1], 10, 32); err == nil {
+			match.Versions = append(match.Versions, uint(version))
+		}
+	}
+	return match, nil
+}
+
+// Check returns ErrStale unless the precondition accepts current
+func (p Precondition) Check(current uint) error {
+	if p.Any {
+		return nil
+	}
+	for _, version := range p.Versions {
+		if version == current {
+			return nil
+		}
+	}
+	return ErrStale
+}
+
+// Send writes body as JSON with the ETag of version, or only 304 Not Modified
+// when the If-None-Match header of c shows the client already has that version
+func Send(c *fiber.Ctx, version uint, body interface{}) error {
+	c.Set(fiber.HeaderETag, ETag(version))
+	if c.Fresh() {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+	return c.JSON(body)
+}
+
+// Save updates all columns of record, whose version field is current, unless the stored
+// record moved past that version in the meantime. The version is incremented on success.
+func Save(query *gorm.DB, record interface{}, current *uint) error {
+	read := *current
+	*current = read + 1
+	result := query.Model(record).Where("version = ?", read).Select("*").Updates(record)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrStale
+	}
+	if result.Error != nil {
+		*current = read
+	}
+	return result.Error
+}
+
+// Restore clears the deletion time of the soft deleted record, whose version field is current,
+// unless the stored record moved past that version in the meantime. The version is incremented on success.
+func Restore(query *gorm.DB, record interface{}, current *uint) error {
+	read := *current
+	result := query.Unscoped().Model(record).Where("version = ?", read).
+		Updates(map[string]interface{}{"deleted_at": nil, "version": read + 1})
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrStale
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	*current = read + 1
+	return nil
+}
+
+// SoftDelete soft deletes record, whose version field is current,
+// unless the stored record moved past that version in the meantime
+func SoftDelete(query *gorm.DB, record interface{}, current uint) error {
+	result := query.Where("version = ?", current).Delete(record)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return ErrStale
+	}
+	return result.Error
+}
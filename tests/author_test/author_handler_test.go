@@ -8,55 +8,33 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
-	"os"
 	"testing"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
-	"gorm.io/driver/postgres"
-	"gorm.io/gorm"
 
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
 	"github.com/tedysaputro/book-catalog-with-go/src/author"
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/category"
+	"github.com/tedysaputro/book-catalog-with-go/src/history"
+	"github.com/tedysaputro/book-catalog-with-go/src/publisher"
 )
 
-func setupTestDB() *gorm.DB {
-	dsn := os.Getenv("DATABASE_URL")
-	if dsn == "" {
-		dsn = "host=localhost user=postgres password=postgres dbname=book_catalog_test port=5432 sslmode=disable"
-	}
-
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
-	if err != nil {
-		panic("failed to connect database: " + err.Error())
-	}
-
-	// Migrate the schema
-	err = db.AutoMigrate(&author.Author{})
-	if err != nil {
-		panic("failed to migrate database: " + err.Error())
-	}
-
-	return db
-}
-
-func cleanupTestDB(db *gorm.DB) {
-	db.Exec("DELETE FROM authors")
-}
-
-func setupTestApp() (*fiber.App, *gorm.DB) {
-	db := setupTestDB()
-	author.SetDB(db)
-
-	app := fiber.New()
-	authorService := author.NewAuthorService()
+func setupTestApp() *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	authors := author.NewMemoryAuthorRepository()
+	books := book.NewMemoryBookRepository(authors, publisher.NewMemoryPublisherRepository(), category.NewMemoryCategoryRepository())
+	changes := history.NewHistoryService(history.NewMemoryHistoryRepository())
+	authorService := author.NewAuthorService(authors, book.NewAuthorDependents(books, changes), changes)
 	authorHandler := author.NewAuthorHandler(authorService)
 	authorHandler.RegisterRoutes(app)
-	return app, db
+	return app
 }
 
 func TestCreateAuthor(t *testing.T) {
-	app, db := setupTestApp()
-	defer cleanupTestDB(db)
+	t.Parallel()
+	app := setupTestApp()
 
 	tests := []struct {
 		name           string
@@ -79,7 +57,7 @@ func TestCreateAuthor(t *testing.T) {
 				Name:        "",
 				Description: "Some description",
 			},
-			expectedStatus: fiber.StatusBadRequest,
+			expectedStatus: fiber.StatusUnprocessableEntity,
 			expectedError:  true,
 		},
 	}
@@ -112,18 +90,19 @@ func TestCreateAuthor(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.payload.Name, detailResponse.Name)
 			} else {
-				var errorResponse map[string]string
-				err = json.Unmarshal(body, &errorResponse)
+				var problem apperror.Problem
+				err = json.Unmarshal(body, &problem)
 				assert.NoError(t, err)
-				assert.NotNil(t, errorResponse["error"])
+				assert.Equal(t, tt.expectedStatus, problem.Status)
+				assert.NotEmpty(t, problem.Detail)
 			}
 		})
 	}
 }
 
 func TestGetAuthor(t *testing.T) {
-	app, db := setupTestApp()
-	defer cleanupTestDB(db)
+	t.Parallel()
+	app := setupTestApp()
 
 	// First create an author
 	createPayload := author.AuthorRequest{
@@ -174,19 +153,20 @@ func TestGetAuthor(t *testing.T) {
 				assert.Equal(t, tt.authorID, response.ID)
 				assert.Equal(t, createPayload.Name, response.Name)
 			} else {
-				var errorResponse map[string]string
+				var problem apperror.Problem
 				body, _ := io.ReadAll(resp.Body)
-				err = json.Unmarshal(body, &errorResponse)
+				err = json.Unmarshal(body, &problem)
 				assert.NoError(t, err)
-				assert.NotNil(t, errorResponse["error"])
+				assert.Equal(t, apperror.ProblemContentType, resp.Header.Get(fiber.HeaderContentType))
+				assert.Equal(t, tt.expectedStatus, problem.Status)
 			}
 		})
 	}
 }
 
 func TestUpdateAuthor(t *testing.T) {
-	app, db := setupTestApp()
-	defer cleanupTestDB(db)
+	t.Parallel()
+	app := setupTestApp()
 
 	// First create an author
 	createPayload := author.AuthorRequest{
@@ -205,13 +185,25 @@ func TestUpdateAuthor(t *testing.T) {
 	tests := []struct {
 		name           string
 		authorID       uint
+		ifMatch        string
 		payload        author.AuthorRequest
 		expectedStatus int
 		expectedError  bool
 	}{
+		{
+			name:     "Missing If-Match",
+			authorID: createResp.ID,
+			payload: author.AuthorRequest{
+				Name:        "Updated Name",
+				Description: "Updated Description",
+			},
+			expectedStatus: fiber.StatusPreconditionRequired,
+			expectedError:  true,
+		},
 		{
 			name:     "Valid Author Update",
 			authorID: createResp.ID,
+			ifMatch:  `"1"`,
 			payload: author.AuthorRequest{
 				Name:        "Updated Name",
 				Description: "Updated Description",
@@ -219,9 +211,21 @@ func TestUpdateAuthor(t *testing.T) {
 			expectedStatus: fiber.StatusOK,
 			expectedError:  false,
 		},
+		{
+			name:     "Stale Version",
+			authorID: createResp.ID,
+			ifMatch:  `"1"`,
+			payload: author.AuthorRequest{
+				Name:        "Lost Update",
+				Description: "Lost Description",
+			},
+			expectedStatus: fiber.StatusPreconditionFailed,
+			expectedError:  true,
+		},
 		{
 			name:     "Update Non-existent Author",
 			authorID: 9999,
+			ifMatch:  "*",
 			payload: author.AuthorRequest{
 				Name:        "Test Name",
 				Description: "Test Description",
@@ -232,11 +236,12 @@ func TestUpdateAuthor(t *testing.T) {
 		{
 			name:     "Invalid Update - Empty Name",
 			authorID: createResp.ID,
+			ifMatch:  `"2"`,
 			payload: author.AuthorRequest{
 				Name:        "",
 				Description: "Test Description",
 			},
-			expectedStatus: fiber.StatusBadRequest,
+			expectedStatus: fiber.StatusUnprocessableEntity,
 			expectedError:  true,
 		},
 	}
@@ -246,6 +251,9 @@ func TestUpdateAuthor(t *testing.T) {
 			payload, _ := json.Marshal(tt.payload)
 			req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/authors/%d", tt.authorID), bytes.NewReader(payload))
 			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
 
 			resp, err := app.Test(req)
 			assert.NoError(t, err)
@@ -260,19 +268,57 @@ func TestUpdateAuthor(t *testing.T) {
 				assert.Equal(t, tt.payload.Name, response.Name)
 				assert.Equal(t, tt.payload.Description, response.Description)
 			} else {
-				var errorResponse map[string]string
+				var problem apperror.Problem
 				body, _ := io.ReadAll(resp.Body)
-				err = json.Unmarshal(body, &errorResponse)
+				err = json.Unmarshal(body, &problem)
 				assert.NoError(t, err)
-				assert.NotNil(t, errorResponse["error"])
+				assert.Equal(t, apperror.ProblemContentType, resp.Header.Get(fiber.HeaderContentType))
+				assert.Equal(t, tt.expectedStatus, problem.Status)
 			}
 		})
 	}
 }
 
+func TestGetAuthorETag(t *testing.T) {
+	t.Parallel()
+	app := setupTestApp()
+
+	payload, _ := json.Marshal(author.AuthorRequest{Name: "Pramoedya Ananta Toer"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/authors", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	_, err := app.Test(req)
+	assert.NoError(t, err)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/authors/1", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, `"1"`, resp.Header.Get("ETag"))
+
+	tests := []struct {
+		name           string
+		ifNoneMatch    string
+		expectedStatus int
+	}{
+		{name: "Current Version", ifNoneMatch: `"1"`, expectedStatus: fiber.StatusNotModified},
+		{name: "Weak Current Version", ifNoneMatch: `W/"1"`, expectedStatus: fiber.StatusNotModified},
+		{name: "Outdated Version", ifNoneMatch: `"0"`, expectedStatus: fiber.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/authors/1", nil)
+			req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			assert.Equal(t, `"1"`, resp.Header.Get("ETag"))
+		})
+	}
+}
+
 func TestListAuthors(t *testing.T) {
-	app, db := setupTestApp()
-	defer cleanupTestDB(db)
+	t.Parallel()
+	app := setupTestApp()
 
 	// Create multiple authors
 	authors := []author.AuthorRequest{
@@ -312,6 +358,15 @@ func TestListAuthors(t *testing.T) {
 			expectedStatus: fiber.StatusOK,
 			expectedCount:  2,
 		},
+		{
+			name: "List Second Page",
+			queryParams: map[string]string{
+				"page":      "2",
+				"page_size": "2",
+			},
+			expectedStatus: fiber.StatusOK,
+			expectedCount:  1,
+		},
 		{
 			name: "Search Author by Name",
 			queryParams: map[string]string{
@@ -343,7 +398,7 @@ func TestListAuthors(t *testing.T) {
 			var response author.AuthorListResponse
 			err = json.Unmarshal(body, &response)
 			assert.NoError(t, err)
-			assert.Len(t, response.Result, tt.expectedCount)
+			assert.Len(t, response.Data, tt.expectedCount)
 		})
 	}
 }
@@ -0,0 +1,800 @@
+package book_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/author"
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/category"
+	"github.com/tedysaputro/book-catalog-with-go/src/history"
+	"github.com/tedysaputro/book-catalog-with-go/src/middleware"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/publisher"
+)
+
+const adminToken = "s3cret"
+
+func setupTestApp(t *testing.T) *fiber.App {
+	authors := author.NewMemoryAuthorRepository()
+	publishers := publisher.NewMemoryPublisherRepository()
+	categories := category.NewMemoryCategoryRepository()
+	books := book.NewMemoryBookRepository(authors, publishers, categories)
+
+	// Seed the relations books point at
+	ctx := context.Background()
+	assert.NoError(t, publishers.Create(ctx, &publisher.Publisher{Name: "Gramedia"}))
+	assert.NoError(t, publishers.Create(ctx, &publisher.Publisher{Name: "Bentang Pustaka"}))
+	assert.NoError(t, authors.Create(ctx, &author.Author{Name: "Andrea Hirata"}))
+	assert.NoError(t, authors.Create(ctx, &author.Author{Name: "Dee Lestari"}))
+	assert.NoError(t, categories.Create(ctx, &category.Category{Code: "FIC", Name: "Fiction"}))
+	assert.NoError(t, categories.Create(ctx, &category.Category{Code: "SCI", Name: "Science"}))
+
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Use(middleware.Actor())
+	changes := history.NewHistoryService(history.NewMemoryHistoryRepository())
+	bookService := book.NewBookService(books, authors, publishers, categories, changes)
+	bookHandler := book.NewBookHandler(bookService)
+	bookHandler.RegisterRoutes(app)
+	bookHandler.RegisterAdminRoutes(app, middleware.Admin(adminToken))
+	author.NewAuthorHandler(author.NewAuthorService(authors, book.NewAuthorDependents(books, changes), changes)).RegisterRoutes(app)
+	publisher.NewPublisherHandler(publisher.NewPublisherService(publishers, book.NewPublisherDependents(books, changes), changes)).RegisterRoutes(app)
+	category.NewCategoryHandler(category.NewCategoryService(categories, book.NewCategoryLinks(books, changes), changes)).RegisterRoutes(app)
+	history.NewHistoryHandler(changes).RegisterRoutes(app)
+	return app
+}
+
+func createBook(t *testing.T, app *fiber.App, request book.BookRequest) *http.Response {
+	payload, _ := json.Marshal(request)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/books", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	return resp
+}
+
+func TestCreateBook(t *testing.T) {
+	t.Parallel()
+	app := setupTestApp(t)
+
+	tests := []struct {
+		name           string
+		payload        book.BookRequest
+		expectedStatus int
+		expectedField  string
+	}{
+		{
+			name: "Valid Book Creation",
+			payload: book.BookRequest{
+				Title: "Laskar Pelangi", ISBN13: "978-979-96257-0-0", Pages: 529, Year: 2005,
+				PublisherID: 1, AuthorIDs: []uint{1}, CategoryIDs: []uint{1},
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name: "Duplicate ISBN",
+			payload: book.BookRequest{
+				Title: "Laskar Pelangi", ISBN10: "979962570X", Pages: 529, Year: 2005, PublisherID: 1,
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name:           "Invalid ISBN Checksum",
+			payload:        book.BookRequest{Title: "Sang Pemimpi", ISBN13: "9789799625701", Pages: 292, Year: 2006, PublisherID: 1},
+			expectedStatus: fiber.StatusUnprocessableEntity,
+			expectedField:  "isbn13",
+		},
+		{
+			name:           "Unknown Publisher",
+			payload:        book.BookRequest{Title: "Sang Pemimpi", Pages: 292, Year: 2006, PublisherID: 99},
+			expectedStatus: fiber.StatusUnprocessableEntity,
+			expectedField:  "publisher_id",
+		},
+		{
+			name:           "Unknown Author",
+			payload:        book.BookRequest{Title: "Edensor", Pages: 288, Year: 2007, PublisherID: 1, AuthorIDs: []uint{1, 99}},
+			expectedStatus: fiber.StatusUnprocessableEntity,
+			expectedField:  "author_ids",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := createBook(t, app, tt.payload)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.expectedField != "" {
+				body, _ := io.ReadAll(resp.Body)
+				var problem apperror.Problem
+				assert.NoError(t, json.Unmarshal(body, &problem))
+				if assert.Len(t, problem.Errors, 1) {
+					assert.Equal(t, tt.expectedField, problem.Errors[0].Field)
+				}
+			}
+		})
+	}
+
+	// The created book comes back with its relations
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/books/isbn/979962570X", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var response book.BookDetailResponse
+	assert.NoError(t, json.Unmarshal(body, &response))
+	assert.Equal(t, "Laskar Pelangi", response.Title)
+	assert.Equal(t, "Gramedia", response.Publisher.Name)
+	assert.Len(t, response.Authors, 1)
+	assert.Len(t, response.Categories, 1)
+}
+
+func TestLinkBookCategories(t *testing.T) {
+	t.Parallel()
+	app := setupTestApp(t)
+
+	resp := createBook(t, app, book.BookRequest{Title: "Laskar Pelangi", Pages: 529, Year: 2005, PublisherID: 1, CategoryIDs: []uint{2, 1}})
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+	// The created book is linked to both categories
+	codes := func() []string {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/books/1", nil))
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		var detail book.BookDetailResponse
+		assert.NoError(t, json.Unmarshal(body, &detail))
+		codes := []string{}
+		for _, c := range detail.Categories {
+			codes = append(codes, c.Code)
+		}
+		return codes
+	}
+	assert.Equal(t, []string{"FIC", "SCI"}, codes())
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedCodes  []string
+	}{
+		{name: "Replace Categories", body: `{"title": "Laskar Pelangi", "pages": 529, "year": 2005, "publisher_id": 1, "category_ids": [2]}`, expectedStatus: fiber.StatusOK, expectedCodes: []string{"SCI"}},
+		{name: "Unknown Category", body: `{"title": "Laskar Pelangi", "pages": 529, "year": 2005, "publisher_id": 1, "category_ids": [1, 99]}`, expectedStatus: fiber.StatusUnprocessableEntity, expectedCodes: []string{"SCI"}},
+		{name: "Unlink Categories", body: `{"title": "Laskar Pelangi", "pages": 529, "year": 2005, "publisher_id": 1}`, expectedStatus: fiber.StatusOK, expectedCodes: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/books/1", bytes.NewReader([]byte(tt.body)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("If-Match", "*")
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			if tt.expectedStatus != fiber.StatusOK {
+				body, _ := io.ReadAll(resp.Body)
+				var problem apperror.Problem
+				assert.NoError(t, json.Unmarshal(body, &problem))
+				if assert.Len(t, problem.Errors, 1) {
+					assert.Equal(t, "category_ids", problem.Errors[0].Field)
+				}
+			}
+			assert.Equal(t, tt.expectedCodes, codes())
+		})
+	}
+}
+
+func TestListBooksByCategory(t *testing.T) {
+	t.Parallel()
+	app := setupTestApp(t)
+
+	createBook(t, app, book.BookRequest{Title: "Laskar Pelangi", Pages: 529, Year: 2005, PublisherID: 1, CategoryIDs: []uint{1}})
+	createBook(t, app, book.BookRequest{Title: "Cosmos", Pages: 365, Year: 1980, PublisherID: 1, CategoryIDs: []uint{2}})
+	createBook(t, app, book.BookRequest{Title: "Sang Pemimpi", Pages: 292, Year: 2006, PublisherID: 1, CategoryIDs: []uint{1, 2}})
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedTitles []string
+	}{
+		{name: "Filter by Category Code", path: "/api/v1/books?category=SCI&sort=title", expectedTitles: []string{"Cosmos", "Sang Pemimpi"}},
+		{name: "Filter by Several Category Codes", path: "/api/v1/books?category=FIC,SCI&sort=title", expectedTitles: []string{"Cosmos", "Laskar Pelangi", "Sang Pemimpi"}},
+		{name: "Filter by Unknown Category Code", path: "/api/v1/books?category=XYZ", expectedTitles: []string{}},
+		{name: "Books of a Category", path: "/api/v1/categories/1/books?sort=-year", expectedTitles: []string{"Sang Pemimpi", "Laskar Pelangi"}},
+		{name: "Fuzzy Title", path: "/api/v1/books?title=laskar%20plangi&fuzzy=true", expectedTitles: []string{"Laskar Pelangi"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+			body, _ := io.ReadAll(resp.Body)
+			var response book.BookListResponse
+			assert.NoError(t, json.Unmarshal(body, &response))
+
+			titles := make([]string, len(response.Data))
+			for i, b := range response.Data {
+				titles[i] = b.Title
+			}
+			assert.Equal(t, tt.expectedTitles, titles)
+		})
+	}
+}
+
+func TestListBooksUnknownSortField(t *testing.T) {
+	t.Parallel()
+	app := setupTestApp(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/books?sort=-price", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var problem apperror.Problem
+	assert.NoError(t, json.Unmarshal(body, &problem))
+	if assert.Len(t, problem.Errors, 1) {
+		assert.Equal(t, "sort", problem.Errors[0].Field)
+		assert.Contains(t, problem.Errors[0].Allowed, "publisher.name")
+	}
+}
+
+func TestListBooksInvalidThreshold(t *testing.T) {
+	t.Parallel()
+	app := setupTestApp(t)
+
+	for _, threshold := range []string{"0", "1.5", "NaN"} {
+		t.Run(threshold, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/books?title=laskar&fuzzy=true&threshold="+threshold, nil)
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+			body, _ := io.ReadAll(resp.Body)
+			var problem apperror.Problem
+			assert.NoError(t, json.Unmarshal(body, &problem))
+			if assert.Len(t, problem.Errors, 1) {
+				assert.Equal(t, "threshold", problem.Errors[0].Field)
+			}
+		})
+	}
+}
+
+func TestListBooksWithCursor(t *testing.T) {
+	t.Parallel()
+	app := setupTestApp(t)
+
+	for _, b := range []struct {
+		title string
+		year  uint
+	}{{"Laskar Pelangi", 2005}, {"Edensor", 2007}, {"Sang Pemimpi", 2006}, {"Maryamah Karpov", 2008}, {"Padang Bulan", 2010}, {"Cinta di Dalam Gelas", 2010}} {
+		createBook(t, app, book.BookRequest{Title: b.title, Pages: 300, Year: b.year, PublisherID: 1})
+	}
+
+	list := func(path string) book.BookListResponse {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		body, _ := io.ReadAll(resp.Body)
+		var response book.BookListResponse
+		assert.NoError(t, json.Unmarshal(body, &response))
+		return response
+	}
+	titles := func(response book.BookListResponse) []string {
+		titles := make([]string, len(response.Data))
+		for i, b := range response.Data {
+			titles[i] = b.Title
+		}
+		return titles
+	}
+
+	first := list("/api/v1/books?sort=-year&page_size=2")
+	assert.Equal(t, []string{"Padang Bulan", "Cinta di Dalam Gelas"}, titles(first))
+	assert.Empty(t, first.PrevCursor)
+
+	// A book inserted before the cursor neither shifts nor repeats the following pages
+	createBook(t, app, book.BookRequest{Title: "Ayah", Pages: 300, Year: 2015, PublisherID: 1})
+
+	second := list("/api/v1/books?sort=-year&page_size=2&cursor=" + first.NextCursor)
+	assert.Equal(t, []string{"Maryamah Karpov", "Edensor"}, titles(second))
+	assert.Zero(t, second.Page)
+	assert.Equal(t, uint64(7), second.Total)
+	assert.Contains(t, second.Links.Next, "cursor="+second.NextCursor)
+
+	third := list("/api/v1/books?sort=-year&page_size=2&cursor=" + second.NextCursor)
+	assert.Equal(t, []string{"Sang Pemimpi", "Laskar Pelangi"}, titles(third))
+	assert.Empty(t, third.NextCursor)
+
+	back := list("/api/v1/books?sort=-year&page_size=2&cursor=" + third.PrevCursor)
+	assert.Equal(t, []string{"Maryamah Karpov", "Edensor"}, titles(back))
+	assert.NotEmpty(t, back.PrevCursor)
+	assert.NotEmpty(t, back.NextCursor)
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "Other Sort Order", path: "/api/v1/books?sort=title&cursor=" + first.NextCursor},
+		{name: "Combined With Page", path: "/api/v1/books?sort=-year&page=2&cursor=" + first.NextCursor},
+		{name: "Malformed Cursor", path: "/api/v1/books?sort=-year&cursor=not-a-cursor"},
+		{name: "Text Key Of A Number Field", path: "/api/v1/books?sort=-year&cursor=" + pagination.Cursor{Sort: "-year,id", Key: []interface{}{"2005", 3}}.Encode()},
+		{name: "Number Key Of A Time Field", path: "/api/v1/books?sort=created_at&cursor=" + pagination.Cursor{Sort: "created_at,id", Key: []interface{}{2005, 3}}.Encode()},
+		{name: "Malformed Time Key", path: "/api/v1/books?sort=created_at&cursor=" + pagination.Cursor{Sort: "created_at,id", Key: []interface{}{"yesterday", 3}}.Encode()},
+		{name: "Fuzzy Matching", path: "/api/v1/books?sort=-year&title=edensor&fuzzy=true&cursor=" + first.NextCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, tt.path, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+			body, _ := io.ReadAll(resp.Body)
+			var problem apperror.Problem
+			assert.NoError(t, json.Unmarshal(body, &problem))
+			if assert.Len(t, problem.Errors, 1) {
+				assert.Equal(t, "cursor", problem.Errors[0].Field)
+			}
+		})
+	}
+}
+
+func TestBookTrash(t *testing.T) {
+	t.Parallel()
+	app := setupTestApp(t)
+
+	send := func(method string, path string, token string) *http.Response {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("If-Match", "*")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		return resp
+	}
+
+	createBook(t, app, book.BookRequest{Title: "Laskar Pelangi", ISBN13: "978-979-96257-0-0", Pages: 529, Year: 2005, PublisherID: 1})
+	createBook(t, app, book.BookRequest{Title: "Sang Pemimpi", Pages: 292, Year: 2006, PublisherID: 1})
+	assert.Equal(t, fiber.StatusNoContent, send(http.MethodDelete, "/api/v1/books/1", "").StatusCode)
+	assert.Equal(t, fiber.StatusNoContent, send(http.MethodDelete, "/api/v1/books/2", "").StatusCode)
+
+	resp := send(http.MethodGet, "/api/v1/books/trash", "")
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	var trash book.BookTrashListResponse
+	assert.NoError(t, json.Unmarshal(body, &trash))
+	if assert.Len(t, trash.Data, 2) {
+		assert.Equal(t, "Laskar Pelangi", trash.Data[0].Title)
+		assert.False(t, trash.Data[0].DeletedAt.IsZero())
+	}
+
+	// The ISBN of a deleted book can be reused, which blocks restoring it
+	createBook(t, app, book.BookRequest{Title: "Laskar Pelangi", ISBN13: "9789799625700", Pages: 534, Year: 2008, PublisherID: 1})
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		token          string
+		expectedStatus int
+	}{
+		{name: "Restore", method: http.MethodPost, path: "/api/v1/books/2/restore", expectedStatus: fiber.StatusOK},
+		{name: "Restore Live Book", method: http.MethodPost, path: "/api/v1/books/2/restore", expectedStatus: fiber.StatusNotFound},
+		{name: "Restore Taken ISBN", method: http.MethodPost, path: "/api/v1/books/1/restore", expectedStatus: fiber.StatusConflict},
+		{name: "Purge Without Token", method: http.MethodDelete, path: "/api/v1/books/1/purge", expectedStatus: fiber.StatusUnauthorized},
+		{name: "Purge With Wrong Token", method: http.MethodDelete, path: "/api/v1/books/1/purge", token: "guess", expectedStatus: fiber.StatusForbidden},
+		{name: "Purge Live Book", method: http.MethodDelete, path: "/api/v1/books/2/purge", token: adminToken, expectedStatus: fiber.StatusNotFound},
+		{name: "Purge", method: http.MethodDelete, path: "/api/v1/books/1/purge", token: adminToken, expectedStatus: fiber.StatusNoContent},
+		{name: "Restore Purged Book", method: http.MethodPost, path: "/api/v1/books/1/restore", expectedStatus: fiber.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedStatus, send(tt.method, tt.path, tt.token).StatusCode)
+		})
+	}
+
+	// The restore is a change of its own, so copies from before the delete are stale
+	resp = send(http.MethodGet, "/api/v1/books/2", "")
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, `"2"`, resp.Header.Get(fiber.HeaderETag))
+	body, _ = io.ReadAll(send(http.MethodGet, "/api/v1/books/trash", "").Body)
+	assert.NoError(t, json.Unmarshal(body, &trash))
+	assert.Empty(t, trash.Data)
+}
+
+func TestRelatedChangesUpdateBooks(t *testing.T) {
+	t.Parallel()
+	app := setupTestApp(t)
+
+	createBook(t, app, book.BookRequest{Title: "Laskar Pelangi", Pages: 529, Year: 2005, PublisherID: 1, AuthorIDs: []uint{1}, CategoryIDs: []uint{1}})
+
+	send := func(method string, path string, body string, header string, value string) *http.Response {
+		req := httptest.NewRequest(method, path, bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(header, value)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		return resp
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+		expectedChange bool
+	}{
+		{name: "Rename Author", method: http.MethodPut, path: "/api/v1/authors/1", body: `{"name": "A. Hirata"}`, expectedStatus: fiber.StatusOK, expectedChange: true},
+		{name: "Describe Author", method: http.MethodPut, path: "/api/v1/authors/1", body: `{"name": "A. Hirata", "description": "Belitung"}`, expectedStatus: fiber.StatusOK},
+		{name: "Rename Publisher", method: http.MethodPut, path: "/api/v1/publishers/1", body: `{"name": "Gramedia Pustaka Utama"}`, expectedStatus: fiber.StatusOK, expectedChange: true},
+		{name: "Recode Category", method: http.MethodPut, path: "/api/v1/categories/1", body: `{"code": "FIK", "name": "Fiction"}`, expectedStatus: fiber.StatusOK, expectedChange: true},
+		{name: "Delete Category", method: http.MethodDelete, path: "/api/v1/categories/1", expectedStatus: fiber.StatusNoContent, expectedChange: true},
+		{name: "Restore Category", method: http.MethodPost, path: "/api/v1/categories/1/restore", expectedStatus: fiber.StatusOK, expectedChange: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			etag := send(http.MethodGet, "/api/v1/books/1", "", "X-Actor", "mirror").Header.Get(fiber.HeaderETag)
+			assert.Equal(t, tt.expectedStatus, send(tt.method, tt.path, tt.body, "If-Match", "*").StatusCode)
+
+			// A mirror holding the book only gets it again when what the book shows changed
+			expectedStatus := fiber.StatusNotModified
+			if tt.expectedChange {
+				expectedStatus = fiber.StatusOK
+			}
+			assert.Equal(t, expectedStatus, send(http.MethodGet, "/api/v1/books/1", "", "If-None-Match", etag).StatusCode)
+		})
+	}
+
+	// Each change of the book is in its history
+	body, _ := io.ReadAll(send(http.MethodGet, "/api/v1/books/1/history", "", "X-Actor", "mirror").Body)
+	var entries history.HistoryListResponse
+	assert.NoError(t, json.Unmarshal(body, &entries))
+	assert.Equal(t, uint64(6), entries.Total)
+}
+
+func TestDeletePolicies(t *testing.T) {
+	t.Parallel()
+	app := setupTestApp(t)
+
+	send := func(method string, path string) *http.Response {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("If-Match", "*")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		return resp
+	}
+	getBook := func(id string) (int, book.BookDetailResponse) {
+		resp := send(http.MethodGet, "/api/v1/books/"+id)
+		body, _ := io.ReadAll(resp.Body)
+		var detail book.BookDetailResponse
+		json.Unmarshal(body, &detail)
+		return resp.StatusCode, detail
+	}
+
+	createBook(t, app, book.BookRequest{Title: "Laskar Pelangi", Pages: 529, Year: 2005, PublisherID: 1, AuthorIDs: []uint{1}})
+	createBook(t, app, book.BookRequest{Title: "Supernova", Pages: 320, Year: 2001, PublisherID: 2, AuthorIDs: []uint{1, 2}})
+
+	// Restrict lists the books that block the delete
+	resp := send(http.MethodDelete, "/api/v1/publishers/1")
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	var problem apperror.Problem
+	assert.NoError(t, json.Unmarshal(body, &problem))
+	assert.Equal(t, []apperror.Reference{{Type: "book", ID: 1, Title: "Laskar Pelangi"}}, problem.References)
+
+	// A delete at a stale version leaves the books of the publisher alone
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/publishers/2?policy=cascade", nil)
+	req.Header.Set("If-Match", `"2"`)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusPreconditionFailed, resp.StatusCode)
+	code, _ := getBook("2")
+	assert.Equal(t, fiber.StatusOK, code)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{name: "Unknown Policy", method: http.MethodDelete, path: "/api/v1/authors/1?policy=orphan", expectedStatus: fiber.StatusBadRequest},
+		{name: "Reassign Without Target", method: http.MethodDelete, path: "/api/v1/authors/1?policy=reassign", expectedStatus: fiber.StatusBadRequest},
+		{name: "Reassign To Itself", method: http.MethodDelete, path: "/api/v1/publishers/1?policy=reassign&reassign_to=1", expectedStatus: fiber.StatusUnprocessableEntity},
+		{name: "Reassign To Missing Publisher", method: http.MethodDelete, path: "/api/v1/publishers/1?policy=reassign&reassign_to=9", expectedStatus: fiber.StatusUnprocessableEntity},
+		{name: "Restrict Author", method: http.MethodDelete, path: "/api/v1/authors/2", expectedStatus: fiber.StatusConflict},
+		{name: "Reassign Publisher", method: http.MethodDelete, path: "/api/v1/publishers/1?policy=reassign&reassign_to=2", expectedStatus: fiber.StatusNoContent},
+		{name: "Reassign Author", method: http.MethodDelete, path: "/api/v1/authors/2?policy=reassign&reassign_to=1", expectedStatus: fiber.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedStatus, send(tt.method, tt.path).StatusCode)
+		})
+	}
+
+	_, detail := getBook("1")
+	assert.Equal(t, "2", detail.Publisher.ID)
+	_, detail = getBook("2")
+	if assert.Len(t, detail.Authors, 1) {
+		assert.Equal(t, "Andrea Hirata", detail.Authors[0].Name)
+	}
+
+	// Cascading the last author of both books moves them to the trash
+	assert.Equal(t, fiber.StatusNoContent, send(http.MethodDelete, "/api/v1/authors/1?policy=cascade").StatusCode)
+	assert.Equal(t, fiber.StatusNotFound, send(http.MethodDelete, "/api/v1/authors/1").StatusCode)
+	status, _ := getBook("1")
+	assert.Equal(t, fiber.StatusNotFound, status)
+	body, _ = io.ReadAll(send(http.MethodGet, "/api/v1/books/trash").Body)
+	var trash book.BookTrashListResponse
+	assert.NoError(t, json.Unmarshal(body, &trash))
+	assert.Len(t, trash.Data, 2)
+}
+
+func TestCascadeKeepsCoAuthoredBooks(t *testing.T) {
+	t.Parallel()
+	app := setupTestApp(t)
+
+	send := func(method string, path string) *http.Response {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("If-Match", "*")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		return resp
+	}
+	getBook := func() (string, book.BookDetailResponse) {
+		resp := send(http.MethodGet, "/api/v1/books/1")
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		body, _ := io.ReadAll(resp.Body)
+		var detail book.BookDetailResponse
+		assert.NoError(t, json.Unmarshal(body, &detail))
+		return resp.Header.Get(fiber.HeaderETag), detail
+	}
+
+	createBook(t, app, book.BookRequest{Title: "Supernova", Pages: 320, Year: 2001, PublisherID: 1, AuthorIDs: []uint{1, 2}})
+
+	// The book keeps its other author and moves to a new version without the deleted one
+	assert.Equal(t, fiber.StatusNoContent, send(http.MethodDelete, "/api/v1/authors/2?policy=cascade").StatusCode)
+	etag, detail := getBook()
+	assert.Equal(t, `"2"`, etag)
+	assert.Len(t, detail.Authors, 1)
+
+	// Restoring the author shows it on the book again
+	assert.Equal(t, fiber.StatusOK, send(http.MethodPost, "/api/v1/authors/2/restore").StatusCode)
+	etag, detail = getBook()
+	assert.Equal(t, `"3"`, etag)
+	assert.Len(t, detail.Authors, 2)
+
+	body, _ := io.ReadAll(send(http.MethodGet, "/api/v1/books/1/history").Body)
+	var entries history.HistoryListResponse
+	assert.NoError(t, json.Unmarshal(body, &entries))
+	assert.Equal(t, uint64(3), entries.Total)
+}
+
+func TestPatchBook(t *testing.T) {
+	t.Parallel()
+	app := setupTestApp(t)
+
+	createBook(t, app, book.BookRequest{Title: "Laskar Pelangi", Pages: 529, Year: 2005, PublisherID: 1, AuthorIDs: []uint{1}, CategoryIDs: []uint{1}})
+
+	tests := []struct {
+		name            string
+		contentType     string
+		ifMatch         string
+		body            string
+		expectedStatus  int
+		expectedTitle   string
+		expectedAuthors []string
+	}{
+		{
+			name:           "Missing If-Match",
+			contentType:    "application/merge-patch+json",
+			body:           `{"title": "Sang Pemimpi"}`,
+			expectedStatus: fiber.StatusPreconditionRequired,
+		},
+		{
+			name:            "Merge Patch Keeps Relations",
+			contentType:     "application/merge-patch+json",
+			ifMatch:         `"1"`,
+			body:            `{"title": "Sang Pemimpi"}`,
+			expectedStatus:  fiber.StatusOK,
+			expectedTitle:   "Sang Pemimpi",
+			expectedAuthors: []string{"Andrea Hirata"},
+		},
+		{
+			name:            "JSON Patch Adds Author",
+			contentType:     "application/json-patch+json",
+			ifMatch:         `"2"`,
+			body:            `[{"op": "add", "path": "/author_ids/-", "value": 2}, {"op": "add", "path": "/author_ids/-", "value": 1}]`,
+			expectedStatus:  fiber.StatusOK,
+			expectedTitle:   "Sang Pemimpi",
+			expectedAuthors: []string{"Andrea Hirata", "Dee Lestari"},
+		},
+		{
+			name:           "Patch Breaks Validation",
+			contentType:    "application/merge-patch+json",
+			ifMatch:        `"3"`,
+			body:           `{"pages": null}`,
+			expectedStatus: fiber.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Unknown Author",
+			contentType:    "application/json-patch+json",
+			ifMatch:        `"3"`,
+			body:           `[{"op": "replace", "path": "/author_ids/0", "value": 99}]`,
+			expectedStatus: fiber.StatusUnprocessableEntity,
+		},
+		{
+			name:            "JSON Patch Removes Author",
+			contentType:     "application/json-patch+json",
+			ifMatch:         `"3"`,
+			body:            `[{"op": "test", "path": "/author_ids/0", "value": 1}, {"op": "remove", "path": "/author_ids/0"}]`,
+			expectedStatus:  fiber.StatusOK,
+			expectedTitle:   "Sang Pemimpi",
+			expectedAuthors: []string{"Dee Lestari"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/books/1", bytes.NewReader([]byte(tt.body)))
+			req.Header.Set("Content-Type", tt.contentType)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.expectedStatus == fiber.StatusOK {
+				body, _ := io.ReadAll(resp.Body)
+				var detail book.BookDetailResponse
+				assert.NoError(t, json.Unmarshal(body, &detail))
+				assert.Equal(t, tt.expectedTitle, detail.Title)
+				names := []string{}
+				for _, a := range detail.Authors {
+					names = append(names, a.Name)
+				}
+				assert.ElementsMatch(t, tt.expectedAuthors, names)
+				if assert.Len(t, detail.Categories, 1) {
+					assert.Equal(t, "FIC", detail.Categories[0].Code)
+				}
+			}
+		})
+	}
+}
+
+func TestPatchBookISBN(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedISBN10 string
+		expectedISBN13 string
+	}{
+		{name: "ISBN-13 Derives ISBN-10", body: `{"isbn13": "978-0-306-40615-7"}`, expectedStatus: fiber.StatusOK, expectedISBN10: "0306406152", expectedISBN13: "9780306406157"},
+		{name: "ISBN-10 Derives ISBN-13", body: `{"isbn10": "0-306-40615-2"}`, expectedStatus: fiber.StatusOK, expectedISBN10: "0306406152", expectedISBN13: "9780306406157"},
+		{name: "Null ISBN-13 Clears ISBN-10", body: `{"isbn13": null}`, expectedStatus: fiber.StatusOK},
+		{name: "Null ISBN-10 Clears ISBN-13", body: `{"isbn10": null}`, expectedStatus: fiber.StatusOK},
+		{name: "Unchanged ISBN-13 Keeps ISBN-10", body: `{"isbn13": "978-979-96257-0-0", "title": "Laskar Pelangi"}`, expectedStatus: fiber.StatusOK, expectedISBN10: "979962570X", expectedISBN13: "9789799625700"},
+		{name: "Both Forms Must Match", body: `{"isbn10": "0306406152", "isbn13": "9780131103627"}`, expectedStatus: fiber.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp(t)
+			resp := createBook(t, app, book.BookRequest{Title: "Laskar Pelangi", ISBN13: "9789799625700", Pages: 529, Year: 2005, PublisherID: 1, AuthorIDs: []uint{1}})
+			assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/books/1", bytes.NewReader([]byte(tt.body)))
+			req.Header.Set("Content-Type", "application/merge-patch+json")
+			req.Header.Set("If-Match", `"1"`)
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.expectedStatus == fiber.StatusOK {
+				body, _ := io.ReadAll(resp.Body)
+				var detail book.BookDetailResponse
+				assert.NoError(t, json.Unmarshal(body, &detail))
+				assert.Equal(t, tt.expectedISBN10, detail.ISBN10)
+				assert.Equal(t, tt.expectedISBN13, detail.ISBN13)
+			}
+		})
+	}
+}
+
+func TestBookHistory(t *testing.T) {
+	t.Parallel()
+	app := setupTestApp(t)
+
+	send := func(method string, path string, body string) *http.Response {
+		req := httptest.NewRequest(method, path, bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", "*")
+		req.Header.Set("X-Actor", "librarian")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		return resp
+	}
+	getBook := func(path string) (int, book.BookDetailResponse) {
+		resp := send(http.MethodGet, path, "")
+		body, _ := io.ReadAll(resp.Body)
+		var detail book.BookDetailResponse
+		json.Unmarshal(body, &detail)
+		return resp.StatusCode, detail
+	}
+
+	beforeCreate := time.Now()
+	createBook(t, app, book.BookRequest{Title: "Laskar Pelangi", Pages: 529, Year: 2005, PublisherID: 1, AuthorIDs: []uint{1}})
+	created := time.Now()
+	resp := send(http.MethodPut, "/api/v1/books/1", `{"title": "Sang Pemimpi", "pages": 292, "year": 2006, "publisher_id": 2, "author_ids": [1, 2]}`)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	// Reverting restores the fields and links of version 1 as version 3
+	assert.Equal(t, fiber.StatusNotFound, send(http.MethodPost, "/api/v1/books/1/revert", `{"version": 9}`).StatusCode)
+	assert.Equal(t, fiber.StatusOK, send(http.MethodPost, "/api/v1/books/1/revert", `{"version": 1}`).StatusCode)
+	_, reverted := getBook("/api/v1/books/1")
+	assert.Equal(t, uint(3), reverted.Version)
+	assert.Equal(t, "Laskar Pelangi", reverted.Title)
+	assert.Equal(t, "1", reverted.Publisher.ID)
+	assert.Len(t, reverted.Authors, 1)
+
+	body, _ := io.ReadAll(send(http.MethodGet, "/api/v1/books/1/history", "").Body)
+	var entries history.HistoryListResponse
+	assert.NoError(t, json.Unmarshal(body, &entries))
+	actions := []history.Action{}
+	for _, e := range entries.Data {
+		actions = append(actions, e.Action)
+	}
+	assert.Equal(t, []history.Action{history.ActionRevert, history.ActionUpdate, history.ActionCreate}, actions)
+	assert.Equal(t, "librarian", entries.Data[0].Actor)
+	assert.Empty(t, entries.Data[2].Before)
+	assert.NotEmpty(t, entries.Data[2].After)
+
+	tests := []struct {
+		name            string
+		path            string
+		expectedStatus  int
+		expectedVersion uint
+		expectedAuthors int
+	}{
+		{name: "Before Creation", path: "/api/v1/books/1?as_of=" + beforeCreate.Add(-time.Second).Format(time.RFC3339), expectedStatus: fiber.StatusNotFound},
+		{name: "After Creation", path: "/api/v1/books/1?as_of=" + created.Format(time.RFC3339Nano), expectedStatus: fiber.StatusOK, expectedVersion: 1, expectedAuthors: 1},
+		{name: "Now", path: "/api/v1/books/1?as_of=" + time.Now().Format(time.RFC3339Nano), expectedStatus: fiber.StatusOK, expectedVersion: 3, expectedAuthors: 1},
+		{name: "Invalid Time", path: "/api/v1/books/1?as_of=yesterday", expectedStatus: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, detail := getBook(tt.path)
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedStatus == fiber.StatusOK {
+				assert.Equal(t, tt.expectedVersion, detail.Version)
+				assert.Len(t, detail.Authors, tt.expectedAuthors)
+			}
+		})
+	}
+
+	// Deleting ends the states as of later times
+	assert.Equal(t, fiber.StatusNoContent, send(http.MethodDelete, "/api/v1/books/1", "").StatusCode)
+	status, _ := getBook("/api/v1/books/1?as_of=" + time.Now().Format(time.RFC3339Nano))
+	assert.Equal(t, fiber.StatusNotFound, status)
+}
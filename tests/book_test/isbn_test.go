@@ -0,0 +1,61 @@
+package book_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+)
+
+func TestNormalizeISBN(t *testing.T) {
+	tests := []struct {
+		name          string
+		isbn          string
+		expectedISBN  string
+		expectedError bool
+	}{
+		{
+			name:         "Valid ISBN-13 With Hyphens",
+			isbn:         "978-0-06-088328-7",
+			expectedISBN: "9780060883287",
+		},
+		{
+			name:         "Valid ISBN-10 Converted To ISBN-13",
+			isbn:         "0-06-088328-6",
+			expectedISBN: "9780060883287",
+		},
+		{
+			name:         "Valid ISBN-10 With X Check Digit",
+			isbn:         "0-8044-2957-x",
+			expectedISBN: "9780804429573",
+		},
+		{
+			name:          "Invalid ISBN-13 Checksum",
+			isbn:          "9780060883288",
+			expectedError: true,
+		},
+		{
+			name:          "Invalid ISBN-10 Checksum",
+			isbn:          "0060883285",
+			expectedError: true,
+		},
+		{
+			name:          "Invalid Length",
+			isbn:          "12345",
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isbn, err := book.NormalizeISBN(tt.isbn)
+			if tt.expectedError {
+				assert.ErrorIs(t, err, book.ErrInvalidISBN)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedISBN, isbn)
+		})
+	}
+}
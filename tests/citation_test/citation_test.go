@@ -0,0 +1,122 @@
+package citation_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/citation"
+	"github.com/tedysaputro/book-catalog-with-go/tests/fixtures"
+)
+
+func TestParseName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		raw      string
+		expected citation.Name
+	}{
+		{raw: "Andrea Hirata", expected: citation.Name{Family: "Hirata", Given: "Andrea"}},
+		{raw: "Hirata, Andrea", expected: citation.Name{Family: "Hirata", Given: "Andrea"}},
+		{raw: "Pramoedya  Ananta Toer", expected: citation.Name{Family: "Toer", Given: "Pramoedya Ananta"}},
+		{raw: "Ludwig van Beethoven", expected: citation.Name{Family: "van Beethoven", Given: "Ludwig"}},
+		{raw: "Martin Luther King Jr.", expected: citation.Name{Family: "King", Given: "Martin Luther", Suffix: "Jr."}},
+		{raw: "Martin Luther King, Jr.", expected: citation.Name{Family: "King", Given: "Martin Luther", Suffix: "Jr."}},
+		{raw: "King, Jr., Martin Luther", expected: citation.Name{Family: "King", Given: "Martin Luther", Suffix: "Jr."}},
+		{raw: "Sukarno", expected: citation.Name{Family: "Sukarno"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			assert.Equal(t, tt.expected, citation.ParseName(tt.raw))
+		})
+	}
+}
+
+func TestCiteBook(t *testing.T) {
+	t.Parallel()
+
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	citation.NewCitationHandler(citation.NewCitationService(fixtures.NewCatalog(t).Books)).RegisterRoutes(app)
+
+	tests := []struct {
+		name                string
+		url                 string
+		accept              string
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "BibTeX By Default",
+			url:                 "/api/v1/books/1/cite",
+			expectedStatus:      fiber.StatusOK,
+			expectedContentType: "application/x-bibtex; charset=utf-8",
+			expectedBody:        "@book{hirata2005laskar,\n  author = {Hirata, Andrea and Lestari, Dee},\n  title = {Laskar Pelangi},\n  publisher = {Bentang Pustaka},\n  year = {2005},\n  isbn = {9789799625700},\n  pagetotal = {529},\n}\n",
+		},
+		{
+			name:                "RIS",
+			url:                 "/api/v1/books/3/cite?format=ris",
+			expectedStatus:      fiber.StatusOK,
+			expectedContentType: "application/x-research-info-systems; charset=utf-8",
+			expectedBody:        "TY  - BOOK\r\nAU  - Lestari, Dee\r\nTI  - Supernova\r\nPB  - Gramedia\r\nPY  - 2001\r\nER  - \r\n",
+		},
+		{
+			name:                "CSL-JSON By Accept",
+			url:                 "/api/v1/books/3/cite",
+			accept:              "application/vnd.citationstyles.csl+json",
+			expectedStatus:      fiber.StatusOK,
+			expectedContentType: "application/vnd.citationstyles.csl+json",
+			expectedBody:        `[{"id":"book-3","type":"book","title":"Supernova","author":[{"family":"Lestari","given":"Dee"}],"publisher":"Gramedia","issued":{"date-parts":[[2001]]},"number-of-pages":320}]`,
+		},
+		{
+			name:                "APA",
+			url:                 "/api/v1/books/1/cite?format=apa",
+			expectedStatus:      fiber.StatusOK,
+			expectedContentType: "text/x-bibliography; style=apa; charset=utf-8",
+			expectedBody:        "Hirata, A., & Lestari, D. (2005). Laskar Pelangi. Bentang Pustaka.\n",
+		},
+		{
+			name:                "MLA By Accept Style",
+			url:                 "/api/v1/books/1/cite",
+			accept:              "text/x-bibliography; style=mla",
+			expectedStatus:      fiber.StatusOK,
+			expectedContentType: "text/x-bibliography; style=mla; charset=utf-8",
+			expectedBody:        "Hirata, Andrea, and Dee Lestari. Laskar Pelangi. Bentang Pustaka, 2005.\n",
+		},
+		{
+			name:                "Filtered Books",
+			url:                 "/api/v1/books/cite?format=apa",
+			expectedStatus:      fiber.StatusOK,
+			expectedContentType: "text/x-bibliography; style=apa; charset=utf-8",
+			expectedBody:        "Hirata, A. (2006). Sang Pemimpi. Bentang Pustaka.\nHirata, A., & Lestari, D. (2005). Laskar Pelangi. Bentang Pustaka.\nLestari, D. (2001). Supernova. Gramedia.\n",
+		},
+		{name: "Unknown Format", url: "/api/v1/books/1/cite?format=chicago", expectedStatus: fiber.StatusBadRequest},
+		{name: "Not Acceptable", url: "/api/v1/books/1/cite", accept: "text/html", expectedStatus: fiber.StatusNotAcceptable},
+		{name: "Missing Book", url: "/api/v1/books/9/cite", expectedStatus: fiber.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.accept != "" {
+				req.Header.Set(fiber.HeaderAccept, tt.accept)
+			}
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			if tt.expectedStatus != fiber.StatusOK {
+				return
+			}
+
+			body, _ := io.ReadAll(resp.Body)
+			assert.Equal(t, tt.expectedContentType, resp.Header.Get(fiber.HeaderContentType))
+			assert.Equal(t, tt.expectedBody, string(body))
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Package fixtures seeds the in-memory catalog the tests of the endpoints run on
+package fixtures
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/author"
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/category"
+	"github.com/tedysaputro/book-catalog-with-go/src/history"
+	"github.com/tedysaputro/book-catalog-with-go/src/publisher"
+)
+
+// Catalog is a seeded catalog kept in memory
+type Catalog struct {
+	Authors    author.AuthorRepository
+	Publishers publisher.PublisherRepository
+	Categories category.CategoryRepository
+	Books      book.BookRepository
+	History    history.HistoryRepository
+	// Changes records the changes made through the services of the catalog in History
+	Changes history.HistoryService
+}
+
+// NewCatalog creates a catalog holding
+//   - publishers 1 "Bentang Pustaka" and 2 "Gramedia"
+//   - authors 1 "Andrea Hirata" and 2 "Dee Lestari"
+//   - category 1 "FIC" (Fiction)
+//   - book 1 "Laskar Pelangi" (ISBN 9789799625700, 529 pages, 2005) by both authors, published
+//     by Bentang Pustaka in Fiction
+//   - book 2 "Sang Pemimpi" (292 pages, 2006) by Andrea Hirata, published by Bentang Pustaka
+//   - book 3 "Supernova" (320 pages, 2001) by Dee Lestari, published by Gramedia in Fiction
+func NewCatalog(t *testing.T) *Catalog {
+	authors := author.NewMemoryAuthorRepository()
+	publishers := publisher.NewMemoryPublisherRepository()
+	categories := category.NewMemoryCategoryRepository()
+	books := book.NewMemoryBookRepository(authors, publishers, categories)
+	changes := history.NewMemoryHistoryRepository()
+
+	ctx := context.Background()
+	assert.NoError(t, publishers.Create(ctx, &publisher.Publisher{Name: "Bentang Pustaka"}))
+	assert.NoError(t, publishers.Create(ctx, &publisher.Publisher{Name: "Gramedia"}))
+	assert.NoError(t, authors.Create(ctx, &author.Author{Name: "Andrea Hirata"}))
+	assert.NoError(t, authors.Create(ctx, &author.Author{Name: "Dee Lestari"}))
+	assert.NoError(t, categories.Create(ctx, &category.Category{Code: "FIC", Name: "Fiction"}))
+	for _, b := range []*book.Book{
+		{Title: "Laskar Pelangi", ISBN13: "9789799625700", Pages: 529, Year: 2005, PublisherID: 1, Authors: []author.Author{{ID: 1}, {ID: 2}}, Categories: []category.Category{{ID: 1}}},
+		{Title: "Sang Pemimpi", Pages: 292, Year: 2006, PublisherID: 1, Authors: []author.Author{{ID: 1}}},
+		{Title: "Supernova", Pages: 320, Year: 2001, PublisherID: 2, Authors: []author.Author{{ID: 2}}, Categories: []category.Category{{ID: 1}}},
+	} {
+		assert.NoError(t, books.Create(ctx, b))
+	}
+
+	return &Catalog{
+		Authors:    authors,
+		Publishers: publishers,
+		Categories: categories,
+		Books:      books,
+		History:    changes,
+		Changes:    history.NewHistoryService(changes),
+	}
+}
+
+// BookService creates the BookService of the catalog
+func (c *Catalog) BookService() book.BookService {
+	return book.NewBookService(c.Books, c.Authors, c.Publishers, c.Categories, c.Changes)
+}
+
+// AuthorService creates the AuthorService of the catalog
+func (c *Catalog) AuthorService() author.AuthorService {
+	return author.NewAuthorService(c.Authors, book.NewAuthorDependents(c.Books, c.Changes), c.Changes)
+}
+
+// PublisherService creates the PublisherService of the catalog
+func (c *Catalog) PublisherService() publisher.PublisherService {
+	return publisher.NewPublisherService(c.Publishers, book.NewPublisherDependents(c.Books, c.Changes), c.Changes)
+}
+
+// CategoryService creates the CategoryService of the catalog
+func (c *Catalog) CategoryService() category.CategoryService {
+	return category.NewCategoryService(c.Categories, book.NewCategoryLinks(c.Books, c.Changes), c.Changes)
+}
@@ -0,0 +1,254 @@
+package graph_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/author"
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/graph"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+	"github.com/tedysaputro/book-catalog-with-go/tests/fixtures"
+)
+
+// countingBookService counts the batched book reads of the resolvers
+type countingBookService struct {
+	book.BookService
+	byGroup int
+}
+
+func (s *countingBookService) GetBooksByGroup(ctx context.Context, grouping book.Grouping, ids []uint, page pagination.Request, sort sorting.Spec) (map[uint]*book.BookListResponse, error) {
+	s.byGroup++
+	return s.BookService.GetBooksByGroup(ctx, grouping, ids, page, sort)
+}
+
+// countingAuthorService counts the batched author reads of the resolvers
+type countingAuthorService struct {
+	author.AuthorService
+	byIDs int
+}
+
+func (s *countingAuthorService) GetAuthorsByIDs(ctx context.Context, ids []uint) ([]author.AuthorDetailResponse, error) {
+	s.byIDs++
+	return s.AuthorService.GetAuthorsByIDs(ctx, ids)
+}
+
+// response is a GraphQL response
+type response struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+// setupTestApp serves the catalog of fixtures.NewCatalog
+func setupTestApp(t *testing.T) (*fiber.App, *countingBookService, *countingAuthorService) {
+	catalog := fixtures.NewCatalog(t)
+	bookService := &countingBookService{BookService: catalog.BookService()}
+	authorService := &countingAuthorService{AuthorService: catalog.AuthorService()}
+
+	service, err := graph.NewGraphService(bookService, authorService, catalog.PublisherService(), catalog.CategoryService())
+	assert.NoError(t, err)
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	graph.NewGraphHandler(service).RegisterRoutes(app)
+	return app, bookService, authorService
+}
+
+// execute posts a GraphQL request and decodes its response
+func execute(t *testing.T, app *fiber.App, query string, variables map[string]interface{}) response {
+	body, _ := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var out response
+	raw, _ := io.ReadAll(resp.Body)
+	assert.NoError(t, json.Unmarshal(raw, &out))
+	return out
+}
+
+// errorCodes returns the codes in the extensions of the errors of a response
+func (r response) errorCodes() []string {
+	codes := []string{}
+	for _, err := range r.Errors {
+		code, _ := err.Extensions["code"].(string)
+		codes = append(codes, code)
+	}
+	return codes
+}
+
+func TestGraphQL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		query         string
+		variables     map[string]interface{}
+		expectedCodes []string
+		expectedData  string
+	}{
+		{
+			name:         "Book With Nested Authors And Their Books",
+			query:        `{ book(id: 2) { title publisher { name } authors { name books { data { title } } } } }`,
+			expectedData: `{"book":{"title":"Sang Pemimpi","publisher":{"name":"Bentang Pustaka"},"authors":[{"name":"Andrea Hirata","books":{"data":[{"title":"Laskar Pelangi"},{"title":"Sang Pemimpi"}]}}]}}`,
+		},
+		{
+			name:         "Filtered Page Of Books",
+			query:        `query($category: [String!]) { books(category: $category, sort: "-year", pageSize: 1) { data { title categories { code } } page pageSize total totalPages prevCursor } }`,
+			variables:    map[string]interface{}{"category": []string{"FIC"}},
+			expectedData: `{"books":{"data":[{"title":"Laskar Pelangi","categories":[{"code":"FIC"}]}],"page":1,"pageSize":1,"total":2,"totalPages":2,"prevCursor":null}}`,
+		},
+		{
+			name:         "Authors By Name",
+			query:        `{ authors(name: "Dee") { data { id version name books { data { title } } } total } }`,
+			expectedData: `{"authors":{"data":[{"id":"2","version":1,"name":"Dee Lestari","books":{"data":[{"title":"Laskar Pelangi"},{"title":"Supernova"}]}}],"total":1}}`,
+		},
+		{
+			name:         "Category Books",
+			query:        `{ categories { data { code books { data { title publisher { name } } } } } }`,
+			expectedData: `{"categories":{"data":[{"code":"FIC","books":{"data":[{"title":"Laskar Pelangi","publisher":{"name":"Bentang Pustaka"}},{"title":"Supernova","publisher":{"name":"Gramedia"}}]}}]}}`,
+		},
+		{
+			name:          "Unknown Book",
+			query:         `{ book(id: 99) { title } }`,
+			expectedCodes: []string{"NOT_FOUND"},
+			expectedData:  `{"book":null}`,
+		},
+		{
+			name:          "Unknown Sort Field",
+			query:         `{ books(sort: "price") { total } }`,
+			expectedCodes: []string{"BAD_REQUEST"},
+		},
+		{
+			name:          "Cursor With Page",
+			query:         `{ books(page: 2, cursor: "abc") { total } }`,
+			expectedCodes: []string{"BAD_REQUEST"},
+		},
+		{
+			name:          "Invalid Query",
+			query:         `{ books { isbn } }`,
+			expectedCodes: []string{""},
+		},
+		{
+			name:         "Paged Books Of A Record",
+			query:        `{ author(id: 2) { books(pageSize: 1, page: 2, sort: "-year") { data { title } page total totalPages } } }`,
+			expectedData: `{"author":{"books":{"data":[{"title":"Supernova"}],"page":2,"total":2,"totalPages":2}}}`,
+		},
+		{
+			name:          "Invalid Sort Of The Books Of A Record",
+			query:         `{ author(id: 2) { books(sort: "price") { total } } }`,
+			expectedCodes: []string{"BAD_REQUEST"},
+		},
+		{
+			name:          "Too Deep",
+			query:         `{ book(id: 1) { publisher { books { data { publisher { books { data { publisher { name } } } } } } } } }`,
+			expectedCodes: []string{"MAX_DEPTH_EXCEEDED"},
+		},
+		{
+			name:          "Too Complex",
+			query:         `{ books(pageSize: 100) { data { authors { books(pageSize: 100) { data { authors { name } } } } } } }`,
+			expectedCodes: []string{"MAX_COMPLEXITY_EXCEEDED"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, _, _ := setupTestApp(t)
+			out := execute(t, app, tt.query, tt.variables)
+
+			if tt.expectedCodes == nil {
+				assert.Empty(t, out.Errors)
+			} else {
+				assert.Equal(t, tt.expectedCodes, out.errorCodes())
+			}
+			if tt.expectedData != "" {
+				data, _ := json.Marshal(out.Data)
+				assert.JSONEq(t, tt.expectedData, string(data))
+			}
+		})
+	}
+}
+
+func TestBatchedResolvers(t *testing.T) {
+	t.Parallel()
+
+	app, books, authors := setupTestApp(t)
+	out := execute(t, app, `{ books { data { title authors { name books(pageSize: 1, sort: "-year") { data { title authors { name } } total } } } } }`, nil)
+	assert.Empty(t, out.Errors)
+	assert.Len(t, out.Data["books"].(map[string]interface{})["data"], 3)
+
+	// One read of the authors of the books and one of their books, whose authors are already loaded
+	assert.Equal(t, 1, authors.byIDs)
+	assert.Equal(t, 1, books.byGroup)
+}
+
+func TestMutations(t *testing.T) {
+	t.Parallel()
+
+	app, _, _ := setupTestApp(t)
+
+	created := execute(t, app, `mutation { createBook(input: {title: "Edensor", pages: 288, year: 2007, publisherId: 1, authorIds: [1]}) { id version authors { name } } }`, nil)
+	assert.Empty(t, created.Errors)
+	assert.Equal(t, map[string]interface{}{"id": "4", "version": float64(1), "authors": []interface{}{map[string]interface{}{"name": "Andrea Hirata"}}}, created.Data["createBook"])
+
+	stale := execute(t, app, `mutation { updateAuthor(id: 1, version: 7, input: {name: "A. Hirata"}) { name } }`, nil)
+	assert.Equal(t, []string{"PRECONDITION_FAILED"}, stale.errorCodes())
+	assert.Equal(t, float64(fiber.StatusPreconditionFailed), stale.Errors[0].Extensions["status"])
+
+	updated := execute(t, app, `mutation { updateAuthor(id: 1, version: 1, input: {name: "A. Hirata"}) { name version } }`, nil)
+	assert.Empty(t, updated.Errors)
+	assert.Equal(t, map[string]interface{}{"name": "A. Hirata", "version": float64(2)}, updated.Data["updateAuthor"])
+
+	restricted := execute(t, app, `mutation { deletePublisher(id: 2, version: 1) }`, nil)
+	assert.Equal(t, []string{"CONFLICT"}, restricted.errorCodes())
+	assert.NotEmpty(t, restricted.Errors[0].Extensions["references"])
+
+	reassigned := execute(t, app, `mutation { deletePublisher(id: 2, version: 1, policy: REASSIGN, reassignTo: 1) }`, nil)
+	assert.Empty(t, reassigned.Errors)
+	assert.Equal(t, true, reassigned.Data["deletePublisher"])
+
+	books := execute(t, app, `{ publisher(id: 1) { books { data { title } total } } publisherGone: publisher(id: 2) { name } }`, nil)
+	assert.Equal(t, []string{"NOT_FOUND"}, books.errorCodes())
+	publisherBooks := books.Data["publisher"].(map[string]interface{})["books"].(map[string]interface{})
+	assert.Len(t, publisherBooks["data"], 4)
+	assert.Equal(t, float64(4), publisherBooks["total"])
+}
+
+func TestQueryString(t *testing.T) {
+	t.Parallel()
+
+	app, _, _ := setupTestApp(t)
+
+	query := url.Values{"query": {`query($id: ID!) { author(id: $id) { name } }`}, "variables": {`{"id": "2"}`}}
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/graphql?"+query.Encode(), nil))
+	assert.NoError(t, err)
+	var out response
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	assert.Equal(t, map[string]interface{}{"author": map[string]interface{}{"name": "Dee Lestari"}}, out.Data)
+
+	mutation := url.Values{"query": {`mutation { deleteBook(id: 1, version: 1) }`}}
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/graphql?"+mutation.Encode(), nil))
+	assert.NoError(t, err)
+	out = response{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	assert.Len(t, out.Errors, 1)
+	assert.Nil(t, out.Data)
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader([]byte("not json"))))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,209 @@
+package importer_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/author"
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/category"
+	"github.com/tedysaputro/book-catalog-with-go/src/history"
+	"github.com/tedysaputro/book-catalog-with-go/src/importer"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/publisher"
+)
+
+func setupTestApp(t *testing.T) (*fiber.App, importer.Repositories) {
+	authors := author.NewMemoryAuthorRepository()
+	publishers := publisher.NewMemoryPublisherRepository()
+	categories := category.NewMemoryCategoryRepository()
+	repos := importer.Repositories{
+		Books:      book.NewMemoryBookRepository(authors, publishers, categories),
+		Authors:    authors,
+		Publishers: publishers,
+		Categories: categories,
+		History:    history.NewHistoryService(history.NewMemoryHistoryRepository()),
+	}
+
+	ctx := context.Background()
+	assert.NoError(t, publishers.Create(ctx, &publisher.Publisher{Name: "Gramedia"}))
+	assert.NoError(t, authors.Create(ctx, &author.Author{Name: "Andrea Hirata"}))
+	assert.NoError(t, categories.Create(ctx, &category.Category{Code: "FIC", Name: "Fiction"}))
+
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	importer.NewImportHandler(importer.NewImportService(importer.NewMemoryStore(repos))).RegisterRoutes(app)
+	return app, repos
+}
+
+const books = `title,isbn13,pages,year,publisher,authors,categories
+Laskar Pelangi,978-979-96257-0-0,529,2005,gramedia,Andrea Hirata,FIC
+Supernova,,320,2001,Bentang Pustaka,Dee Lestari,FIC
+`
+
+const marcxml = `<collection xmlns="http://www.loc.gov/MARC21/slim">
+  <record>
+    <datafield tag="100" ind1="0" ind2=" "><subfield code="a">Andrea Hirata</subfield></datafield>
+    <datafield tag="245" ind1="1" ind2="0"><subfield code="a">Sang Pemimpi</subfield></datafield>
+    <datafield tag="264" ind1=" " ind2="1"><subfield code="b">Gramedia</subfield><subfield code="c">2006</subfield></datafield>
+    <datafield tag="300" ind1=" " ind2=" "><subfield code="a">292 pages</subfield></datafield>
+  </record>
+  <record>
+    <datafield tag="245" ind1="0" ind2="0"><subfield code="a">Edensor</subfield></datafield>
+    <datafield tag="264" ind1=" " ind2="1"><subfield code="b">Gramedia</subfield><subfield code="c">2007</subfield></datafield>
+  </record>
+</collection>`
+
+func TestImportBooks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		query              string
+		contentType        string
+		csv                string
+		expectedStatus     int
+		expectedStatuses   []importer.RowStatus
+		expectedErrors     map[int][]string
+		expectedPublishers []string
+		expectedBooks      uint64
+	}{
+		{
+			name:               "Dry Run Reports Missing Publisher",
+			query:              "?dry_run=true",
+			csv:                books,
+			expectedStatus:     fiber.StatusOK,
+			expectedStatuses:   []importer.RowStatus{importer.StatusValid, importer.StatusFailed},
+			expectedErrors:     map[int][]string{3: {"publisher", "authors"}},
+			expectedPublishers: []string{},
+		},
+		{
+			name:               "Dry Run Creating Missing Records",
+			query:              "?dry_run=true&create_missing=true",
+			csv:                books,
+			expectedStatus:     fiber.StatusOK,
+			expectedStatuses:   []importer.RowStatus{importer.StatusValid, importer.StatusValid},
+			expectedPublishers: []string{"Bentang Pustaka"},
+		},
+		{
+			name:               "Atomic Import Writes Nothing On Failure",
+			csv:                books,
+			expectedStatus:     fiber.StatusUnprocessableEntity,
+			expectedStatuses:   []importer.RowStatus{importer.StatusSkipped, importer.StatusFailed},
+			expectedErrors:     map[int][]string{3: {"publisher", "authors"}},
+			expectedPublishers: []string{},
+		},
+		{
+			name:               "Atomic Import Creating Missing Records",
+			query:              "?create_missing=true",
+			csv:                books,
+			expectedStatus:     fiber.StatusCreated,
+			expectedStatuses:   []importer.RowStatus{importer.StatusImported, importer.StatusImported},
+			expectedPublishers: []string{"Bentang Pustaka"},
+			expectedBooks:      2,
+		},
+		{
+			name:               "Best Effort Import Keeps Valid Rows",
+			query:              "?mode=best_effort",
+			csv:                books + "Sang Pemimpi,9789799625700,292,2006,Gramedia,,\nEdensor,,many,2007,Gramedia,,\nMaryamah Karpov,,504,2008,Gramedia,,\n",
+			expectedStatus:     fiber.StatusCreated,
+			expectedStatuses:   []importer.RowStatus{importer.StatusImported, importer.StatusFailed, importer.StatusFailed, importer.StatusFailed, importer.StatusImported},
+			expectedErrors:     map[int][]string{3: {"publisher", "authors"}, 4: {"isbn13"}, 5: {"pages"}},
+			expectedPublishers: []string{},
+			expectedBooks:      2,
+		},
+		{
+			name:               "Mapped Columns",
+			query:              "?columns=title:Judul,pages:Halaman,year:Tahun,publisher:Penerbit",
+			csv:                "Judul,Halaman,Tahun,Penerbit\nSang Pemimpi,292,2006,Gramedia\nEdensor,288\n",
+			expectedStatus:     fiber.StatusUnprocessableEntity,
+			expectedStatuses:   []importer.RowStatus{importer.StatusSkipped, importer.StatusFailed},
+			expectedErrors:     map[int][]string{3: {"row"}},
+			expectedPublishers: []string{},
+		},
+		{
+			name:               "MARCXML Records",
+			query:              "?mode=best_effort",
+			contentType:        "application/marcxml+xml",
+			csv:                marcxml,
+			expectedStatus:     fiber.StatusCreated,
+			expectedStatuses:   []importer.RowStatus{importer.StatusImported, importer.StatusFailed},
+			expectedErrors:     map[int][]string{2: {"pages"}},
+			expectedPublishers: []string{},
+			expectedBooks:      1,
+		},
+		{name: "Invalid MARC File", contentType: "application/marc", csv: "00099nam", expectedStatus: fiber.StatusBadRequest},
+		{name: "Missing Column", csv: "title,pages,year\nSang Pemimpi,292,2006\n", expectedStatus: fiber.StatusBadRequest},
+		{name: "No Rows", csv: "title,pages,year,publisher\n", expectedStatus: fiber.StatusBadRequest},
+		{name: "Unknown Mode", query: "?mode=partial", csv: books, expectedStatus: fiber.StatusBadRequest},
+		{name: "Unknown Mapped Column", query: "?columns=judul:Judul", csv: books, expectedStatus: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, repos := setupTestApp(t)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/import/books"+tt.query, strings.NewReader(tt.csv))
+			if tt.contentType == "" {
+				tt.contentType = "text/csv"
+			}
+			req.Header.Set("Content-Type", tt.contentType)
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			if tt.expectedStatus == fiber.StatusBadRequest {
+				return
+			}
+
+			body, _ := io.ReadAll(resp.Body)
+			var report importer.ImportReport
+			assert.NoError(t, json.Unmarshal(body, &report))
+			statuses := []importer.RowStatus{}
+			errors := map[int][]string{}
+			for _, row := range report.Rows {
+				statuses = append(statuses, row.Status)
+				for _, e := range row.Errors {
+					errors[row.Row] = append(errors[row.Row], e.Field)
+				}
+			}
+			assert.Equal(t, tt.expectedStatuses, statuses)
+			if tt.expectedErrors == nil {
+				tt.expectedErrors = map[int][]string{}
+			}
+			assert.Equal(t, tt.expectedErrors, errors)
+			assert.Equal(t, tt.expectedPublishers, report.CreatedPublishers)
+
+			_, total, err := repos.Books.FindAll(context.Background(), pagination.Request{Page: 1, PageSize: 10}, nil, book.BookFilter{})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedBooks, total)
+		})
+	}
+}
+
+func TestImportRecordsCreatedRecords(t *testing.T) {
+	t.Parallel()
+
+	app, repos := setupTestApp(t)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/import/books?create_missing=true", strings.NewReader(books))
+	req.Header.Set("Content-Type", "text/csv")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+	// Bentang Pustaka and Dee Lestari follow the records of setupTestApp
+	ctx := context.Background()
+	var createdPublisher publisher.PublisherDetailResponse
+	assert.NoError(t, repos.History.StateAtVersion(ctx, "publisher", 2, 1, &createdPublisher))
+	assert.Equal(t, "Bentang Pustaka", createdPublisher.Name)
+	var createdAuthor author.AuthorDetailResponse
+	assert.NoError(t, repos.History.StateAtVersion(ctx, "author", 2, 1, &createdAuthor))
+	assert.Equal(t, "Dee Lestari", createdAuthor.Name)
+}
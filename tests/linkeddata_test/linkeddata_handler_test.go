@@ -0,0 +1,141 @@
+package linkeddata_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/linkeddata"
+	"github.com/tedysaputro/book-catalog-with-go/tests/fixtures"
+)
+
+// setupTestApp serves the catalog of fixtures.NewCatalog
+func setupTestApp(t *testing.T) *fiber.App {
+	catalog := fixtures.NewCatalog(t)
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	linkeddata.NewLinkedDataHandler(linkeddata.NewLinkedDataService(catalog.Books, catalog.Authors, catalog.Publishers)).RegisterRoutes(app)
+	book.NewBookHandler(catalog.BookService()).RegisterRoutes(app)
+	return app
+}
+
+func TestLinkedData(t *testing.T) {
+	t.Parallel()
+
+	app := setupTestApp(t)
+
+	tests := []struct {
+		name                string
+		url                 string
+		accept              string
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        map[string]interface{}
+		expectedXML         string
+	}{
+		{
+			name:                "Book As JSON-LD",
+			url:                 "/api/v1/books/1",
+			accept:              "application/ld+json",
+			expectedStatus:      fiber.StatusOK,
+			expectedContentType: linkeddata.JSONLDType,
+			expectedBody: map[string]interface{}{
+				"@context":      "https://schema.org",
+				"@id":           "http://example.com/api/v1/books/1",
+				"@type":         "Book",
+				"name":          "Laskar Pelangi",
+				"isbn":          "9789799625700",
+				"numberOfPages": float64(529),
+				"datePublished": "2005",
+				"genre":         []interface{}{"Fiction"},
+				"author": []interface{}{
+					map[string]interface{}{"@id": "http://example.com/api/v1/authors/1", "@type": "Person", "name": "Andrea Hirata"},
+					map[string]interface{}{"@id": "http://example.com/api/v1/authors/2", "@type": "Person", "name": "Dee Lestari"},
+				},
+				"publisher": map[string]interface{}{
+					"@id": "http://example.com/api/v1/publishers/1", "@type": "Organization", "name": "Bentang Pustaka",
+				},
+			},
+		},
+		{
+			name:                "Book As Dublin Core",
+			url:                 "/api/v1/books/1",
+			accept:              "application/rdf+xml",
+			expectedStatus:      fiber.StatusOK,
+			expectedContentType: "application/rdf+xml; charset=utf-8",
+			expectedXML:         "<dc:creator>Andrea Hirata</dc:creator>",
+		},
+		{
+			name:                "Author As JSON-LD",
+			url:                 "/api/v1/authors/1",
+			accept:              "application/ld+json",
+			expectedStatus:      fiber.StatusOK,
+			expectedContentType: linkeddata.JSONLDType,
+			expectedBody: map[string]interface{}{
+				"@context": "https://schema.org",
+				"@id":      "http://example.com/api/v1/authors/1",
+				"@type":    "Person",
+				"name":     "Andrea Hirata",
+				"@reverse": map[string]interface{}{"author": []interface{}{
+					map[string]interface{}{"@id": "http://example.com/api/v1/books/1", "@type": "Book", "name": "Laskar Pelangi"},
+					map[string]interface{}{"@id": "http://example.com/api/v1/books/2", "@type": "Book", "name": "Sang Pemimpi"},
+				}},
+			},
+		},
+		{
+			name:                "Publisher As JSON-LD",
+			url:                 "/api/v1/publishers/1",
+			accept:              "application/ld+json",
+			expectedStatus:      fiber.StatusOK,
+			expectedContentType: linkeddata.JSONLDType,
+			expectedBody: map[string]interface{}{
+				"@context": "https://schema.org",
+				"@id":      "http://example.com/api/v1/publishers/1",
+				"@type":    "Organization",
+				"name":     "Bentang Pustaka",
+				"@reverse": map[string]interface{}{"publisher": []interface{}{
+					map[string]interface{}{"@id": "http://example.com/api/v1/books/1", "@type": "Book", "name": "Laskar Pelangi"},
+					map[string]interface{}{"@id": "http://example.com/api/v1/books/2", "@type": "Book", "name": "Sang Pemimpi"},
+				}},
+			},
+		},
+		{name: "Book As JSON", url: "/api/v1/books/1", accept: "application/json", expectedStatus: fiber.StatusOK, expectedContentType: fiber.MIMEApplicationJSON},
+		{name: "Book As JSON By Default", url: "/api/v1/books/1", expectedStatus: fiber.StatusOK, expectedContentType: fiber.MIMEApplicationJSON},
+		{name: "Trash Falls Through", url: "/api/v1/books/trash", accept: "application/ld+json", expectedStatus: fiber.StatusOK, expectedContentType: fiber.MIMEApplicationJSON},
+		{name: "Missing Book", url: "/api/v1/books/9", accept: "application/ld+json", expectedStatus: fiber.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.accept != "" {
+				req.Header.Set(fiber.HeaderAccept, tt.accept)
+			}
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			if tt.expectedStatus != fiber.StatusOK {
+				return
+			}
+
+			assert.Equal(t, tt.expectedContentType, resp.Header.Get(fiber.HeaderContentType))
+			assert.Equal(t, fiber.HeaderAccept, resp.Header.Get(fiber.HeaderVary))
+			body, _ := io.ReadAll(resp.Body)
+			if tt.expectedBody != nil {
+				var doc map[string]interface{}
+				assert.NoError(t, json.Unmarshal(body, &doc))
+				delete(doc, "dateModified")
+				assert.Equal(t, tt.expectedBody, doc)
+			}
+			if tt.expectedXML != "" {
+				assert.Contains(t, string(body), tt.expectedXML)
+			}
+		})
+	}
+}
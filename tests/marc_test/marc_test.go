@@ -0,0 +1,159 @@
+package marc_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/author"
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/marc"
+	"github.com/tedysaputro/book-catalog-with-go/src/publisher"
+	"github.com/tedysaputro/book-catalog-with-go/tests/fixtures"
+)
+
+var laskarPelangi = &book.Book{
+	ID:          1,
+	Title:       "Laskar Pelangi",
+	Description: "Sepuluh anak Belitung bersekolah di SD Muhammadiyah.",
+	ISBN10:      "9799625708",
+	ISBN13:      "9789799625700",
+	Pages:       529,
+	Year:        2005,
+	Publisher:   publisher.Publisher{Name: "Bentang Pustaka"},
+	Authors:     []author.Author{{Name: "Andrea Hirata"}, {Name: "Dee Lestari"}},
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range marc.Formats {
+		t.Run(string(format), func(t *testing.T) {
+			var file bytes.Buffer
+			assert.NoError(t, format.Encode(&file, []marc.Record{marc.FromBook(laskarPelangi), marc.FromBook(laskarPelangi)}))
+
+			decode := marc.Decode
+			if format == marc.FormatMARCXML {
+				decode = marc.DecodeXML
+			}
+			records, err := decode(&file)
+			assert.NoError(t, err)
+			assert.Len(t, records, 2)
+
+			b := marc.ToBook(records[1])
+			assert.Equal(t, laskarPelangi.Title, b.Title)
+			assert.Equal(t, laskarPelangi.Description, b.Description)
+			assert.Equal(t, laskarPelangi.ISBN10, b.ISBN10)
+			assert.Equal(t, laskarPelangi.ISBN13, b.ISBN13)
+			assert.Equal(t, laskarPelangi.Pages, b.Pages)
+			assert.Equal(t, laskarPelangi.Year, b.Year)
+			assert.Equal(t, laskarPelangi.Publisher.Name, b.Publisher.Name)
+			assert.Equal(t, laskarPelangi.Authors, b.Authors)
+		})
+	}
+}
+
+func TestToBook(t *testing.T) {
+	t.Parallel()
+
+	record := marc.Record{DataFields: []marc.DataField{
+		{Tag: "700", Ind1: "1", Subfields: []marc.Subfield{{Code: "a", Value: "Lestari, Dee,"}}},
+		{Tag: "100", Ind1: "1", Subfields: []marc.Subfield{{Code: "a", Value: "Hirata, Andrea,"}}},
+		{Tag: "020", Subfields: []marc.Subfield{{Code: "a", Value: "978-979-96257-0-0 (pbk.)"}}},
+		{Tag: "245", Ind1: "1", Subfields: []marc.Subfield{{Code: "a", Value: "Laskar pelangi :"}, {Code: "b", Value: "sebuah novel /"}}},
+		{Tag: "260", Subfields: []marc.Subfield{{Code: "b", Value: "Bentang,"}, {Code: "c", Value: "c2005."}}},
+		{Tag: "300", Subfields: []marc.Subfield{{Code: "a", Value: "xiv, 529 p. ;"}}},
+	}}
+
+	b := marc.ToBook(record)
+	assert.Equal(t, "Laskar pelangi: sebuah novel", b.Title)
+	assert.Equal(t, "9789799625700", b.ISBN13)
+	assert.Equal(t, "Bentang", b.Publisher.Name)
+	assert.Equal(t, uint(2005), b.Year)
+	assert.Equal(t, []author.Author{{Name: "Hirata, Andrea"}, {Name: "Lestari, Dee"}}, b.Authors)
+}
+
+func TestExportBooks(t *testing.T) {
+	t.Parallel()
+
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	marc.NewMARCHandler(marc.NewMARCService(fixtures.NewCatalog(t).Books)).RegisterRoutes(app)
+
+	tests := []struct {
+		name                string
+		url                 string
+		expectedStatus      int
+		expectedContentType string
+		expectedRecords     int
+	}{
+		{name: "Book", url: "/api/v1/books/1/marc", expectedStatus: fiber.StatusOK, expectedContentType: marc.ContentType, expectedRecords: 1},
+		{name: "Book As MARCXML", url: "/api/v1/books/1/marc?format=marcxml", expectedStatus: fiber.StatusOK, expectedContentType: marc.XMLContentType, expectedRecords: 1},
+		{name: "Filtered Books", url: "/api/v1/books/marc?title=pemimpi", expectedStatus: fiber.StatusOK, expectedContentType: marc.ContentType, expectedRecords: 1},
+		{name: "All Books", url: "/api/v1/books/marc?format=marcxml", expectedStatus: fiber.StatusOK, expectedContentType: marc.XMLContentType, expectedRecords: 3},
+		{name: "Missing Book", url: "/api/v1/books/9/marc", expectedStatus: fiber.StatusNotFound},
+		{name: "Unknown Format", url: "/api/v1/books/1/marc?format=unimarc", expectedStatus: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, tt.url, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			if tt.expectedStatus != fiber.StatusOK {
+				return
+			}
+
+			assert.Equal(t, tt.expectedContentType, resp.Header.Get(fiber.HeaderContentType))
+			body, _ := io.ReadAll(resp.Body)
+			decode := marc.Decode
+			if tt.expectedContentType == marc.XMLContentType {
+				decode = marc.DecodeXML
+			}
+			records, err := decode(bytes.NewReader(body))
+			assert.NoError(t, err)
+			assert.Len(t, records, tt.expectedRecords)
+		})
+	}
+}
+
+// malformedRecord returns a 50 byte record whose single directory entry is entry
+func malformedRecord(leader, entry string) []byte {
+	record := []byte(leader + entry)
+	record = append(record, 0x1E)
+	record = append(record, bytes.Repeat([]byte{'a'}, 12)...)
+	return append(record, 0x1D)
+}
+
+func TestDecodeMalformed(t *testing.T) {
+	t.Parallel()
+
+	const leader = "00050nam a2200037 i 4500"
+	tests := []struct {
+		name   string
+		record []byte
+	}{
+		{name: "Negative Field Length", record: malformedRecord(leader, "245-10000000")},
+		{name: "Negative Field Start", record: malformedRecord(leader, "2450010-0001")},
+		{name: "Empty Field", record: malformedRecord(leader, "245000000000")},
+		{name: "Field Past The Record", record: malformedRecord(leader, "245009900000")},
+		{name: "Signed Record Length", record: malformedRecord("-0050nam a2200037 i 4500", "245001000000")},
+		{name: "Signed Base Address", record: malformedRecord("00050nam a22+0037 i 4500", "245001000000")},
+		{name: "Base Address Past The Record", record: malformedRecord("00050nam a2299999 i 4500", "245001000000")},
+		{name: "Truncated Leader", record: []byte("00050nam")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotPanics(t, func() {
+				_, err := marc.Decode(bytes.NewReader(tt.record))
+				assert.ErrorIs(t, err, marc.ErrInvalidRecord)
+			})
+		})
+	}
+}
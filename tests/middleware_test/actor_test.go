@@ -0,0 +1,50 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/history"
+	"github.com/tedysaputro/book-catalog-with-go/src/middleware"
+)
+
+func TestActor(t *testing.T) {
+	tests := []struct {
+		name           string
+		actor          string
+		expectedStatus int
+		expectedActor  string
+	}{
+		{name: "Named Actor", actor: " librarian ", expectedStatus: fiber.StatusOK, expectedActor: "librarian"},
+		{name: "No Actor", expectedStatus: fiber.StatusOK, expectedActor: history.Anonymous},
+		{name: "Longest Actor", actor: strings.Repeat("é", history.MaxActorLength), expectedStatus: fiber.StatusOK, expectedActor: strings.Repeat("é", history.MaxActorLength)},
+		{name: "Actor Too Long", actor: strings.Repeat("a", history.MaxActorLength+1), expectedStatus: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+			app.Use(middleware.Actor())
+			actor := ""
+			app.Post("/books", func(c *fiber.Ctx) error {
+				actor = history.ActorFrom(c.UserContext())
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/books", nil)
+			if tt.actor != "" {
+				req.Header.Set(middleware.ActorHeader, tt.actor)
+			}
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tt.expectedActor, actor)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/middleware"
+)
+
+func TestAdmin(t *testing.T) {
+	tests := []struct {
+		name           string
+		token          string
+		authorization  string
+		expectedStatus int
+	}{
+		{name: "Valid Token", token: "s3cret", authorization: "Bearer s3cret", expectedStatus: fiber.StatusOK},
+		{name: "Missing Token", token: "s3cret", expectedStatus: fiber.StatusUnauthorized},
+		{name: "Other Scheme", token: "s3cret", authorization: "Basic s3cret", expectedStatus: fiber.StatusUnauthorized},
+		{name: "Wrong Token", token: "s3cret", authorization: "Bearer guess", expectedStatus: fiber.StatusForbidden},
+		{name: "Admin Disabled", authorization: "Bearer ", expectedStatus: fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Delete("/purge", middleware.Admin(tt.token), func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodDelete, "/purge", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/middleware"
+)
+
+func TestParseRouteTimeouts(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected map[string]time.Duration
+		wantErr  bool
+	}{
+		{name: "Empty", raw: "", expected: map[string]time.Duration{}},
+		{
+			name:     "Several Routes",
+			raw:      "/api/v1/search=10s, /api/v1/books/=250ms",
+			expected: map[string]time.Duration{"/api/v1/search": 10 * time.Second, "/api/v1/books": 250 * time.Millisecond},
+		},
+		{name: "Missing Duration", raw: "/api/v1/search", wantErr: true},
+		{name: "Invalid Duration", raw: "/api/v1/search=ten", wantErr: true},
+		{name: "Relative Prefix", raw: "api=1s", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			routes, err := middleware.ParseRouteTimeouts(tt.raw)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, routes)
+		})
+	}
+}
+
+func TestTimeoutConfigFor(t *testing.T) {
+	config := middleware.TimeoutConfig{
+		Default: 5 * time.Second,
+		Routes: map[string]time.Duration{
+			"/api/v1/books":      3 * time.Second,
+			"/api/v1/books/isbn": time.Second,
+		},
+	}
+
+	assert.Equal(t, 5*time.Second, config.For("/api/v1/authors"))
+	assert.Equal(t, 3*time.Second, config.For("/api/v1/books"))
+	assert.Equal(t, 3*time.Second, config.For("/api/v1/books/1"))
+	assert.Equal(t, time.Second, config.For("/api/v1/books/isbn/9789799625700"))
+	assert.Equal(t, 5*time.Second, config.For("/api/v1/bookshelf"))
+}
+
+func TestTimeout(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Use(middleware.Timeout(middleware.TimeoutConfig{
+		Default: time.Second,
+		Routes:  map[string]time.Duration{"/slow": 20 * time.Millisecond},
+	}))
+	// Waits on the request context like a database query would
+	app.Get("/slow", func(c *fiber.Ctx) error {
+		<-c.UserContext().Done()
+		return c.UserContext().Err()
+	})
+	app.Get("/fast", func(c *fiber.Ctx) error {
+		_, hasDeadline := c.UserContext().Deadline()
+		return c.JSON(fiber.Map{"deadline": hasDeadline})
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/slow", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusGatewayTimeout, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var problem apperror.Problem
+	assert.NoError(t, json.Unmarshal(body, &problem))
+	assert.Equal(t, fiber.StatusGatewayTimeout, problem.Status)
+	assert.Equal(t, "request timed out after 20ms", problem.Detail)
+	assert.Equal(t, "/slow", problem.Instance)
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/fast", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	body, _ = io.ReadAll(resp.Body)
+	assert.JSONEq(t, `{"deadline":true}`, string(body))
+}
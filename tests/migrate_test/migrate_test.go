@@ -0,0 +1,65 @@
+package migrate_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/migrate"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	migrations, err := migrate.Load(os.DirFS("../../src/migrate/migrations"))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, migrations)
+
+	for i, m := range migrations {
+		assert.Equal(t, int64(i+1), m.Version, "migrations must be numbered without gaps")
+		assert.NotEmpty(t, m.Up)
+		assert.NotEmpty(t, m.Down)
+	}
+}
+
+func TestCreateMigration(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name          string
+		migration     string
+		expectedFiles []string
+		expectedError bool
+	}{
+		{
+			name:          "First Migration",
+			migration:     "Create Books",
+			expectedFiles: []string{"000001_create_books.up.sql", "000001_create_books.down.sql"},
+		},
+		{
+			name:          "Next Migration",
+			migration:     "add-book-isbn",
+			expectedFiles: []string{"000002_add_book_isbn.up.sql", "000002_add_book_isbn.down.sql"},
+		},
+		{
+			name:          "Empty Name",
+			migration:     "--",
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths, err := migrate.Create(dir, tt.migration)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			for i, file := range tt.expectedFiles {
+				assert.Equal(t, filepath.Join(dir, file), paths[i])
+				assert.FileExists(t, paths[i])
+			}
+		})
+	}
+}
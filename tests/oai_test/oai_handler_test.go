@@ -0,0 +1,220 @@
+package oai_test
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/category"
+	"github.com/tedysaputro/book-catalog-with-go/src/oai"
+	"github.com/tedysaputro/book-catalog-with-go/tests/fixtures"
+)
+
+// header is the header of a record in a response
+type header struct {
+	Status     string `xml:"status,attr"`
+	Identifier string `xml:"identifier"`
+}
+
+// resumptionToken is the resumption token of a list in a response
+type resumptionToken struct {
+	Value            string `xml:",chardata"`
+	CompleteListSize uint64 `xml:"completeListSize,attr"`
+	Cursor           uint64 `xml:"cursor,attr"`
+}
+
+// response is the part of an OAI-PMH response the tests look at
+type response struct {
+	Request struct {
+		Verb string `xml:"verb,attr"`
+	} `xml:"request"`
+	Error struct {
+		Code string `xml:"code,attr"`
+	} `xml:"error"`
+	ListIdentifiers []header         `xml:"ListIdentifiers>header"`
+	ListRecords     []header         `xml:"ListRecords>record>header"`
+	GetRecord       []header         `xml:"GetRecord>record>header"`
+	IdentifiersNext *resumptionToken `xml:"ListIdentifiers>resumptionToken"`
+}
+
+// headers returns the headers of the records in the response
+func (r response) headers() []header {
+	return append(append(append([]header{}, r.ListIdentifiers...), r.ListRecords...), r.GetRecord...)
+}
+
+// setupTestApp serves the books of fixtures.NewCatalog, the second deleted, and extra books of the
+// second publisher
+func setupTestApp(t *testing.T, extra int) (*fiber.App, book.BookRepository) {
+	catalog := fixtures.NewCatalog(t)
+	ctx := context.Background()
+	assert.NoError(t, catalog.Categories.Create(ctx, &category.Category{Code: "SCI", Name: "Science"}))
+	for i := 0; i < extra; i++ {
+		assert.NoError(t, catalog.Books.Create(ctx, &book.Book{Title: fmt.Sprintf("Book %d", i+1), Pages: 100, Year: 2020, PublisherID: 2}))
+	}
+	deleted, err := catalog.Books.FindByID(ctx, 2)
+	assert.NoError(t, err)
+	assert.NoError(t, catalog.Books.SoftDelete(ctx, deleted))
+
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	oai.NewOAIHandler(oai.NewOAIService(catalog.Books, catalog.Categories, catalog.Publishers)).RegisterRoutes(app)
+	return app, catalog.Books
+}
+
+// request sends an OAI-PMH request and decodes its response
+func request(t *testing.T, app *fiber.App, method, query string) (response, string) {
+	req := httptest.NewRequest(method, "/oai?"+query, nil)
+	if method == http.MethodPost {
+		req = httptest.NewRequest(method, "/oai", strings.NewReader(query))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationForm)
+	}
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, oai.ContentType, resp.Header.Get(fiber.HeaderContentType))
+
+	body, _ := io.ReadAll(resp.Body)
+	var out response
+	assert.NoError(t, xml.Unmarshal(body, &out))
+	return out, string(body)
+}
+
+func TestOAI(t *testing.T) {
+	t.Parallel()
+
+	app, _ := setupTestApp(t, 0)
+	today := time.Now().UTC().Format("2006-01-02")
+	tomorrow := time.Now().UTC().Add(24 * time.Hour).Format("2006-01-02")
+	yesterday := time.Now().UTC().Add(-24 * time.Hour).Format("2006-01-02")
+
+	tests := []struct {
+		name                string
+		method              string
+		query               string
+		expectedError       string
+		expectedIdentifiers []string
+		expectedDeleted     []string
+		expectedContains    []string
+	}{
+		{name: "Identify", query: "verb=Identify", expectedContains: []string{"<baseURL>http://example.com/oai</baseURL>", "<deletedRecord>transient</deletedRecord>", "<granularity>YYYY-MM-DDThh:mm:ssZ</granularity>"}},
+		{name: "Missing Verb", query: "", expectedError: oai.CodeBadVerb},
+		{name: "Unknown Verb", query: "verb=ListBooks", expectedError: oai.CodeBadVerb},
+		{name: "Repeated Argument", query: "verb=ListRecords&metadataPrefix=oai_dc&metadataPrefix=marc21", expectedError: oai.CodeBadArgument},
+		{name: "Unknown Argument", query: "verb=Identify&identifier=oai:book-catalog.local:book:1", expectedError: oai.CodeBadArgument},
+		{name: "Missing Metadata Prefix", query: "verb=ListRecords", expectedError: oai.CodeBadArgument},
+		{name: "Unknown Metadata Prefix", query: "verb=ListRecords&metadataPrefix=mods", expectedError: oai.CodeCannotDisseminateFormat},
+		{name: "List Metadata Formats", query: "verb=ListMetadataFormats&identifier=oai:book-catalog.local:book:1", expectedContains: []string{"<metadataPrefix>oai_dc</metadataPrefix>", "<metadataPrefix>marc21</metadataPrefix>"}},
+		{name: "List Metadata Formats Of Unknown Record", query: "verb=ListMetadataFormats&identifier=oai:book-catalog.local:book:99", expectedError: oai.CodeIDDoesNotExist},
+		{name: "List Sets", query: "verb=ListSets", expectedContains: []string{"<setSpec>category:FIC</setSpec>", "<setSpec>publisher:2</setSpec>"}},
+		{name: "List Identifiers", query: "verb=ListIdentifiers&metadataPrefix=oai_dc", expectedIdentifiers: []string{"oai:book-catalog.local:book:1", "oai:book-catalog.local:book:2", "oai:book-catalog.local:book:3"}, expectedDeleted: []string{"oai:book-catalog.local:book:2"}},
+		{name: "List Records", method: http.MethodPost, query: "verb=ListRecords&metadataPrefix=oai_dc&set=category:FIC", expectedIdentifiers: []string{"oai:book-catalog.local:book:1", "oai:book-catalog.local:book:3"}, expectedContains: []string{"<dc:title>Laskar Pelangi</dc:title>", "<dc:identifier>urn:isbn:9789799625700</dc:identifier>"}},
+		{name: "List MARC Records", query: "verb=ListRecords&metadataPrefix=marc21&set=publisher:2", expectedIdentifiers: []string{"oai:book-catalog.local:book:3"}, expectedContains: []string{`<record xmlns="http://www.loc.gov/MARC21/slim">`, `<subfield code="a">Supernova</subfield>`}},
+		{name: "Parent Set", query: "verb=ListIdentifiers&metadataPrefix=oai_dc&set=category", expectedIdentifiers: []string{"oai:book-catalog.local:book:1", "oai:book-catalog.local:book:3"}},
+		{name: "Empty Set", query: "verb=ListIdentifiers&metadataPrefix=oai_dc&set=category:SCI", expectedError: oai.CodeNoRecordsMatch},
+		{name: "Unknown Set", query: "verb=ListIdentifiers&metadataPrefix=oai_dc&set=shelf:1", expectedError: oai.CodeNoRecordsMatch},
+		{name: "Changed Today", query: "verb=ListIdentifiers&metadataPrefix=oai_dc&from=" + today + "&until=" + today, expectedIdentifiers: []string{"oai:book-catalog.local:book:1", "oai:book-catalog.local:book:2", "oai:book-catalog.local:book:3"}, expectedDeleted: []string{"oai:book-catalog.local:book:2"}},
+		{name: "Changed From Tomorrow", query: "verb=ListIdentifiers&metadataPrefix=oai_dc&from=" + tomorrow, expectedError: oai.CodeNoRecordsMatch},
+		{name: "Changed Until Yesterday", query: "verb=ListIdentifiers&metadataPrefix=oai_dc&until=" + yesterday, expectedError: oai.CodeNoRecordsMatch},
+		{name: "Mixed Granularities", query: "verb=ListIdentifiers&metadataPrefix=oai_dc&from=" + yesterday + "&until=" + today + "T23:59:59Z", expectedError: oai.CodeBadArgument},
+		{name: "Invalid Datestamp", query: "verb=ListIdentifiers&metadataPrefix=oai_dc&from=yesterday", expectedError: oai.CodeBadArgument},
+		{name: "Invalid Resumption Token", query: "verb=ListIdentifiers&resumptionToken=abc", expectedError: oai.CodeBadResumptionToken},
+		{name: "Get Record", query: "verb=GetRecord&metadataPrefix=oai_dc&identifier=oai:book-catalog.local:book:3", expectedIdentifiers: []string{"oai:book-catalog.local:book:3"}, expectedContains: []string{"<dc:publisher>Gramedia</dc:publisher>"}},
+		{name: "Get Deleted Record", query: "verb=GetRecord&metadataPrefix=marc21&identifier=oai:book-catalog.local:book:2", expectedIdentifiers: []string{"oai:book-catalog.local:book:2"}, expectedDeleted: []string{"oai:book-catalog.local:book:2"}},
+		{name: "Get Unknown Record", query: "verb=GetRecord&metadataPrefix=oai_dc&identifier=oai:example.org:book:1", expectedError: oai.CodeIDDoesNotExist},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			out, body := request(t, app, method, tt.query)
+			assert.Equal(t, tt.expectedError, out.Error.Code)
+			if tt.expectedError == oai.CodeBadVerb || tt.expectedError == oai.CodeBadArgument {
+				assert.Empty(t, out.Request.Verb)
+			}
+
+			identifiers, deleted := []string{}, []string{}
+			for _, h := range out.headers() {
+				identifiers = append(identifiers, h.Identifier)
+				if h.Status == "deleted" {
+					deleted = append(deleted, h.Identifier)
+				}
+			}
+			if tt.expectedIdentifiers != nil {
+				assert.Equal(t, tt.expectedIdentifiers, identifiers)
+			}
+			if tt.expectedDeleted != nil {
+				assert.Equal(t, tt.expectedDeleted, deleted)
+			}
+			for _, expected := range tt.expectedContains {
+				assert.Contains(t, body, expected)
+			}
+		})
+	}
+}
+
+func TestResumptionToken(t *testing.T) {
+	t.Parallel()
+
+	app, _ := setupTestApp(t, 150)
+
+	first, _ := request(t, app, http.MethodGet, "verb=ListIdentifiers&metadataPrefix=marc21&set=publisher:2")
+	assert.Len(t, first.ListIdentifiers, oai.PageSize)
+	if assert.NotNil(t, first.IdentifiersNext) {
+		assert.NotEmpty(t, first.IdentifiersNext.Value)
+		assert.Equal(t, uint64(151), first.IdentifiersNext.CompleteListSize)
+		assert.Equal(t, uint64(0), first.IdentifiersNext.Cursor)
+	}
+
+	last, _ := request(t, app, http.MethodGet, "verb=ListIdentifiers&resumptionToken="+url.QueryEscape(first.IdentifiersNext.Value))
+	assert.Len(t, last.ListIdentifiers, 51)
+	assert.Equal(t, "oai:book-catalog.local:book:153", last.ListIdentifiers[50].Identifier)
+	if assert.NotNil(t, last.IdentifiersNext) {
+		assert.Empty(t, last.IdentifiersNext.Value)
+		assert.Equal(t, uint64(151), last.IdentifiersNext.CompleteListSize)
+		assert.Equal(t, uint64(oai.PageSize), last.IdentifiersNext.Cursor)
+	}
+
+	combined, _ := request(t, app, http.MethodGet, "verb=ListIdentifiers&metadataPrefix=oai_dc&resumptionToken="+url.QueryEscape(first.IdentifiersNext.Value))
+	assert.Equal(t, oai.CodeBadArgument, combined.Error.Code)
+}
+
+func TestResumptionTokenAfterChange(t *testing.T) {
+	t.Parallel()
+
+	app, books := setupTestApp(t, 150)
+	ctx := context.Background()
+
+	first, _ := request(t, app, http.MethodGet, "verb=ListIdentifiers&metadataPrefix=marc21&set=publisher:2")
+	assert.Len(t, first.ListIdentifiers, oai.PageSize)
+	assert.Equal(t, "oai:book-catalog.local:book:102", first.ListIdentifiers[oai.PageSize-1].Identifier)
+
+	// A book of the first page is purged and a new one added before the harvest resumes
+	purged, err := books.FindByID(ctx, 3)
+	assert.NoError(t, err)
+	assert.NoError(t, books.Purge(ctx, purged))
+	assert.NoError(t, books.Create(ctx, &book.Book{Title: "Supernova", Pages: 320, Year: 2001, PublisherID: 2}))
+
+	last, _ := request(t, app, http.MethodGet, "verb=ListIdentifiers&resumptionToken="+url.QueryEscape(first.IdentifiersNext.Value))
+	if assert.Len(t, last.ListIdentifiers, 52) {
+		assert.Equal(t, "oai:book-catalog.local:book:103", last.ListIdentifiers[0].Identifier)
+		assert.Equal(t, "oai:book-catalog.local:book:154", last.ListIdentifiers[51].Identifier)
+	}
+	if assert.NotNil(t, last.IdentifiersNext) {
+		assert.Empty(t, last.IdentifiersNext.Value)
+		assert.Equal(t, uint64(oai.PageSize), last.IdentifiersNext.Cursor)
+	}
+}
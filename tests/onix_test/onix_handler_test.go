@@ -0,0 +1,94 @@
+package onix_test
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/author"
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/onix"
+	"github.com/tedysaputro/book-catalog-with-go/src/version"
+	"github.com/tedysaputro/book-catalog-with-go/tests/fixtures"
+)
+
+// feed is the part of an ONIX message the tests look at
+type feed struct {
+	XMLName  xml.Name `xml:"http://ns.editeur.org/onix/3.0/reference ONIXMessage"`
+	Release  string   `xml:"release,attr"`
+	Products []struct {
+		RecordReference  string `xml:"RecordReference"`
+		NotificationType string `xml:"NotificationType"`
+		TitleText        string `xml:"DescriptiveDetail>TitleDetail>TitleElement>TitleText"`
+	} `xml:"Product"`
+}
+
+func TestGetPublisherFeed(t *testing.T) {
+	t.Parallel()
+
+	catalog := fixtures.NewCatalog(t)
+	ctx := context.Background()
+	edensor := &book.Book{Title: "Edensor", Pages: 288, Year: 2007, PublisherID: 1}
+	assert.NoError(t, catalog.Books.Create(ctx, edensor))
+	since := time.Now()
+	sangPemimpi, err := catalog.Books.FindByID(ctx, 2)
+	assert.NoError(t, err)
+	assert.NoError(t, catalog.Books.SoftDelete(ctx, sangPemimpi))
+
+	// Renaming the author changes Laskar Pelangi, moving Edensor to Gramedia withdraws it from the feed
+	_, err = catalog.AuthorService().UpdateAuthor(ctx, 1, author.AuthorRequest{Name: "A. Hirata"}, version.Precondition{Any: true})
+	assert.NoError(t, err)
+	_, err = catalog.BookService().UpdateBook(ctx, edensor.ID, book.BookRequest{Title: "Edensor", Pages: 288, Year: 2007, PublisherID: 2}, version.Precondition{Any: true})
+	assert.NoError(t, err)
+
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	onix.NewONIXHandler(onix.NewONIXService(catalog.Publishers, catalog.Books, catalog.History)).RegisterRoutes(app)
+
+	tests := []struct {
+		name                  string
+		query                 string
+		id                    string
+		expectedStatus        int
+		expectedNotifications []string
+		expectedTitles        []string
+	}{
+		{name: "Full Feed", id: "1", expectedStatus: fiber.StatusOK, expectedNotifications: []string{"03"}, expectedTitles: []string{"Laskar Pelangi"}},
+		{name: "Delta Feed With Deletes", id: "1", query: "?since=" + url.QueryEscape(since.Format(time.RFC3339Nano)), expectedStatus: fiber.StatusOK, expectedNotifications: []string{"03", "05", "05"}, expectedTitles: []string{"Laskar Pelangi", "", ""}},
+		{name: "Delta Feed Of The New Publisher", id: "2", query: "?since=" + url.QueryEscape(since.Format(time.RFC3339Nano)), expectedStatus: fiber.StatusOK, expectedNotifications: []string{"03"}, expectedTitles: []string{"Edensor"}},
+		{name: "Empty Delta Feed", id: "1", query: "?since=" + url.QueryEscape(time.Now().Add(time.Hour).Format(time.RFC3339)), expectedStatus: fiber.StatusOK, expectedNotifications: []string{}, expectedTitles: []string{}},
+		{name: "Invalid Since", id: "1", query: "?since=yesterday", expectedStatus: fiber.StatusBadRequest},
+		{name: "Missing Publisher", id: "9", expectedStatus: fiber.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/publishers/"+tt.id+"/onix"+tt.query, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			if tt.expectedStatus != fiber.StatusOK {
+				return
+			}
+
+			body, _ := io.ReadAll(resp.Body)
+			var message feed
+			assert.NoError(t, xml.Unmarshal(body, &message))
+			assert.Equal(t, onix.Release, message.Release)
+			notifications, titles := []string{}, []string{}
+			for _, product := range message.Products {
+				notifications = append(notifications, product.NotificationType)
+				titles = append(titles, product.TitleText)
+			}
+			assert.Equal(t, tt.expectedNotifications, notifications)
+			assert.Equal(t, tt.expectedTitles, titles)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package opds_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/opds"
+	"github.com/tedysaputro/book-catalog-with-go/tests/fixtures"
+)
+
+// atomFeed is the part of an OPDS 1.2 feed the tests look at
+type atomFeed struct {
+	Links []struct {
+		Rel  string `xml:"rel,attr"`
+		Href string `xml:"href,attr"`
+	} `xml:"link"`
+	Entries []struct {
+		Title string `xml:"title"`
+	} `xml:"entry"`
+}
+
+// opds2Feed is the part of an OPDS 2.0 feed the tests look at
+type opds2Feed struct {
+	Links []struct {
+		Rel  string `json:"rel"`
+		Href string `json:"href"`
+	} `json:"links"`
+	Navigation []struct {
+		Title string `json:"title"`
+	} `json:"navigation"`
+	Publications []struct {
+		Metadata struct {
+			Title string `json:"title"`
+		} `json:"metadata"`
+	} `json:"publications"`
+}
+
+func setupTestApp(t *testing.T) *fiber.App {
+	catalog := fixtures.NewCatalog(t)
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	opds.NewOPDSHandler(opds.NewOPDSService(catalog.Books, catalog.Authors, catalog.Publishers, catalog.Categories)).RegisterRoutes(app)
+	return app
+}
+
+func TestOPDS(t *testing.T) {
+	t.Parallel()
+
+	app := setupTestApp(t)
+
+	tests := []struct {
+		name                string
+		url                 string
+		expectedStatus      int
+		expectedContentType string
+		expectedTitles      []string
+		expectedNext        string
+	}{
+		{name: "Root", url: "/opds", expectedStatus: fiber.StatusOK, expectedContentType: opds.NavigationType, expectedTitles: []string{"New books", "By category", "By author", "By publisher"}},
+		{name: "Root V2", url: "/opds/v2", expectedStatus: fiber.StatusOK, expectedContentType: opds.OPDS2Type, expectedTitles: []string{"New books", "By category", "By author", "By publisher"}},
+		{name: "Newest", url: "/opds/new?page_size=2", expectedStatus: fiber.StatusOK, expectedContentType: opds.AcquisitionType, expectedTitles: []string{"Supernova", "Sang Pemimpi"}, expectedNext: "http://example.com/opds/new?page=2&page_size=2"},
+		{name: "Newest V2", url: "/opds/v2/new?page_size=2", expectedStatus: fiber.StatusOK, expectedContentType: opds.OPDS2Type, expectedTitles: []string{"Supernova", "Sang Pemimpi"}, expectedNext: "http://example.com/opds/v2/new?page=2&page_size=2"},
+		{name: "Categories", url: "/opds/categories", expectedStatus: fiber.StatusOK, expectedContentType: opds.NavigationType, expectedTitles: []string{"Fiction"}},
+		{name: "Category Books", url: "/opds/v2/categories/FIC", expectedStatus: fiber.StatusOK, expectedContentType: opds.OPDS2Type, expectedTitles: []string{"Laskar Pelangi", "Supernova"}},
+		{name: "Author Books", url: "/opds/authors/1", expectedStatus: fiber.StatusOK, expectedContentType: opds.AcquisitionType, expectedTitles: []string{"Laskar Pelangi", "Sang Pemimpi"}},
+		{name: "Publishers", url: "/opds/v2/publishers", expectedStatus: fiber.StatusOK, expectedContentType: opds.OPDS2Type, expectedTitles: []string{"Bentang Pustaka", "Gramedia"}},
+		{name: "Publisher Books", url: "/opds/publishers/2", expectedStatus: fiber.StatusOK, expectedContentType: opds.AcquisitionType, expectedTitles: []string{"Supernova"}},
+		{name: "Search", url: "/opds/search?q=pelangi", expectedStatus: fiber.StatusOK, expectedContentType: opds.AcquisitionType, expectedTitles: []string{"Laskar Pelangi"}},
+		{name: "Search Without Terms", url: "/opds/search", expectedStatus: fiber.StatusBadRequest},
+		{name: "Missing Category", url: "/opds/categories/SCI", expectedStatus: fiber.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, tt.url, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			if tt.expectedStatus != fiber.StatusOK {
+				return
+			}
+			assert.Equal(t, tt.expectedContentType, resp.Header.Get(fiber.HeaderContentType))
+
+			body, _ := io.ReadAll(resp.Body)
+			titles := []string{}
+			links := map[string]string{}
+			if tt.expectedContentType == opds.OPDS2Type {
+				var feed opds2Feed
+				assert.NoError(t, json.Unmarshal(body, &feed))
+				for _, entry := range feed.Navigation {
+					titles = append(titles, entry.Title)
+				}
+				for _, publication := range feed.Publications {
+					titles = append(titles, publication.Metadata.Title)
+				}
+				for _, link := range feed.Links {
+					links[link.Rel] = link.Href
+				}
+			} else {
+				var feed atomFeed
+				assert.NoError(t, xml.Unmarshal(body, &feed))
+				for _, entry := range feed.Entries {
+					titles = append(titles, entry.Title)
+				}
+				for _, link := range feed.Links {
+					links[link.Rel] = link.Href
+				}
+			}
+			assert.Equal(t, tt.expectedTitles, titles)
+			assert.Equal(t, tt.expectedNext, links["next"])
+		})
+	}
+}
+
+func TestOpenSearch(t *testing.T) {
+	t.Parallel()
+
+	app := setupTestApp(t)
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/opds/opensearch.xml", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, opds.OpenSearchType, resp.Header.Get(fiber.HeaderContentType))
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), `template="http://example.com/opds/search?q={searchTerms}&amp;page={startPage?}"`)
+}
@@ -0,0 +1,119 @@
+package pagination_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+)
+
+func setupTestApp() *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Get("/items", func(c *fiber.Ctx) error {
+		page, err := pagination.FromQuery(c, nil)
+		if err != nil {
+			return err
+		}
+		items := []int{}
+		for i := page.Offset(); i < min(page.Offset()+int(page.PageSize), 25); i++ {
+			items = append(items, i+1)
+		}
+		return c.JSON(pagination.New(items, page, 25).WithLinks(c))
+	})
+	return app
+}
+
+func TestPage(t *testing.T) {
+	app := setupTestApp()
+
+	tests := []struct {
+		name               string
+		path               string
+		expectedStatus     int
+		expectedPage       uint
+		expectedPageSize   uint
+		expectedItems      int
+		expectedNext       string
+		expectedPrev       string
+		expectedDeprecated bool
+	}{
+		{
+			name:             "Defaults",
+			path:             "/items",
+			expectedStatus:   fiber.StatusOK,
+			expectedPage:     1,
+			expectedPageSize: pagination.DefaultPageSize,
+			expectedItems:    10,
+			expectedNext:     "/items?page=2&page_size=10",
+		},
+		{
+			name:             "Last Page Keeps Other Parameters",
+			path:             "/items?page=3&page_size=10&sort=-id",
+			expectedStatus:   fiber.StatusOK,
+			expectedPage:     3,
+			expectedPageSize: 10,
+			expectedItems:    5,
+			expectedPrev:     "/items?page=2&page_size=10&sort=-id",
+		},
+		{
+			name:               "Deprecated Parameters",
+			path:               "/items?p=2&limit=5",
+			expectedStatus:     fiber.StatusOK,
+			expectedPage:       2,
+			expectedPageSize:   5,
+			expectedItems:      5,
+			expectedNext:       "/items?page=3&page_size=5",
+			expectedPrev:       "/items?page=1&page_size=5",
+			expectedDeprecated: true,
+		},
+		{
+			name:             "Page Size Capped",
+			path:             "/items?page_size=1000",
+			expectedStatus:   fiber.StatusOK,
+			expectedPage:     1,
+			expectedPageSize: pagination.MaxPageSize,
+			expectedItems:    25,
+		},
+		{name: "Zero Page", path: "/items?page=0", expectedStatus: fiber.StatusBadRequest},
+		{name: "Negative Page Size", path: "/items?page_size=-5", expectedStatus: fiber.StatusBadRequest},
+		{name: "Invalid Page", path: "/items?pages=first", expectedStatus: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, tt.path, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			if tt.expectedStatus != fiber.StatusOK {
+				return
+			}
+
+			body, _ := io.ReadAll(resp.Body)
+			var page pagination.Page[int]
+			assert.NoError(t, json.Unmarshal(body, &page))
+			assert.Equal(t, tt.expectedPage, page.Page)
+			assert.Equal(t, tt.expectedPageSize, page.PageSize)
+			assert.Len(t, page.Data, tt.expectedItems)
+			assert.Equal(t, uint64(25), page.Total)
+			assert.Equal(t, tt.expectedNext, page.Links.Next)
+			assert.Equal(t, tt.expectedPrev, page.Links.Prev)
+			assert.Equal(t, tt.expectedDeprecated, resp.Header.Get("Deprecation") == "true")
+		})
+	}
+}
+
+func TestNewTotalPages(t *testing.T) {
+	request := pagination.Request{Page: 1, PageSize: 10}
+
+	assert.Equal(t, uint64(0), pagination.New([]int(nil), request, 0).TotalPages)
+	assert.Equal(t, uint64(1), pagination.New([]int{}, request, 10).TotalPages)
+	assert.Equal(t, uint64(2), pagination.New([]int{}, request, 11).TotalPages)
+	assert.NotNil(t, pagination.New([]int(nil), request, 0).Data)
+}
@@ -0,0 +1,141 @@
+package patch_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/patch"
+)
+
+type document struct {
+	Title     string   `json:"title"`
+	Pages     uint     `json:"pages"`
+	AuthorIDs []uint   `json:"author_ids"`
+	Tags      []string `json:"tags"`
+}
+
+func setupTestApp() *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Patch("/document", func(c *fiber.Ctx) error {
+		p, err := patch.FromRequest(c)
+		if err != nil {
+			return err
+		}
+		doc := document{Title: "Laskar Pelangi", Pages: 529, AuthorIDs: []uint{1, 2}, Tags: []string{"novel"}}
+		if err := p.Apply(&doc); err != nil {
+			return err
+		}
+		return c.JSON(doc)
+	})
+	return app
+}
+
+func TestApply(t *testing.T) {
+	app := setupTestApp()
+
+	tests := []struct {
+		name           string
+		contentType    string
+		body           string
+		expectedStatus int
+		expected       document
+	}{
+		{
+			name:           "Merge Patch Keeps Omitted Members",
+			contentType:    patch.MergePatchContentType,
+			body:           `{"title": "Sang Pemimpi"}`,
+			expectedStatus: fiber.StatusOK,
+			expected:       document{Title: "Sang Pemimpi", Pages: 529, AuthorIDs: []uint{1, 2}, Tags: []string{"novel"}},
+		},
+		{
+			name:           "Merge Patch Null Removes",
+			contentType:    patch.MergePatchContentType + "; charset=utf-8",
+			body:           `{"tags": null, "author_ids": [3]}`,
+			expectedStatus: fiber.StatusOK,
+			expected:       document{Title: "Laskar Pelangi", Pages: 529, AuthorIDs: []uint{3}},
+		},
+		{
+			name:           "JSON Patch Adds And Removes IDs",
+			contentType:    patch.JSONPatchContentType,
+			body:           `[{"op": "add", "path": "/author_ids/-", "value": 7}, {"op": "remove", "path": "/author_ids/0"}]`,
+			expectedStatus: fiber.StatusOK,
+			expected:       document{Title: "Laskar Pelangi", Pages: 529, AuthorIDs: []uint{2, 7}, Tags: []string{"novel"}},
+		},
+		{
+			name:        "JSON Patch Replace Move Copy Test",
+			contentType: patch.JSONPatchContentType,
+			body: `[
+				{"op": "test", "path": "/pages", "value": 529.0},
+				{"op": "replace", "path": "/pages", "value": 534},
+				{"op": "copy", "from": "/title", "path": "/tags/0"},
+				{"op": "move", "from": "/tags/1", "path": "/tags/-"}
+			]`,
+			expectedStatus: fiber.StatusOK,
+			expected:       document{Title: "Laskar Pelangi", Pages: 534, AuthorIDs: []uint{1, 2}, Tags: []string{"Laskar Pelangi", "novel"}},
+		},
+		{
+			name:           "Failed Test",
+			contentType:    patch.JSONPatchContentType,
+			body:           `[{"op": "test", "path": "/title", "value": "Edensor"}, {"op": "replace", "path": "/title", "value": "Maryamah Karpov"}]`,
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name:           "Missing Path",
+			contentType:    patch.JSONPatchContentType,
+			body:           `[{"op": "remove", "path": "/author_ids/5"}]`,
+			expectedStatus: fiber.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Unknown Member",
+			contentType:    patch.MergePatchContentType,
+			body:           `{"isbn": "9789799625700"}`,
+			expectedStatus: fiber.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Wrong Type",
+			contentType:    patch.MergePatchContentType,
+			body:           `{"pages": "many"}`,
+			expectedStatus: fiber.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Unknown Op",
+			contentType:    patch.JSONPatchContentType,
+			body:           `[{"op": "merge", "path": "/title"}]`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "Plain JSON",
+			contentType:    fiber.MIMEApplicationJSON,
+			body:           `{"title": "Sang Pemimpi"}`,
+			expectedStatus: fiber.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, "/document", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.expectedStatus == fiber.StatusOK {
+				body, _ := io.ReadAll(resp.Body)
+				var doc document
+				assert.NoError(t, json.Unmarshal(body, &doc))
+				assert.Equal(t, tt.expected, doc)
+			}
+			if tt.expectedStatus == fiber.StatusUnsupportedMediaType {
+				assert.Equal(t, patch.AcceptPatch, resp.Header.Get("Accept-Patch"))
+			}
+		})
+	}
+}
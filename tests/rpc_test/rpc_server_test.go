@@ -0,0 +1,278 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/history"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/rpc"
+	"github.com/tedysaputro/book-catalog-with-go/src/rpc/catalogv1"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+	"github.com/tedysaputro/book-catalog-with-go/tests/fixtures"
+)
+
+// setupTestServer serves the catalog of fixtures.NewCatalog over an in-memory connection
+func setupTestServer(t *testing.T) (*grpc.ClientConn, history.HistoryService) {
+	catalog := fixtures.NewCatalog(t)
+	server := rpc.NewServer(rpc.Config{}, catalog.BookService(), catalog.AuthorService(), catalog.PublisherService(), catalog.CategoryService())
+	listener := bufconn.Listen(1 << 20)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn, catalog.Changes
+}
+
+// titles returns the titles of books
+func titles(books []*catalogv1.Book) []string {
+	result := []string{}
+	for _, b := range books {
+		result = append(result, b.GetTitle())
+	}
+	return result
+}
+
+func TestBookService(t *testing.T) {
+	t.Parallel()
+
+	conn, _ := setupTestServer(t)
+	client := catalogv1.NewBookServiceClient(conn)
+	ctx := context.Background()
+
+	b, err := client.GetBook(ctx, &catalogv1.GetRequest{Id: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "Laskar Pelangi", b.GetTitle())
+	assert.Equal(t, &catalogv1.Reference{Id: 1, Name: "Bentang Pustaka"}, b.GetPublisher())
+	assert.Equal(t, []*catalogv1.CategoryReference{{Id: 1, Code: "FIC", Name: "Fiction"}}, b.GetCategories())
+
+	b, err = client.GetBookByISBN(ctx, &catalogv1.GetBookByISBNRequest{Isbn: "978-979-96257-0-0"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), b.GetId())
+
+	list, err := client.ListBooks(ctx, &catalogv1.ListBooksRequest{
+		Options: &catalogv1.ListOptions{PageSize: 1, Sort: "-year"},
+		Filter:  &catalogv1.BookFilter{Categories: []string{"FIC"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Laskar Pelangi"}, titles(list.GetBooks()))
+	assert.Equal(t, uint64(2), list.GetPage().GetTotal())
+	assert.NotEmpty(t, list.GetPage().GetNextCursor())
+
+	list, err = client.ListBooks(ctx, &catalogv1.ListBooksRequest{
+		Options: &catalogv1.ListOptions{PageSize: 1, Sort: "-year", Cursor: list.GetPage().GetNextCursor()},
+		Filter:  &catalogv1.BookFilter{Categories: []string{"FIC"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Supernova"}, titles(list.GetBooks()))
+}
+
+func TestStreams(t *testing.T) {
+	t.Parallel()
+
+	conn, _ := setupTestServer(t)
+	ctx := context.Background()
+
+	books, err := catalogv1.NewBookServiceClient(conn).StreamBooks(ctx, &catalogv1.StreamBooksRequest{Sort: "title"})
+	assert.NoError(t, err)
+	var streamed []*catalogv1.Book
+	for {
+		b, err := books.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		assert.NoError(t, err)
+		streamed = append(streamed, b)
+	}
+	assert.Equal(t, []string{"Laskar Pelangi", "Sang Pemimpi", "Supernova"}, titles(streamed))
+
+	authors, err := catalogv1.NewAuthorServiceClient(conn).StreamAuthors(ctx, &catalogv1.StreamAuthorsRequest{Filter: &catalogv1.NameFilter{Name: "Dee"}})
+	assert.NoError(t, err)
+	a, err := authors.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, "Dee Lestari", a.GetName())
+	_, err = authors.Recv()
+	assert.ErrorIs(t, err, io.EOF)
+
+	publishers, err := catalogv1.NewPublisherServiceClient(conn).StreamPublishers(ctx, &catalogv1.StreamPublishersRequest{Sort: "price"})
+	assert.NoError(t, err)
+	_, err = publishers.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestErrorCodes(t *testing.T) {
+	t.Parallel()
+
+	conn, _ := setupTestServer(t)
+	books := catalogv1.NewBookServiceClient(conn)
+	authors := catalogv1.NewAuthorServiceClient(conn)
+	categories := catalogv1.NewCategoryServiceClient(conn)
+
+	tests := []struct {
+		name         string
+		call         func(ctx context.Context) error
+		expectedCode codes.Code
+	}{
+		{
+			name: "Unknown Book",
+			call: func(ctx context.Context) error {
+				_, err := books.GetBook(ctx, &catalogv1.GetRequest{Id: 99})
+				return err
+			},
+			expectedCode: codes.NotFound,
+		},
+		{
+			name: "Missing ID",
+			call: func(ctx context.Context) error {
+				_, err := books.GetBook(ctx, &catalogv1.GetRequest{})
+				return err
+			},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "Cursor With Page",
+			call: func(ctx context.Context) error {
+				_, err := books.ListBooks(ctx, &catalogv1.ListBooksRequest{Options: &catalogv1.ListOptions{Page: 2, Cursor: "abc"}})
+				return err
+			},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "Update Without Version",
+			call: func(ctx context.Context) error {
+				_, err := authors.UpdateAuthor(ctx, &catalogv1.UpdateAuthorRequest{Id: 1, Author: &catalogv1.AuthorInput{Name: "Andrea"}})
+				return err
+			},
+			expectedCode: codes.FailedPrecondition,
+		},
+		{
+			name: "Update Stale Version",
+			call: func(ctx context.Context) error {
+				_, err := authors.UpdateAuthor(ctx, &catalogv1.UpdateAuthorRequest{Id: 1, Version: 7, Author: &catalogv1.AuthorInput{Name: "Andrea"}})
+				return err
+			},
+			expectedCode: codes.Aborted,
+		},
+		{
+			name: "Delete Author With Books",
+			call: func(ctx context.Context) error {
+				_, err := authors.DeleteAuthor(ctx, &catalogv1.DeleteRequest{Id: 2, Version: 1, Policy: catalogv1.DeletionPolicy_DELETION_POLICY_RESTRICT})
+				return err
+			},
+			expectedCode: codes.FailedPrecondition,
+		},
+		{
+			name: "Reassign Without Target",
+			call: func(ctx context.Context) error {
+				_, err := authors.DeleteAuthor(ctx, &catalogv1.DeleteRequest{Id: 2, Version: 1, Policy: catalogv1.DeletionPolicy_DELETION_POLICY_REASSIGN})
+				return err
+			},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "Duplicate Category Code",
+			call: func(ctx context.Context) error {
+				_, err := categories.CreateCategory(ctx, &catalogv1.CategoryInput{Code: "FIC", Name: "Fiction"})
+				return err
+			},
+			expectedCode: codes.FailedPrecondition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call(context.Background())
+			assert.Equal(t, tt.expectedCode, status.Code(err), err)
+		})
+	}
+}
+
+func TestErrorDetails(t *testing.T) {
+	t.Parallel()
+
+	conn, _ := setupTestServer(t)
+	_, err := catalogv1.NewBookServiceClient(conn).ListBooks(context.Background(), &catalogv1.ListBooksRequest{Options: &catalogv1.ListOptions{Sort: "price"}})
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Len(t, st.Details(), 1)
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	assert.True(t, ok)
+	assert.Equal(t, "sort", badRequest.GetFieldViolations()[0].GetField())
+}
+
+func TestMutations(t *testing.T) {
+	t.Parallel()
+
+	conn, changes := setupTestServer(t)
+	client := catalogv1.NewPublisherServiceClient(conn)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), rpc.ActorMetadata, "librarian")
+
+	created, err := client.CreatePublisher(ctx, &catalogv1.PublisherInput{Name: "Mizan"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), created.GetVersion())
+
+	updated, err := client.UpdatePublisher(ctx, &catalogv1.UpdatePublisherRequest{Id: created.GetId(), Version: created.GetVersion(), Publisher: &catalogv1.PublisherInput{Name: "Mizan Pustaka"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "Mizan Pustaka", updated.GetName())
+	assert.Equal(t, uint32(2), updated.GetVersion())
+
+	entries, err := changes.GetHistory(context.Background(), "publisher", uint(created.GetId()), pagination.Request{Page: 1, PageSize: 10}, sorting.Spec{{Name: "version", Desc: true}})
+	assert.NoError(t, err)
+	if assert.Len(t, entries.Data, 2) {
+		assert.Equal(t, "librarian", entries.Data[0].Actor)
+	}
+
+	long := metadata.AppendToOutgoingContext(context.Background(), rpc.ActorMetadata, strings.Repeat("a", history.MaxActorLength+1))
+	_, err = client.UpdatePublisher(long, &catalogv1.UpdatePublisherRequest{Id: created.GetId(), Version: updated.GetVersion(), Publisher: &catalogv1.PublisherInput{Name: "Mizan"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.DeletePublisher(ctx, &catalogv1.DeleteRequest{Id: created.GetId(), Version: updated.GetVersion()})
+	assert.NoError(t, err)
+	_, err = client.GetPublisher(ctx, &catalogv1.GetRequest{Id: created.GetId()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestHealthAndReflection(t *testing.T) {
+	t.Parallel()
+
+	conn, _ := setupTestServer(t)
+	ctx := context.Background()
+
+	for _, service := range []string{"", "catalog.v1.BookService", "catalog.v1.CategoryService"} {
+		resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		assert.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+	}
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	assert.NoError(t, err)
+	assert.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{}}))
+	resp, err := stream.Recv()
+	assert.NoError(t, err)
+	var services []string
+	for _, service := range resp.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+	assert.Subset(t, services, []string{"catalog.v1.BookService", "catalog.v1.AuthorService", "catalog.v1.PublisherService", "catalog.v1.CategoryService", "grpc.health.v1.Health"})
+}
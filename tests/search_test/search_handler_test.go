@@ -0,0 +1,144 @@
+package search_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/apperror"
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/pagination"
+	"github.com/tedysaputro/book-catalog-with-go/src/search"
+	"github.com/tedysaputro/book-catalog-with-go/tests/fixtures"
+)
+
+// setupTestApp serves the books of fixtures.NewCatalog, the second deleted, and Edensor, whose
+// description names the first
+func setupTestApp(t *testing.T) *fiber.App {
+	catalog := fixtures.NewCatalog(t)
+	ctx := context.Background()
+	assert.NoError(t, catalog.Books.Create(ctx, &book.Book{Title: "Edensor", Description: "The sequel to Laskar Pelangi", Pages: 288, Year: 2007, PublisherID: 1}))
+	deleted, err := catalog.Books.FindByID(ctx, 2)
+	assert.NoError(t, err)
+	assert.NoError(t, catalog.Books.SoftDelete(ctx, deleted))
+
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	search.NewSearchHandler(search.NewSearchService(search.NewMemorySearchRepository(catalog.Books), catalog.BookService())).RegisterRoutes(app)
+	return app
+}
+
+func TestSearch(t *testing.T) {
+	t.Parallel()
+
+	app := setupTestApp(t)
+
+	tests := []struct {
+		name               string
+		query              string
+		expectedStatus     int
+		expectedTitles     []string
+		expectedTotal      uint64
+		expectedHighlights []string
+		expectedSnippets   []string
+	}{
+		{
+			name:               "Title Ranks Above Description",
+			query:              "?q=laskar",
+			expectedStatus:     fiber.StatusOK,
+			expectedTitles:     []string{"Laskar Pelangi", "Edensor"},
+			expectedTotal:      2,
+			expectedHighlights: []string{"<b>Laskar</b> Pelangi", "Edensor"},
+			expectedSnippets:   []string{"", "The sequel to <b>Laskar</b> Pelangi"},
+		},
+		{
+			name:           "Author Names",
+			query:          "?q=Dee+Lestari",
+			expectedStatus: fiber.StatusOK,
+			expectedTitles: []string{"Laskar Pelangi", "Supernova"},
+			expectedTotal:  2,
+		},
+		{name: "Every Word Must Match", query: "?q=laskar+gramedia", expectedStatus: fiber.StatusOK, expectedTitles: []string{}},
+		{name: "Excluded Word", query: "?q=lestari+-pelangi", expectedStatus: fiber.StatusOK, expectedTitles: []string{"Supernova"}, expectedTotal: 1},
+		{name: "Second Page", query: "?q=pelangi&page=2&page_size=1", expectedStatus: fiber.StatusOK, expectedTitles: []string{"Edensor"}, expectedTotal: 2},
+		{name: "Deleted Book", query: "?q=pemimpi", expectedStatus: fiber.StatusOK, expectedTitles: []string{}},
+		{name: "Empty Query", query: "?q=+", expectedStatus: fiber.StatusBadRequest},
+		{name: "Missing Query", expectedStatus: fiber.StatusBadRequest},
+		{name: "Cursor", query: "?q=laskar&cursor=abc", expectedStatus: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/search"+tt.query, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			if tt.expectedStatus != fiber.StatusOK {
+				return
+			}
+
+			body, _ := io.ReadAll(resp.Body)
+			var response search.SearchListResponse
+			assert.NoError(t, json.Unmarshal(body, &response))
+			titles, highlights, snippets := []string{}, []string{}, []string{}
+			for _, result := range response.Data {
+				titles = append(titles, result.Book.Title)
+				highlights = append(highlights, result.Highlight)
+				snippets = append(snippets, result.Snippet)
+			}
+			assert.Equal(t, tt.expectedTitles, titles)
+			assert.Equal(t, tt.expectedTotal, response.Total)
+			if tt.expectedHighlights != nil {
+				assert.Equal(t, tt.expectedHighlights, highlights)
+				assert.Equal(t, tt.expectedSnippets, snippets)
+			}
+		})
+	}
+}
+
+func TestSearchRanks(t *testing.T) {
+	t.Parallel()
+
+	catalog := fixtures.NewCatalog(t)
+	results, total, err := search.NewMemorySearchRepository(catalog.Books).Search(context.Background(), "andrea", pagination.Request{Page: 1, PageSize: pagination.DefaultPageSize})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), total)
+	if assert.Len(t, results, 2) {
+		// Both books name the author, so they rank alike and keep the order of their IDs
+		assert.Equal(t, uint(1), results[0].BookID)
+		assert.Equal(t, uint(2), results[1].BookID)
+		assert.Equal(t, results[0].Rank, results[1].Rank)
+		assert.Positive(t, results[0].Rank)
+	}
+}
+
+// staleRepository answers every search with the results of an index that still holds deleted books
+type staleRepository struct {
+	results []search.Result
+}
+
+func (r staleRepository) Search(ctx context.Context, q string, page pagination.Request) ([]search.Result, uint64, error) {
+	return r.results, uint64(len(r.results)), nil
+}
+
+func TestSearchSkipsDeletedBooks(t *testing.T) {
+	t.Parallel()
+
+	catalog := fixtures.NewCatalog(t)
+	ctx := context.Background()
+	deleted, err := catalog.Books.FindByID(ctx, 2)
+	assert.NoError(t, err)
+	assert.NoError(t, catalog.Books.SoftDelete(ctx, deleted))
+
+	repo := staleRepository{results: []search.Result{{BookID: 2, Rank: 1}, {BookID: 1, Rank: 0.5}}}
+	response, err := search.NewSearchService(repo, catalog.BookService()).Search(ctx, "hirata", pagination.Request{Page: 1, PageSize: pagination.DefaultPageSize})
+	assert.NoError(t, err)
+	if assert.Len(t, response.Data, 1) {
+		assert.Equal(t, "Laskar Pelangi", response.Data[0].Book.Title)
+		assert.Equal(t, 0.5, response.Data[0].Rank)
+	}
+}
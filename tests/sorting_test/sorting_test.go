@@ -0,0 +1,72 @@
+package sorting_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/sorting"
+)
+
+func TestFromQuery(t *testing.T) {
+	tests := []struct {
+		name          string
+		sort          string
+		sortBy        string
+		direction     string
+		expectedSpec  string
+		expectedField string
+	}{
+		{
+			name:         "Default Sort",
+			expectedSpec: "id",
+		},
+		{
+			name:         "Multiple Fields",
+			sort:         "-year, title",
+			expectedSpec: "-year,title",
+		},
+		{
+			name:         "Related Field",
+			sort:         "publisher.name,-id",
+			expectedSpec: "publisher.name,-id",
+		},
+		{
+			name:         "Deprecated sortBy And direction",
+			sortBy:       "title",
+			direction:    "DESC",
+			expectedSpec: "-title",
+		},
+		{
+			name:          "Unknown Field",
+			sort:          "title;DROP TABLE books",
+			expectedField: "title;DROP TABLE books",
+		},
+		{
+			name:          "Duplicate Field",
+			sort:          "title,-title",
+			expectedField: "title",
+		},
+		{
+			name:          "Invalid Direction",
+			sortBy:        "title",
+			direction:     "sideways",
+			expectedField: "sideways",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := sorting.FromQuery(tt.sort, tt.sortBy, tt.direction, book.SortFields)
+			if tt.expectedField != "" {
+				var sortErr *sorting.Error
+				assert.ErrorAs(t, err, &sortErr)
+				assert.Equal(t, tt.expectedField, sortErr.Field)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedSpec, spec.String())
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package trash_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tedysaputro/book-catalog-with-go/src/author"
+	"github.com/tedysaputro/book-catalog-with-go/src/book"
+	"github.com/tedysaputro/book-catalog-with-go/src/category"
+	"github.com/tedysaputro/book-catalog-with-go/src/history"
+	"github.com/tedysaputro/book-catalog-with-go/src/publisher"
+	"github.com/tedysaputro/book-catalog-with-go/src/trash"
+	"github.com/tedysaputro/book-catalog-with-go/tests/fixtures"
+)
+
+func TestRetentionPurge(t *testing.T) {
+	ctx := context.Background()
+	catalog := fixtures.NewCatalog(t)
+
+	fiction, err := catalog.Categories.FindByID(ctx, 1)
+	assert.NoError(t, err)
+	assert.NoError(t, catalog.Categories.SoftDelete(ctx, fiction))
+	sangPemimpi, err := catalog.Books.FindByID(ctx, 2)
+	assert.NoError(t, err)
+	assert.NoError(t, catalog.Books.SoftDelete(ctx, sangPemimpi))
+	mizan := &publisher.Publisher{Name: "Mizan"}
+	assert.NoError(t, catalog.Publishers.Create(ctx, mizan))
+	assert.NoError(t, catalog.Publishers.SoftDelete(ctx, mizan))
+
+	// A deleted code is free again, and the category holding it can no longer be restored
+	assert.NoError(t, catalog.Categories.Create(ctx, &category.Category{Code: "FIC", Name: "Fiction"}))
+	deleted, err := catalog.Categories.FindDeletedByID(ctx, fiction.ID)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, catalog.Categories.Restore(ctx, deleted), category.ErrCodeTaken)
+
+	retention := trash.Retention{Period: 30 * 24 * time.Hour, Purgers: []trash.Purger{
+		catalog.BookService(), catalog.AuthorService(), catalog.CategoryService(), catalog.PublisherService(),
+	}}
+
+	purged, err := retention.Purge(ctx, time.Now())
+	assert.NoError(t, err)
+	assert.Zero(t, purged)
+
+	purged, err = retention.Purge(ctx, time.Now().Add(31*24*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
+
+	_, err = catalog.Books.FindDeletedByID(ctx, sangPemimpi.ID)
+	assert.ErrorIs(t, err, book.ErrBookNotInTrash)
+	_, err = catalog.Categories.FindDeletedByID(ctx, fiction.ID)
+	assert.ErrorIs(t, err, category.ErrCategoryNotInTrash)
+	_, err = catalog.Publishers.FindDeletedByID(ctx, mizan.ID)
+	assert.ErrorIs(t, err, publisher.ErrPublisherNotInTrash)
+
+	// Every purge is in the history, along with the change of the books losing the category
+	for _, tt := range []struct {
+		entityType      string
+		expectedActions []history.Action
+	}{
+		{entityType: "book", expectedActions: []history.Action{history.ActionPurge, history.ActionUpdate, history.ActionUpdate}},
+		{entityType: "category", expectedActions: []history.Action{history.ActionPurge}},
+		{entityType: "publisher", expectedActions: []history.Action{history.ActionPurge}},
+	} {
+		entries, err := catalog.History.FindSince(ctx, tt.entityType, time.Time{})
+		assert.NoError(t, err)
+		actions := []history.Action{}
+		for _, entry := range entries {
+			actions = append(actions, entry.Action)
+		}
+		assert.Equal(t, tt.expectedActions, actions, tt.entityType)
+	}
+	laskarPelangi, err := catalog.Books.FindByID(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), laskarPelangi.Version)
+	assert.Empty(t, laskarPelangi.CategoryIDs())
+}
+
+func TestRetentionPurgeAuthors(t *testing.T) {
+	ctx := context.Background()
+	authors := author.NewMemoryAuthorRepository()
+	publishers := publisher.NewMemoryPublisherRepository()
+	books := book.NewMemoryBookRepository(authors, publishers, category.NewMemoryCategoryRepository())
+	changes := history.NewHistoryService(history.NewMemoryHistoryRepository())
+	authorService := author.NewAuthorService(authors, book.NewAuthorDependents(books, changes), changes)
+
+	andrea := &author.Author{Name: "Andrea Hirata"}
+	dee := &author.Author{Name: "Dee Lestari"}
+	assert.NoError(t, publishers.Create(ctx, &publisher.Publisher{Name: "Bentang Pustaka"}))
+	assert.NoError(t, authors.Create(ctx, andrea))
+	assert.NoError(t, authors.Create(ctx, dee))
+	supernova := &book.Book{Title: "Supernova", Pages: 320, Year: 2001, PublisherID: 1, Authors: []author.Author{*andrea, *dee}}
+	assert.NoError(t, books.Create(ctx, supernova))
+	assert.NoError(t, authors.SoftDelete(ctx, dee))
+
+	retention := trash.Retention{Period: 30 * 24 * time.Hour, Purgers: []trash.Purger{authorService}}
+	purged, err := retention.Purge(ctx, time.Now().Add(31*24*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	// The book losing the author moves to a new version recorded in its history
+	stored, err := books.FindByID(ctx, supernova.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), stored.Version)
+	assert.Equal(t, []uint{andrea.ID}, stored.AuthorIDs())
+	var state book.BookDetailResponse
+	assert.NoError(t, changes.StateAtVersion(ctx, "book", supernova.ID, 2, &state))
+	_, err = authors.FindDeletedByID(ctx, dee.ID)
+	assert.ErrorIs(t, err, author.ErrAuthorNotInTrash)
+}